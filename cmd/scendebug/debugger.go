@@ -0,0 +1,391 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	vmi "github.com/multiversx/mx-chain-vm-common-go"
+	am "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec"
+	ei "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/interpreter"
+	er "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+const prompt = "(scendebug) "
+
+const helpText = `commands:
+  step [n]                 execute the next step, or the next n steps
+  continue                 execute steps until the next breakpoint, or the end of the scenario
+  list                     list the steps, marking the next one and the breakpoints
+  break <id|number>        stop before the step with the given tx id, or the given step number
+  delete <id|number>       remove a breakpoint
+  account <address>        print an account
+  storage <address> [key]  print the storage of an account, or a single key
+  esdt <address> [token]   print the ESDT balances of an account, or of a single token
+  query <address> <function> [arguments...]
+                           run an scQuery against the current state, without changing it
+  world                    print the whole state
+  restart                  start over, with a fresh state
+  quit                     leave the debugger
+addresses, keys and arguments are scenario expressions, e.g. "address:owner", "sc:adder", "str:key", "1000"`
+
+var errQuit = errors.New("quit")
+
+type scenarioDebugger struct {
+	scenarioPath  string
+	out           io.Writer
+	executor      *am.VMTestExecutor
+	steps         []*debugStep
+	nextStep      int
+	breakpoints   map[string]struct{}
+	interpreter   ei.ExprInterpreter
+	reconstructor er.ExprReconstructor
+}
+
+func newScenarioDebugger(scenarioPath string, out io.Writer) (*scenarioDebugger, error) {
+	debugger := &scenarioDebugger{
+		scenarioPath: scenarioPath,
+		out:          out,
+		breakpoints:  make(map[string]struct{}),
+	}
+	err := debugger.restart()
+	if err != nil {
+		return nil, err
+	}
+	return debugger, nil
+}
+
+// restart reloads the scenario, so changes to the files are picked up, and resets the state
+func (d *scenarioDebugger) restart() error {
+	steps, err := loadDebugSteps(d.scenarioPath)
+	if err != nil {
+		return err
+	}
+	executor, err := am.NewVMTestExecutor()
+	if err != nil {
+		return err
+	}
+	executor.SetTxOutputObserver(func(step *mj.TxStep, output *vmi.VMOutput) {
+		d.printTx(step.TxIdent, step.Tx)
+		d.printVMOutput(output)
+	})
+
+	d.steps = steps
+	d.executor = executor
+	d.nextStep = 0
+	d.interpreter = ei.ExprInterpreter{}
+	if len(steps) > 0 {
+		d.interpreter.FileResolver = steps[0].resolver
+	}
+	fmt.Fprintf(d.out, "loaded %s: %d steps\n", d.scenarioPath, len(steps))
+	return nil
+}
+
+func (d *scenarioDebugger) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(d.out, prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(d.out)
+			return scanner.Err()
+		}
+
+		err := d.execute(scanner.Text())
+		if err == errQuit {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintf(d.out, "error: %s\n", err.Error())
+		}
+	}
+}
+
+// execute runs a command line, as typed at the prompt; empty lines are ignored
+func (d *scenarioDebugger) execute(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	return d.command(fields[0], fields[1:])
+}
+
+func (d *scenarioDebugger) command(name string, args []string) error {
+	switch name {
+	case "step", "s":
+		count := 1
+		if len(args) > 0 {
+			var err error
+			count, err = strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid step count: %s", args[0])
+			}
+		}
+		for i := 0; i < count; i++ {
+			err := d.executeNextStep()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case "continue", "c":
+		return d.continueToBreakpoint()
+	case "list", "l":
+		d.listSteps()
+		return nil
+	case "break", "b":
+		if len(args) != 1 {
+			return errors.New("usage: break <id|number>")
+		}
+		d.addBreakpoint(args[0])
+		return nil
+	case "delete", "d":
+		if len(args) != 1 {
+			return errors.New("usage: delete <id|number>")
+		}
+		if _, exists := d.breakpoints[args[0]]; !exists {
+			return fmt.Errorf("no breakpoint %s", args[0])
+		}
+		delete(d.breakpoints, args[0])
+		return nil
+	case "account", "a":
+		if len(args) != 1 {
+			return errors.New("usage: account <address>")
+		}
+		return d.printAccount(args[0])
+	case "storage":
+		if len(args) < 1 || len(args) > 2 {
+			return errors.New("usage: storage <address> [key]")
+		}
+		return d.printStorage(args[0], args[1:])
+	case "esdt":
+		if len(args) < 1 || len(args) > 2 {
+			return errors.New("usage: esdt <address> [token]")
+		}
+		return d.printESDT(args[0], args[1:])
+	case "query", "q":
+		if len(args) < 2 {
+			return errors.New("usage: query <address> <function> [arguments...]")
+		}
+		return d.query(args[0], args[1], args[2:])
+	case "world", "w":
+		return d.executor.DumpWorld()
+	case "restart":
+		return d.restart()
+	case "help", "h":
+		fmt.Fprintln(d.out, helpText)
+		return nil
+	case "quit", "exit":
+		return errQuit
+	default:
+		return fmt.Errorf("unknown command %s, type \"help\" for the list of commands", name)
+	}
+}
+
+func (d *scenarioDebugger) executeNextStep() error {
+	if d.nextStep >= len(d.steps) {
+		return errors.New("the scenario has ended, \"restart\" to run it again")
+	}
+	step := d.steps[d.nextStep]
+	d.nextStep++
+
+	fmt.Fprintf(d.out, "step %d: %s\n", d.nextStep, step.description())
+	for _, scenario := range step.prepare {
+		err := d.executor.PrepareScenario(scenario, step.resolver)
+		if err != nil {
+			return err
+		}
+	}
+	d.interpreter.FileResolver = step.resolver
+
+	err := d.executor.ExecuteStep(step.step)
+	if err != nil {
+		return fmt.Errorf("step %d failed: %w", d.nextStep, err)
+	}
+	if d.nextStep == len(d.steps) {
+		fmt.Fprintln(d.out, "scenario completed")
+	}
+	return nil
+}
+
+func (d *scenarioDebugger) continueToBreakpoint() error {
+	for d.nextStep < len(d.steps) {
+		err := d.executeNextStep()
+		if err != nil {
+			return err
+		}
+		if d.nextStep < len(d.steps) && d.isBreakpoint(d.nextStep) {
+			fmt.Fprintf(d.out, "breakpoint before step %d: %s\n", d.nextStep+1, d.steps[d.nextStep].description())
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *scenarioDebugger) addBreakpoint(breakpoint string) {
+	d.breakpoints[strings.TrimSpace(breakpoint)] = struct{}{}
+}
+
+func (d *scenarioDebugger) isBreakpoint(stepIndex int) bool {
+	if _, exists := d.breakpoints[strconv.Itoa(stepIndex+1)]; exists {
+		return true
+	}
+	for _, id := range d.steps[stepIndex].ids() {
+		if _, exists := d.breakpoints[id]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *scenarioDebugger) listSteps() {
+	for stepIndex, step := range d.steps {
+		marker := "  "
+		if stepIndex == d.nextStep {
+			marker = "->"
+		}
+		breakpointMarker := " "
+		if d.isBreakpoint(stepIndex) {
+			breakpointMarker = "*"
+		}
+		fmt.Fprintf(d.out, "%s%s %3d  %s\n", marker, breakpointMarker, stepIndex+1, step.description())
+	}
+}
+
+func (d *scenarioDebugger) interpret(expression string) ([]byte, error) {
+	value, err := d.interpreter.InterpretString(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %s: %w", expression, err)
+	}
+	return value, nil
+}
+
+func (d *scenarioDebugger) scenarioAccount(addressExpression string) (*mj.Account, error) {
+	address, err := d.interpret(addressExpression)
+	if err != nil {
+		return nil, err
+	}
+	account, err := d.executor.ScenarioAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account %s does not exist", addressExpression)
+	}
+	return account, nil
+}
+
+func (d *scenarioDebugger) printAccount(addressExpression string) error {
+	account, err := d.scenarioAccount(addressExpression)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(d.out, oj.JSONString(mjwrite.AccountsToOJ([]*mj.Account{account})))
+	return nil
+}
+
+func (d *scenarioDebugger) printStorage(addressExpression string, keyExpressions []string) error {
+	address, err := d.interpret(addressExpression)
+	if err != nil {
+		return err
+	}
+	account := d.executor.World.AcctMap.GetAccount(address)
+	if account == nil {
+		return fmt.Errorf("account %s does not exist", addressExpression)
+	}
+
+	if len(keyExpressions) > 0 {
+		key, err := d.interpret(keyExpressions[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(d.out, d.reconstructor.Reconstruct(account.StorageValue(string(key)), er.NoHint))
+		return nil
+	}
+
+	var keys []string
+	for key, value := range account.Storage {
+		if len(value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(d.out, "%s: %s\n",
+			d.reconstructor.Reconstruct([]byte(key), er.NoHint),
+			d.reconstructor.Reconstruct(account.Storage[key], er.NoHint))
+	}
+	return nil
+}
+
+func (d *scenarioDebugger) printESDT(addressExpression string, tokenExpressions []string) error {
+	account, err := d.scenarioAccount(addressExpression)
+	if err != nil {
+		return err
+	}
+
+	var token []byte
+	if len(tokenExpressions) > 0 {
+		token, err = d.interpret(tokenExpressions[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, esdtData := range account.ESDTData {
+		if token != nil && string(esdtData.TokenIdentifier.Value) != string(token) {
+			continue
+		}
+		for _, instance := range esdtData.Instances {
+			fmt.Fprintf(d.out, "%s nonce %s: %s\n",
+				esdtData.TokenIdentifier.Original,
+				instance.Nonce.Original,
+				instance.Balance.Original)
+		}
+		if esdtData.LastNonce.Value > 0 {
+			fmt.Fprintf(d.out, "%s last nonce: %s\n", esdtData.TokenIdentifier.Original, esdtData.LastNonce.Original)
+		}
+		if len(esdtData.Roles) > 0 {
+			fmt.Fprintf(d.out, "%s roles: %s\n", esdtData.TokenIdentifier.Original, strings.Join(esdtData.Roles, ", "))
+		}
+	}
+	return nil
+}
+
+func (d *scenarioDebugger) query(addressExpression string, function string, argumentExpressions []string) error {
+	address, err := d.interpret(addressExpression)
+	if err != nil {
+		return err
+	}
+	arguments := make([]mj.JSONBytesFromTree, len(argumentExpressions))
+	for i, argumentExpression := range argumentExpressions {
+		argument, err := d.interpret(argumentExpression)
+		if err != nil {
+			return err
+		}
+		arguments[i] = mj.JSONBytesFromTree{
+			Value:    argument,
+			Original: &oj.OJsonString{Value: argumentExpression},
+		}
+	}
+
+	tx := &mj.Transaction{
+		Type:      mj.ScQuery,
+		To:        mj.JSONBytesFromString{Value: address, Original: addressExpression},
+		Function:  function,
+		Arguments: arguments,
+	}
+	d.printTx("query", tx)
+	output, err := d.executor.ExecuteQuery("query", tx)
+	if err != nil {
+		return err
+	}
+	d.printVMOutput(output)
+	return nil
+}
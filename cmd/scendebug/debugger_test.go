@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const debugTestScenario = `{
+	"steps": [
+		{
+			"step": "setState",
+			"accounts": {
+				"address:A": { "nonce": "0", "balance": "1000" },
+				"address:B": { "nonce": "0", "balance": "0" }
+			}
+		},
+		{
+			"step": "transfer",
+			"txId": "first",
+			"tx": { "from": "address:A", "to": "address:B", "value": "100" }
+		},
+		{
+			"step": "transfer",
+			"txId": "second",
+			"tx": { "from": "address:A", "to": "address:B", "value": "200" }
+		},
+		{
+			"step": "checkState",
+			"accounts": {
+				"address:A": { "nonce": "2", "balance": "700" },
+				"address:B": { "nonce": "0", "balance": "300" }
+			}
+		}
+	]
+}`
+
+func newTestDebugger(t *testing.T) (*scenarioDebugger, *bytes.Buffer) {
+	scenarioPath := filepath.Join(t.TempDir(), "debug.scen.json")
+	err := os.WriteFile(scenarioPath, []byte(debugTestScenario), 0644)
+	require.Nil(t, err)
+
+	out := &bytes.Buffer{}
+	debugger, err := newScenarioDebugger(scenarioPath, out)
+	require.Nil(t, err)
+	return debugger, out
+}
+
+func requireBalance(t *testing.T, debugger *scenarioDebugger, addressExpression string, balance int64) {
+	address, err := debugger.interpret(addressExpression)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(balance), debugger.executor.World.AcctMap.GetAccount(address).Balance)
+}
+
+func TestScenarioDebugger_BreakpointOnStepId(t *testing.T) {
+	debugger, out := newTestDebugger(t)
+
+	require.Nil(t, debugger.execute("break second"))
+	require.Nil(t, debugger.execute("continue"))
+	require.Equal(t, 2, debugger.nextStep)
+	require.Contains(t, out.String(), "breakpoint before step 3: debug.scen.json step 3 (transfer) [second]")
+	requireBalance(t, debugger, "address:B", 100)
+
+	require.Nil(t, debugger.execute("continue"))
+	require.Equal(t, 4, debugger.nextStep)
+	require.Contains(t, out.String(), "scenario completed")
+	requireBalance(t, debugger, "address:B", 300)
+
+	require.NotNil(t, debugger.execute("step"))
+}
+
+func TestScenarioDebugger_BreakpointOnStepNumber(t *testing.T) {
+	debugger, out := newTestDebugger(t)
+
+	require.Nil(t, debugger.execute("break 2"))
+	require.Nil(t, debugger.execute("break 4"))
+	require.Nil(t, debugger.execute("delete 4"))
+	require.NotNil(t, debugger.execute("delete 4"))
+
+	require.Nil(t, debugger.execute("list"))
+	require.Contains(t, out.String(), "->    1  debug.scen.json step 1 (setState)")
+	require.Contains(t, out.String(), "  *   2  debug.scen.json step 2 (transfer) [first]")
+
+	require.Nil(t, debugger.execute("continue"))
+	require.Equal(t, 1, debugger.nextStep)
+	require.Nil(t, debugger.execute("continue"))
+	require.Equal(t, 4, debugger.nextStep)
+}
+
+func TestScenarioDebugger_Query(t *testing.T) {
+	// "file:" values are relative to the scenario
+	scenarioDir := t.TempDir()
+	adderPath, err := filepath.Abs("../../test/adder/output/adder.wasm")
+	require.Nil(t, err)
+	adderPath, err = filepath.Rel(scenarioDir, adderPath)
+	require.Nil(t, err)
+	scenarioPath := filepath.Join(scenarioDir, "query.scen.json")
+	err = os.WriteFile(scenarioPath, []byte(`{
+		"steps": [
+			{
+				"step": "setState",
+				"accounts": {
+					"address:owner": { "nonce": "0", "balance": "0" },
+					"sc:adder": {
+						"nonce": "0",
+						"balance": "0",
+						"storage": { "str:sum": "5" },
+						"code": "file:`+adderPath+`",
+						"owner": "address:owner"
+					}
+				}
+			}
+		]
+	}`), 0644)
+	require.Nil(t, err)
+
+	out := &bytes.Buffer{}
+	debugger, err := newScenarioDebugger(scenarioPath, out)
+	require.Nil(t, err)
+	require.Nil(t, debugger.execute("step"))
+
+	out.Reset()
+	require.Nil(t, debugger.execute("query sc:adder getSum"))
+	require.Contains(t, out.String(), "tx query: to sc:adder getSum()")
+	require.Contains(t, out.String(), "status: 0 (ok)")
+	require.Contains(t, out.String(), "out: [0x05 (5)]")
+
+	// the query does not change the state
+	require.Nil(t, debugger.execute("storage sc:adder str:sum"))
+	require.Contains(t, out.String(), "0x05 (5)\n")
+}
+
+func TestScenarioDebugger_QueryErrors(t *testing.T) {
+	debugger, _ := newTestDebugger(t)
+	require.Nil(t, debugger.execute("step"))
+
+	// only accounts with code can be queried, and the arguments are scenario expressions
+	require.NotNil(t, debugger.execute("query address:B getSum"))
+	require.NotNil(t, debugger.execute("query unknown:B getSum"))
+	require.NotNil(t, debugger.execute("query address:B getSum unknown:5"))
+	require.NotNil(t, debugger.execute("query address:B"))
+	require.Equal(t, 1, debugger.nextStep)
+}
+
+func TestScenarioDebugger_ScriptedRun(t *testing.T) {
+	debugger, out := newTestDebugger(t)
+
+	script := strings.Join([]string{
+		"step",
+		"",
+		"frobnicate",
+		"account address:A",
+		"restart",
+		"quit",
+		"step",
+	}, "\n")
+	err := debugger.run(strings.NewReader(script))
+	require.Nil(t, err)
+
+	output := out.String()
+	require.Contains(t, output, "step 1: debug.scen.json step 1 (setState)")
+	require.Contains(t, output, `error: unknown command frobnicate`)
+	require.Contains(t, output, `"address:A": {`)
+	require.Equal(t, 2, strings.Count(output, "loaded "))
+	// the commands after quit are not run
+	require.Equal(t, 0, debugger.nextStep)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals success
+	ErrCodeSuccess = iota
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+)
+
+type cliArguments struct {
+	Breakpoints string
+}
+
+func main() {
+	app := initializeCLI()
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(ErrCodeSuccess)
+}
+
+func initializeCLI() *cli.App {
+	app := cli.NewApp()
+	app.Name = "scendebug"
+	app.Usage = "steps through a scenario interactively; type \"help\" at the prompt for the commands"
+	app.ArgsUsage = "<scenario file>"
+
+	args := &cliArguments{}
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:        "break",
+			Value:       "",
+			Usage:       "comma-separated breakpoints, as step ids or step numbers",
+			Destination: &args.Breakpoints,
+		},
+	}
+
+	app.Authors = []cli.Author{
+		{
+			Name:  "The MultiversX Team",
+			Email: "contact@multiversx.com",
+		},
+	}
+
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() != 1 {
+			return fmt.Errorf("exactly one argument expected - the scenario file")
+		}
+
+		debugger, err := newScenarioDebugger(context.Args().First(), os.Stdout)
+		if err != nil {
+			return err
+		}
+		if len(args.Breakpoints) > 0 {
+			for _, breakpoint := range strings.Split(args.Breakpoints, ",") {
+				debugger.addBreakpoint(breakpoint)
+			}
+		}
+
+		return debugger.run(os.Stdin)
+	}
+
+	return app
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	vmi "github.com/multiversx/mx-chain-vm-common-go"
+	er "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+func (d *scenarioDebugger) printTx(txIdent string, tx *mj.Transaction) {
+	var arguments []string
+	for _, argument := range tx.Arguments {
+		arguments = append(arguments, oj.JSONString(argument.Original))
+	}
+
+	fmt.Fprintf(d.out, "  tx %s:", txIdent)
+	if tx.Type.HasSender() {
+		fmt.Fprintf(d.out, " from %s", tx.From.Original)
+	}
+	if tx.Type.HasReceiver() {
+		fmt.Fprintf(d.out, " to %s", tx.To.Original)
+	}
+	if len(tx.Value.Original) > 0 {
+		fmt.Fprintf(d.out, " value %s", tx.Value.Original)
+	}
+	if tx.Type.HasFunction() {
+		fmt.Fprintf(d.out, " %s(%s)", tx.Function, strings.Join(arguments, ", "))
+	} else if len(arguments) > 0 {
+		fmt.Fprintf(d.out, " arguments (%s)", strings.Join(arguments, ", "))
+	}
+	fmt.Fprintln(d.out)
+}
+
+func (d *scenarioDebugger) printVMOutput(output *vmi.VMOutput) {
+	fmt.Fprintf(d.out, "  status: %d (%s)", output.ReturnCode, output.ReturnCode.String())
+	if len(output.ReturnMessage) > 0 {
+		fmt.Fprintf(d.out, ", message: %s", output.ReturnMessage)
+	}
+	fmt.Fprintln(d.out)
+
+	var results []string
+	for _, result := range output.ReturnData {
+		results = append(results, d.reconstructor.Reconstruct(result, er.NoHint))
+	}
+	fmt.Fprintf(d.out, "  out: [%s]\n", strings.Join(results, ", "))
+	fmt.Fprintf(d.out, "  gas remaining: %d, refund: %s\n", output.GasRemaining, output.GasRefund.String())
+
+	for _, logEntry := range output.Logs {
+		var topics []string
+		for _, topic := range logEntry.Topics {
+			topics = append(topics, d.reconstructor.Reconstruct(topic, er.NoHint))
+		}
+		fmt.Fprintf(d.out, "  log %s from %s, topics: [%s]\n",
+			d.reconstructor.Reconstruct(logEntry.Identifier, er.StrHint),
+			d.reconstructor.Reconstruct(logEntry.Address, er.AddressHint),
+			strings.Join(topics, ", "))
+	}
+
+	var addresses []string
+	for address := range output.OutputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		d.printOutputAccount(output.OutputAccounts[address])
+	}
+}
+
+func (d *scenarioDebugger) printOutputAccount(outputAccount *vmi.OutputAccount) {
+	var changes []string
+	if outputAccount.BalanceDelta != nil && outputAccount.BalanceDelta.Sign() != 0 {
+		changes = append(changes, "balance "+outputAccount.BalanceDelta.String())
+	}
+
+	var keys []string
+	for key, update := range outputAccount.StorageUpdates {
+		if update.Written {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		changes = append(changes, fmt.Sprintf("%s = %s",
+			d.reconstructor.Reconstruct([]byte(key), er.NoHint),
+			d.reconstructor.Reconstruct(outputAccount.StorageUpdates[key].Data, er.NoHint)))
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(d.out, "  %s: %s\n",
+		d.reconstructor.Reconstruct(outputAccount.Address, er.AddressHint),
+		strings.Join(changes, ", "))
+}
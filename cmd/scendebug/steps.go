@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/parse"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+// debugStep is a step of the scenario, or of one of its external steps files, which are inlined,
+// so they can be stepped through as well
+type debugStep struct {
+	location string
+	step     mj.Step
+
+	// prepare holds the files starting with this step, whose configuration is applied before executing it
+	prepare  []*mj.Scenario
+	resolver fr.FileResolver
+}
+
+// ids yields the names a breakpoint can refer to the step by: its tx id, or the tx ids inside a block
+func (ds *debugStep) ids() []string {
+	switch step := ds.step.(type) {
+	case *mj.TxStep:
+		return []string{step.TxIdent}
+	case *mj.BlockStep:
+		var ids []string
+		for _, blockStep := range step.Steps {
+			if txStep, isTx := blockStep.(*mj.TxStep); isTx {
+				ids = append(ids, txStep.TxIdent)
+			}
+		}
+		return ids
+	default:
+		return nil
+	}
+}
+
+func (ds *debugStep) description() string {
+	description := fmt.Sprintf("%s (%s)", ds.location, ds.step.StepTypeName())
+	if ids := ds.ids(); len(ids) > 0 {
+		description += fmt.Sprintf(" %v", ids)
+	}
+	return description
+}
+
+// loadDebugSteps parses a scenario file, inlining its external steps
+func loadDebugSteps(scenarioPath string) ([]*debugStep, error) {
+	absolutePath, err := filepath.Abs(scenarioPath)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(absolutePath)
+	if err != nil {
+		return nil, err
+	}
+	resolver := fr.NewDefaultFileResolver()
+	resolver.SetContext(absolutePath)
+
+	return parseDebugSteps(filepath.Base(scenarioPath), oj.FormatFromPath(scenarioPath), contents, resolver)
+}
+
+func parseDebugSteps(fileName string, format oj.Format, contents []byte, resolver fr.FileResolver) ([]*debugStep, error) {
+	parser := mjparse.NewParser(resolver)
+	scenario, err := parser.ParseScenarioFileInFormat(contents, format)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", fileName, err)
+	}
+
+	var debugSteps []*debugStep
+	for stepIndex, generalStep := range scenario.Steps {
+		location := fmt.Sprintf("%s step %d", fileName, stepIndex+1)
+
+		externalStep, isExternal := generalStep.(*mj.ExternalStepsStep)
+		if !isExternal {
+			debugSteps = append(debugSteps, &debugStep{
+				location: location,
+				step:     generalStep,
+				resolver: resolver,
+			})
+			continue
+		}
+
+		externalContents, externalResolver, err := resolver.ResolveExternalSteps(externalStep.Path, externalStep.ParametersMap())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		externalSteps, err := parseDebugSteps(
+			fmt.Sprintf("%s > %s", location, externalStep.Path),
+			oj.FormatFromPath(externalStep.Path),
+			externalContents,
+			externalResolver)
+		if err != nil {
+			return nil, err
+		}
+		debugSteps = append(debugSteps, externalSteps...)
+	}
+
+	if len(debugSteps) > 0 {
+		debugSteps[0].prepare = append([]*mj.Scenario{scenario}, debugSteps[0].prepare...)
+	}
+	return debugSteps, nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/parse"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+const scenarioSuffix = ".scen.json"
+
+// formatFileResolver resolves "file:" values leniently: formatting and linting do not need the contents,
+// so missing files (e.g. contracts that were not built) resolve to nothing
+type formatFileResolver struct {
+	*fr.DefaultFileResolver
+}
+
+func newFormatFileResolver() *formatFileResolver {
+	return &formatFileResolver{DefaultFileResolver: fr.NewDefaultFileResolver()}
+}
+
+// Clone creates new instance of the same type.
+func (resolver *formatFileResolver) Clone() fr.FileResolver {
+	return &formatFileResolver{DefaultFileResolver: resolver.DefaultFileResolver.Clone().(*fr.DefaultFileResolver)}
+}
+
+// ResolveFileValue yields the file contents, or nothing if the file does not exist.
+func (resolver *formatFileResolver) ResolveFileValue(value string) ([]byte, error) {
+	contents, err := resolver.DefaultFileResolver.ResolveFileValue(value)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []byte{}, nil
+	}
+	return contents, err
+}
+
+// ResolveExternalSteps loads an external steps file, keeping the resolver lenient inside it.
+func (resolver *formatFileResolver) ResolveExternalSteps(value string, parameters map[string]string) ([]byte, fr.FileResolver, error) {
+	contents, externalResolver, err := resolver.DefaultFileResolver.ResolveExternalSteps(value, parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return contents, &formatFileResolver{DefaultFileResolver: externalResolver.(*fr.DefaultFileResolver)}, nil
+}
+
+type scenarioFile struct {
+	path        string
+	contents    []byte
+	formatted   []byte
+	hasComments bool
+	scenario    *mj.Scenario
+	resolver    fr.FileResolver
+}
+
+// collectScenarioPaths expands the directories to the scenarios they contain, in any format
+func collectScenarioPaths(args []string) ([]string, error) {
+	var scenarioPaths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			scenarioPaths = append(scenarioPaths, arg)
+			continue
+		}
+
+		err = filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && oj.HasSuffixInAnyFormat(path, scenarioSuffix) {
+				scenarioPaths = append(scenarioPaths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scenarioPaths, nil
+}
+
+// loadScenarioFile parses a scenario and serializes it back, in the same format, which yields its canonical form.
+// The canonical form has no comments, so JSON5 and YAML files with comments are never rewritten.
+func loadScenarioFile(path string) (*scenarioFile, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(absolutePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newFormatFileResolver()
+	resolver.SetContext(absolutePath)
+	parser := mjparse.NewParser(resolver)
+	format := oj.FormatFromPath(absolutePath)
+	scenario, err := parser.ParseScenarioFileInFormat(contents, format)
+	if err != nil {
+		return nil, err
+	}
+	hasComments, err := oj.HasComments(contents, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scenarioFile{
+		path:        path,
+		contents:    contents,
+		formatted:   []byte(mjwrite.ScenarioToString(scenario, format)),
+		hasComments: hasComments,
+		scenario:    scenario,
+		resolver:    resolver,
+	}, nil
+}
+
+func (file *scenarioFile) isCanonical() bool {
+	return bytes.Equal(file.contents, file.formatted)
+}
+
+func (file *scenarioFile) rewrite() error {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file.path, file.formatted, info.Mode())
+}
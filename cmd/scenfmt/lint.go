@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/parse"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+// knownAccount is an account that exists in the world, with the step that set or deployed it
+type knownAccount struct {
+	name     string
+	location string
+	checked  bool
+}
+
+type newAddressMockUsage struct {
+	mock     *mj.NewAddressMock
+	location string
+	used     bool
+}
+
+// scenarioLinter walks the steps in execution order, following external steps,
+// keeping track of the accounts that exist and of the new address mocks
+type scenarioLinter struct {
+	findings        []string
+	accounts        map[string]*knownAccount
+	accountOrder    []string
+	newAddressMocks []*newAddressMockUsage
+	hasCheckState   bool
+}
+
+// lintScenario yields the problems found in a scenario; unknown fields are already rejected by the parser
+func lintScenario(file *scenarioFile) []string {
+	linter := &scenarioLinter{
+		accounts: make(map[string]*knownAccount),
+	}
+	linter.lintSteps(file.scenario.Steps, "", file.resolver)
+
+	for _, usage := range linter.newAddressMocks {
+		if !usage.used {
+			linter.addFinding(usage.location, "newAddresses entry for creator %s, nonce %s is never used by an scDeploy",
+				usage.mock.CreatorAddress.Original, usage.mock.CreatorNonce.Original)
+		}
+	}
+
+	// scenarios that never check the state are not expected to check every account
+	if linter.hasCheckState {
+		for _, key := range linter.accountOrder {
+			account := linter.accounts[key]
+			if !account.checked {
+				linter.addFinding(account.location, "account %s is never checked", account.name)
+			}
+		}
+	}
+
+	return linter.findings
+}
+
+func (linter *scenarioLinter) addFinding(location string, format string, args ...interface{}) {
+	linter.findings = append(linter.findings, location+": "+fmt.Sprintf(format, args...))
+}
+
+func (linter *scenarioLinter) addAccount(address mj.JSONBytesFromString, location string) {
+	key := string(address.Value)
+	if _, exists := linter.accounts[key]; exists {
+		return
+	}
+	linter.accounts[key] = &knownAccount{
+		name:     address.Original,
+		location: location,
+	}
+	linter.accountOrder = append(linter.accountOrder, key)
+}
+
+func (linter *scenarioLinter) lintSteps(steps []mj.Step, locationPrefix string, resolver fr.FileResolver) {
+	for stepIndex, generalStep := range steps {
+		location := fmt.Sprintf("%sstep %d (%s)", locationPrefix, stepIndex+1, generalStep.StepTypeName())
+
+		switch step := generalStep.(type) {
+		case *mj.ExternalStepsStep:
+			linter.lintExternalSteps(step, location, resolver)
+		case *mj.SetStateStep:
+			for _, account := range step.Accounts {
+				linter.addAccount(account.Address, location)
+			}
+			for _, mock := range step.NewAddressMocks {
+				linter.newAddressMocks = append(linter.newAddressMocks, &newAddressMockUsage{
+					mock:     mock,
+					location: location,
+				})
+			}
+		case *mj.BlockStep:
+			linter.lintSteps(step.Steps, location+" > ", resolver)
+		case *mj.TxStep:
+			if step.Tx.Type == mj.ScDeploy {
+				linter.lintDeploy(step.Tx, location)
+			}
+		case *mj.CheckStateStep:
+			linter.lintCheckState(step, location)
+		}
+	}
+}
+
+func (linter *scenarioLinter) lintExternalSteps(step *mj.ExternalStepsStep, location string, resolver fr.FileResolver) {
+	contents, externalResolver, err := resolver.ResolveExternalSteps(step.Path, step.ParametersMap())
+	if err != nil {
+		linter.addFinding(location, "cannot follow external steps: %s", err.Error())
+		return
+	}
+	parser := mjparse.NewParser(externalResolver)
+	externalScenario, err := parser.ParseScenarioFileInFormat(contents, oj.FormatFromPath(step.Path))
+	if err != nil {
+		linter.addFinding(location, "error parsing external steps %s: %s", step.Path, err.Error())
+		return
+	}
+	linter.lintSteps(externalScenario.Steps, fmt.Sprintf("%s > %s ", location, step.Path), externalResolver)
+}
+
+// lintDeploy marks the new address mocks of the deployer as used, the mocked address then exists
+func (linter *scenarioLinter) lintDeploy(tx *mj.Transaction, location string) {
+	for _, usage := range linter.newAddressMocks {
+		if usage.used || string(usage.mock.CreatorAddress.Value) != string(tx.From.Value) {
+			continue
+		}
+		usage.used = true
+		linter.addAccount(usage.mock.NewAddress, location)
+		return
+	}
+}
+
+func (linter *scenarioLinter) lintCheckState(step *mj.CheckStateStep, location string) {
+	linter.hasCheckState = true
+	if step.CheckAccounts == nil {
+		return
+	}
+
+	listed := make(map[string]struct{}, len(step.CheckAccounts.Accounts))
+	for _, checkAccount := range step.CheckAccounts.Accounts {
+		key := string(checkAccount.Address.Value)
+		listed[key] = struct{}{}
+		if account, exists := linter.accounts[key]; exists {
+			account.checked = true
+		}
+	}
+
+	if step.CheckAccounts.MoreAccountsAllowed {
+		return
+	}
+
+	var missing []string
+	for _, key := range linter.accountOrder {
+		if _, isListed := listed[key]; !isListed {
+			missing = append(missing, linter.accounts[key].name)
+		}
+	}
+	for _, name := range missing {
+		linter.addFinding(location, `checkState without "+" does not list account %s, which exists by then`, name)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const lintSetStateAB = `{
+	"step": "setState",
+	"accounts": {
+		"address:A": { "nonce": "0", "balance": "100" },
+		"address:B": { "nonce": "0", "balance": "0" }
+	}
+}`
+
+func TestLintScenario_Rules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		steps    []string
+		findings []string
+	}{
+		{
+			name: "all accounts checked",
+			steps: []string{lintSetStateAB, `{
+				"step": "checkState",
+				"accounts": {
+					"address:A": { "balance": "100" },
+					"address:B": { "balance": "0" }
+				}
+			}`},
+		},
+		{
+			name:  "never checks the state",
+			steps: []string{lintSetStateAB},
+		},
+		{
+			name: "unknown field",
+			steps: []string{`{
+				"step": "setState",
+				"accounts": {
+					"address:A": { "nonce": "0", "balanse": "100" }
+				}
+			}`},
+			findings: []string{`scenario: error processing steps: cannot parse set state step: unknown account field: balanse`},
+		},
+		{
+			name: "unused newAddresses",
+			steps: []string{`{
+				"step": "setState",
+				"accounts": {
+					"address:A": { "nonce": "0", "balance": "0" }
+				},
+				"newAddresses": [
+					{ "creatorAddress": "address:A", "creatorNonce": "0", "newAddress": "sc:contract" }
+				]
+			}`},
+			findings: []string{`scenario: step 1 (setState): newAddresses entry for creator address:A, nonce 0 is never used by an scDeploy`},
+		},
+		{
+			name: "used newAddresses",
+			steps: []string{`{
+				"step": "setState",
+				"accounts": {
+					"address:A": { "nonce": "0", "balance": "0" }
+				},
+				"newAddresses": [
+					{ "creatorAddress": "address:A", "creatorNonce": "0", "newAddress": "sc:contract" }
+				]
+			}`, `{
+				"step": "scDeploy",
+				"txId": "deploy",
+				"tx": {
+					"from": "address:A",
+					"contractCode": "file:missing.wasm",
+					"arguments": [],
+					"gasLimit": "1,000,000",
+					"gasPrice": "0"
+				}
+			}`},
+		},
+		{
+			name: "account never checked",
+			steps: []string{lintSetStateAB, `{
+				"step": "checkState",
+				"accounts": {
+					"address:A": { "balance": "100" },
+					"+": ""
+				}
+			}`},
+			findings: []string{`scenario: step 1 (setState): account address:B is never checked`},
+		},
+		{
+			name: "checkState without +",
+			steps: []string{lintSetStateAB, `{
+				"step": "checkState",
+				"accounts": {
+					"address:A": { "balance": "100" }
+				}
+			}`},
+			findings: []string{
+				`scenario: step 2 (checkState): checkState without "+" does not list account address:B, which exists by then`,
+				`scenario: step 1 (setState): account address:B is never checked`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scenarioPath := writeTestScenario(t, t.TempDir(), "lint.scen.json",
+				`{ "steps": [`+strings.Join(testCase.steps, ",")+`] }`)
+
+			output := &bytes.Buffer{}
+			exitCode, err := processScenarios([]string{scenarioPath}, &cliArguments{Lint: true}, output)
+			require.Nil(t, err)
+
+			findings := strings.Split(strings.TrimSpace(strings.ReplaceAll(output.String(), scenarioPath, "scenario")), "\n")
+			if len(testCase.findings) == 0 {
+				require.Equal(t, ErrCodeSuccess, exitCode)
+				require.Equal(t, "", output.String())
+				return
+			}
+			require.Equal(t, ErrCodeLintFindings, exitCode)
+			require.Equal(t, testCase.findings, findings)
+		})
+	}
+}
+
+func writeTestScenario(t *testing.T, dir string, name string, contents string) string {
+	scenarioPath := filepath.Join(dir, name)
+	err := os.WriteFile(scenarioPath, []byte(contents), 0644)
+	require.Nil(t, err)
+	return scenarioPath
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals success
+	ErrCodeSuccess = iota
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+	// ErrCodeNotFormatted signals that some files are not in canonical form (--check),
+	// or could not be rewritten, because their comments would be lost
+	ErrCodeNotFormatted
+	// ErrCodeLintFindings signals that the linter found problems (--lint)
+	ErrCodeLintFindings
+)
+
+type cliArguments struct {
+	Check bool
+	Lint  bool
+}
+
+func main() {
+	app := initializeCLI()
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(ErrCodeSuccess)
+}
+
+func initializeCLI() *cli.App {
+	app := cli.NewApp()
+	app.Name = "scenfmt"
+	app.Usage = "rewrites scenario files (.scen.json, .scen.json5, .scen.yaml) in canonical form, except JSON5 and YAML files with comments; with --check and --lint, only reports, without rewriting"
+	app.ArgsUsage = "<file or directory>..."
+
+	args := &cliArguments{}
+
+	app.Flags = []cli.Flag{
+		cli.BoolFlag{
+			Name:        "check",
+			Usage:       "list the files that are not in canonical form, instead of rewriting them",
+			Destination: &args.Check,
+		},
+		cli.BoolFlag{
+			Name:        "lint",
+			Usage:       "report unknown fields, unused newAddresses, accounts that are never checked and checkState steps that would miss accounts",
+			Destination: &args.Lint,
+		},
+	}
+
+	app.Authors = []cli.Author{
+		{
+			Name:  "The MultiversX Team",
+			Email: "contact@multiversx.com",
+		},
+	}
+
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() == 0 {
+			return fmt.Errorf("at least one argument expected - the scenario files or the directories containing them")
+		}
+
+		scenarioPaths, err := collectScenarioPaths(context.Args())
+		if err != nil {
+			return err
+		}
+
+		exitCode, err := processScenarios(scenarioPaths, args, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if exitCode != ErrCodeSuccess {
+			os.Exit(exitCode)
+		}
+		return nil
+	}
+
+	return app
+}
+
+// processScenarios formats, checks or lints the scenarios, reporting to the output, and yields the exit code
+func processScenarios(scenarioPaths []string, args *cliArguments, output io.Writer) (int, error) {
+	numNotFormatted := 0
+	numFindings := 0
+	for _, scenarioPath := range scenarioPaths {
+		file, err := loadScenarioFile(scenarioPath)
+		if err != nil {
+			if !args.Lint {
+				return ErrCodeCriticalError, err
+			}
+			// the parser rejects unknown fields, among other errors
+			fmt.Fprintf(output, "%s: %s\n", scenarioPath, err.Error())
+			numFindings++
+			continue
+		}
+
+		if args.Lint {
+			for _, finding := range lintScenario(file) {
+				fmt.Fprintf(output, "%s: %s\n", scenarioPath, finding)
+				numFindings++
+			}
+		}
+
+		if file.isCanonical() {
+			continue
+		}
+		switch {
+		case file.hasComments && (args.Check || !args.Lint):
+			fmt.Fprintf(output, "%s: not in canonical form, and cannot be formatted without losing its comments\n", scenarioPath)
+			numNotFormatted++
+		case args.Check:
+			fmt.Fprintf(output, "%s: not in canonical form\n", scenarioPath)
+			numNotFormatted++
+		case !args.Lint:
+			err = file.rewrite()
+			if err != nil {
+				return ErrCodeCriticalError, err
+			}
+			fmt.Fprintf(output, "%s: formatted\n", scenarioPath)
+		}
+	}
+
+	if numFindings > 0 {
+		return ErrCodeLintFindings, nil
+	}
+	if numNotFormatted > 0 {
+		return ErrCodeNotFormatted, nil
+	}
+	return ErrCodeSuccess, nil
+}
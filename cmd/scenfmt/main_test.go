@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const nonCanonicalScenario = `{"steps":[{"step":"setState","accounts":{"address:A":{"nonce":"0","balance":"100"}}}]}`
+
+func TestProcessScenarios_Check(t *testing.T) {
+	dir := t.TempDir()
+	scenarioPath := writeTestScenario(t, dir, "check.scen.json", nonCanonicalScenario)
+
+	output := &bytes.Buffer{}
+	exitCode, err := processScenarios([]string{scenarioPath}, &cliArguments{Check: true}, output)
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeNotFormatted, exitCode)
+	require.Equal(t, scenarioPath+": not in canonical form\n", output.String())
+
+	// only reported, not rewritten
+	contents, err := os.ReadFile(scenarioPath)
+	require.Nil(t, err)
+	require.Equal(t, nonCanonicalScenario, string(contents))
+
+	// without --check, the file is rewritten, and then passes the check
+	output.Reset()
+	exitCode, err = processScenarios([]string{scenarioPath}, &cliArguments{}, output)
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeSuccess, exitCode)
+	require.Equal(t, scenarioPath+": formatted\n", output.String())
+
+	output.Reset()
+	exitCode, err = processScenarios([]string{scenarioPath}, &cliArguments{Check: true}, output)
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeSuccess, exitCode)
+	require.Equal(t, "", output.String())
+}
+
+func TestProcessScenarios_CheckInvalidScenario(t *testing.T) {
+	scenarioPath := writeTestScenario(t, t.TempDir(), "invalid.scen.json", `{"steps":[{"step":"unknown"}]}`)
+
+	_, err := processScenarios([]string{scenarioPath}, &cliArguments{Check: true}, &bytes.Buffer{})
+	require.NotNil(t, err)
+}
+
+func TestProcessScenarios_LintFindingsTakePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	notFormatted := writeTestScenario(t, dir, "a.scen.json", nonCanonicalScenario)
+	withFindings := writeTestScenario(t, dir, "b.scen.json", `{"steps":[{"step":"setState","accounts":{"address:A":{"balanse":"100"}}}]}`)
+
+	exitCode, err := processScenarios([]string{notFormatted, withFindings}, &cliArguments{Check: true, Lint: true}, &bytes.Buffer{})
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeLintFindings, exitCode)
+}
+
+func TestProcessScenarios_CommentsAreNotLost(t *testing.T) {
+	dir := t.TempDir()
+	commentedJSON5 := `{
+    // the owner
+    steps: [{step: 'setState', accounts: {'address:A': {nonce: 0, balance: 100}}}],
+}
+`
+	commentedYAML := `steps:
+  - step: setState
+    accounts:
+      address:A: {nonce: 0, balance: 100} # the owner
+`
+	json5Path := writeTestScenario(t, dir, "commented.scen.json5", commentedJSON5)
+	yamlPath := writeTestScenario(t, dir, "commented.scen.yaml", commentedYAML)
+	scenarioPaths := []string{json5Path, yamlPath}
+	expectedOutput := json5Path + ": not in canonical form, and cannot be formatted without losing its comments\n" +
+		yamlPath + ": not in canonical form, and cannot be formatted without losing its comments\n"
+
+	output := &bytes.Buffer{}
+	exitCode, err := processScenarios(scenarioPaths, &cliArguments{Check: true}, output)
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeNotFormatted, exitCode)
+	require.Equal(t, expectedOutput, output.String())
+
+	// without --check, the files are not rewritten either
+	output.Reset()
+	exitCode, err = processScenarios(scenarioPaths, &cliArguments{}, output)
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeNotFormatted, exitCode)
+	require.Equal(t, expectedOutput, output.String())
+
+	contents, err := os.ReadFile(json5Path)
+	require.Nil(t, err)
+	require.Equal(t, commentedJSON5, string(contents))
+	contents, err = os.ReadFile(yamlPath)
+	require.Nil(t, err)
+	require.Equal(t, commentedYAML, string(contents))
+}
+
+func TestProcessScenarios_SlashesInJSON5Strings(t *testing.T) {
+	scenarioPath := writeTestScenario(t, t.TempDir(), "slashes.scen.json5",
+		`{comment: 'a/b // not a comment', steps: [{step: "setState", accounts: {"address:A": {nonce: 0, balance: 100}}}]}`)
+
+	output := &bytes.Buffer{}
+	exitCode, err := processScenarios([]string{scenarioPath}, &cliArguments{}, output)
+	require.Nil(t, err)
+	require.Equal(t, ErrCodeSuccess, exitCode)
+	require.Equal(t, scenarioPath+": formatted\n", output.String())
+
+	contents, err := os.ReadFile(scenarioPath)
+	require.Nil(t, err)
+	require.Contains(t, string(contents), `comment: "a/b // not a comment",`)
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/parse"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+const (
+	importBig        = "math/big"
+	importTesting    = "testing"
+	importVMCommon   = "github.com/multiversx/mx-chain-vm-common-go"
+	importWorldMock  = "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
+	importTestCommon = "github.com/multiversx/mx-chain-vm-v1_3-go/testcommon"
+	importRequire    = "github.com/stretchr/testify/require"
+)
+
+var importAliases = map[string]string{
+	importWorldMock: "worldmock",
+}
+
+// codeRecordingResolver remembers which file each contract code was loaded from,
+// so the test can load it from the same file, instead of embedding it
+type codeRecordingResolver struct {
+	*fr.DefaultFileResolver
+	codePaths map[string]string
+}
+
+// Clone creates new instance of the same type.
+func (resolver *codeRecordingResolver) Clone() fr.FileResolver {
+	return &codeRecordingResolver{
+		DefaultFileResolver: resolver.DefaultFileResolver.Clone().(*fr.DefaultFileResolver),
+		codePaths:           resolver.codePaths,
+	}
+}
+
+// ResolveFileValue yields the file contents, recording where they came from.
+func (resolver *codeRecordingResolver) ResolveFileValue(value string) ([]byte, error) {
+	contents, err := resolver.DefaultFileResolver.ResolveFileValue(value)
+	if err == nil && len(contents) > 0 {
+		resolver.codePaths[string(contents)] = resolver.ResolveAbsolutePath(value)
+	}
+	return contents, err
+}
+
+// ResolveExternalSteps loads an external steps file, recording the code files it refers to as well.
+func (resolver *codeRecordingResolver) ResolveExternalSteps(value string, parameters map[string]string) ([]byte, fr.FileResolver, error) {
+	contents, externalResolver, err := resolver.DefaultFileResolver.ResolveExternalSteps(value, parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return contents, &codeRecordingResolver{
+		DefaultFileResolver: externalResolver.(*fr.DefaultFileResolver),
+		codePaths:           resolver.codePaths,
+	}, nil
+}
+
+// goTestGenerator writes the body of the test, one statement per line, and formats it at the end
+type goTestGenerator struct {
+	outputDir string
+	codePaths map[string]string
+
+	lines     []string
+	imports   map[string]struct{}
+	usesHost  bool
+	usesWorld bool
+	declared  map[string]bool
+	variables map[string]string
+	names     map[string]struct{}
+}
+
+// generateGoTest converts a scenario file, in any format, into the source of a Go test
+func generateGoTest(scenarioPath string, packageName string, testName string, outputDir string) ([]byte, error) {
+	absolutePath, err := filepath.Abs(scenarioPath)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(absolutePath)
+	if err != nil {
+		return nil, err
+	}
+	absoluteOutputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &codeRecordingResolver{
+		DefaultFileResolver: fr.NewDefaultFileResolver(),
+		codePaths:           make(map[string]string),
+	}
+	resolver.SetContext(absolutePath)
+
+	gen := &goTestGenerator{
+		outputDir: absoluteOutputDir,
+		codePaths: resolver.codePaths,
+		imports:   map[string]struct{}{importTesting: {}, importTestCommon: {}},
+		declared:  make(map[string]bool),
+		variables: make(map[string]string),
+		names:     make(map[string]struct{}),
+	}
+	err = gen.addScenarioFile(filepath.Base(scenarioPath), oj.FormatFromPath(scenarioPath), contents, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	return gen.source(filepath.Base(scenarioPath), packageName, testName)
+}
+
+func (gen *goTestGenerator) line(format string, args ...interface{}) {
+	gen.lines = append(gen.lines, fmt.Sprintf(format, args...))
+}
+
+func (gen *goTestGenerator) comment(format string, args ...interface{}) {
+	gen.lines = append(gen.lines, goComment(fmt.Sprintf(format, args...))...)
+}
+
+func (gen *goTestGenerator) use(importPath string) {
+	gen.imports[importPath] = struct{}{}
+}
+
+// declare adds the declaration of a variable shared by the statements, the first time it is needed
+func (gen *goTestGenerator) declare(name string, typeName string) {
+	if gen.declared[name] {
+		return
+	}
+	gen.declared[name] = true
+	gen.line("var %s %s", name, typeName)
+}
+
+// newName yields a variable name based on the given one, that is not used yet
+func (gen *goTestGenerator) newName(base string) string {
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := gen.names[name]; !taken {
+			gen.names[name] = struct{}{}
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// address yields a variable holding the address, declared the first time the address is used,
+// and named after the scenario expression, e.g. ownerAddress for "address:owner"
+func (gen *goTestGenerator) address(address mj.JSONBytesFromString) string {
+	if variable, found := gen.variables[string(address.Value)]; found {
+		return variable
+	}
+
+	name := address.Original
+	if separator := strings.Index(name, ":"); separator >= 0 {
+		name = name[separator+1:]
+	}
+	if strings.HasPrefix(address.Original, "0x") {
+		name = ""
+	}
+	identifier := goIdentifier(name)
+	if !strings.HasSuffix(strings.ToLower(identifier), "address") {
+		identifier += "Address"
+	}
+	if identifier == "Address" {
+		identifier = "address"
+	}
+	variable := gen.newName(identifier)
+
+	gen.variables[string(address.Value)] = variable
+	gen.line("%s := %s", variable, goBytes(address.Value))
+	return variable
+}
+
+// code yields the contract code: a variable loading the file it was read from, or the bytes themselves
+func (gen *goTestGenerator) code(code mj.JSONBytesFromString) string {
+	codePath, found := gen.codePaths[string(code.Value)]
+	if !found {
+		return goBytes(code.Value)
+	}
+	if variable, found := gen.variables[codePath]; found {
+		return variable
+	}
+
+	relativePath, err := filepath.Rel(gen.outputDir, codePath)
+	if err != nil {
+		relativePath = codePath
+	}
+	baseName := strings.TrimSuffix(filepath.Base(codePath), filepath.Ext(codePath))
+	variable := gen.newName(goIdentifier(baseName) + "Code")
+
+	gen.variables[codePath] = variable
+	gen.line("%s := testcommon.GetSCCode(%q)", variable, filepath.ToSlash(relativePath))
+	return variable
+}
+
+func (gen *goTestGenerator) bigInt(value mj.JSONBigInt) string {
+	gen.use(importBig)
+	return goBigInt(value.Value)
+}
+
+func (gen *goTestGenerator) addScenarioFile(fileName string, format oj.Format, contents []byte, resolver fr.FileResolver) error {
+	parser := mjparse.NewParser(resolver)
+	scenario, err := parser.ParseScenarioFileInFormat(contents, format)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", fileName, err)
+	}
+
+	if len(scenario.Comment) > 0 {
+		gen.comment(scenario.Comment)
+	}
+	if scenario.CheckGas {
+		gen.comment("%s checks gas, which depends on its gas schedule; the test does not check gas", fileName)
+	}
+	if len(scenario.EnableEpochs) > 0 {
+		gen.comment("the enableEpochs of %s are not converted; the test runs with the default flags", fileName)
+	}
+
+	return gen.addSteps(fileName, scenario.Steps, resolver)
+}
+
+func (gen *goTestGenerator) addSteps(fileName string, steps []mj.Step, resolver fr.FileResolver) error {
+	for stepIndex, generalStep := range steps {
+		location := fmt.Sprintf("%s step %d", fileName, stepIndex+1)
+		gen.line("")
+
+		var err error
+		switch step := generalStep.(type) {
+		case *mj.ExternalStepsStep:
+			err = gen.addExternalSteps(location, step, resolver)
+		case *mj.SetStateStep:
+			gen.addSetState(step)
+		case *mj.CheckStateStep:
+			gen.addCheckState(step)
+		case *mj.DumpStateStep:
+			gen.comment("%s: dumpState is not converted", location)
+		case *mj.BlockStep:
+			err = gen.addBlock(fileName, step, resolver)
+		case *mj.TxStep:
+			gen.addTx(step)
+		default:
+			gen.comment("%s: %s is not converted", location, generalStep.StepTypeName())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gen *goTestGenerator) addExternalSteps(location string, step *mj.ExternalStepsStep, resolver fr.FileResolver) error {
+	externalContents, externalResolver, err := resolver.ResolveExternalSteps(step.Path, step.ParametersMap())
+	if err != nil {
+		return fmt.Errorf("%s: %w", location, err)
+	}
+
+	gen.comment("steps from %s", step.Path)
+	return gen.addScenarioFile(filepath.Base(step.Path), oj.FormatFromPath(step.Path), externalContents, externalResolver)
+}
+
+func (gen *goTestGenerator) addBlock(fileName string, step *mj.BlockStep, resolver fr.FileResolver) error {
+	if len(step.Comment) > 0 {
+		gen.comment(step.Comment)
+	}
+	if step.CurrentBlockInfo == nil {
+		gen.comment("block without block info: its steps run in the current block")
+		return gen.addSteps(fileName, step.Steps, resolver)
+	}
+
+	gen.usesWorld = true
+	gen.use(importRequire)
+	gen.line("require.Nil(t, world.StartBlock(%s))", gen.blockInfo(step.CurrentBlockInfo))
+	err := gen.addSteps(fileName, step.Steps, resolver)
+	if err != nil {
+		return err
+	}
+	gen.line("world.EndBlock()")
+	return nil
+}
+
+func (gen *goTestGenerator) blockInfo(blockInfo *mj.BlockInfo) string {
+	gen.use(importWorldMock)
+	randomSeed := ""
+	if blockInfo.BlockRandomSeed != nil {
+		randomSeed = goByteList(blockInfo.BlockRandomSeed.Value)
+	}
+	return fmt.Sprintf("&worldmock.BlockInfo{BlockTimestamp: %d, BlockNonce: %d, BlockRound: %d, BlockEpoch: %d, RandomSeed: &[48]byte{%s}}",
+		blockInfo.BlockTimestamp.Value,
+		blockInfo.BlockNonce.Value,
+		blockInfo.BlockRound.Value,
+		blockInfo.BlockEpoch.Value,
+		randomSeed)
+}
+
+// source puts the test together, with the imports it needs
+func (gen *goTestGenerator) source(scenarioName string, packageName string, testName string) ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("// Generated by scengotest from %s.\n", scenarioName))
+	sb.WriteString("// It is meant as a starting point, to be extended with further assertions.\n\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", packageName))
+
+	var imports []string
+	for importPath := range gen.imports {
+		imports = append(imports, importPath)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		iStandard := !strings.Contains(imports[i], ".")
+		jStandard := !strings.Contains(imports[j], ".")
+		if iStandard != jStandard {
+			return iStandard
+		}
+		return imports[i] < imports[j]
+	})
+	sb.WriteString("import (\n")
+	for i, importPath := range imports {
+		if i > 0 && !strings.Contains(imports[i-1], ".") && strings.Contains(importPath, ".") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("%s %q\n", importAliases[importPath], importPath))
+	}
+	sb.WriteString(")\n\n")
+
+	sb.WriteString(fmt.Sprintf("func %s(t *testing.T) {\n", testName))
+	switch {
+	case gen.usesHost:
+		sb.WriteString("host, world := testcommon.DefaultTestVMWithWorldMock(t)\n")
+	case gen.usesWorld:
+		sb.WriteString("_, world := testcommon.DefaultTestVMWithWorldMock(t)\n")
+	default:
+		sb.WriteString("testcommon.DefaultTestVMWithWorldMock(t)\n")
+	}
+	for _, line := range gen.lines {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+
+	source, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return nil, fmt.Errorf("the generated test is not valid Go: %w", err)
+	}
+	return source, nil
+}
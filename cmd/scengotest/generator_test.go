@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files with the generated tests")
+
+// the generated tests go to a package next to this one, so the relative contract paths are the same in the golden files;
+// the "_" keeps it out of "./..." while it exists
+const generatedPackage = "_generated"
+
+func TestGenerateGoTest_Golden(t *testing.T) {
+	testCases := []struct {
+		scenarioPath string
+		testName     string
+		golden       string
+		// only tests that execute no contracts are run, the others are only compiled
+		run bool
+	}{
+		{
+			scenarioPath: "../../test/adder/scenarios/adder.scen.json",
+			testName:     "TestAdder",
+			golden:       "adder.golden",
+		},
+		{
+			scenarioPath: "../../test/crowdfunding-esdt/scenarios/crowdfunding-fund.scen.json",
+			testName:     "TestCrowdfundingFund",
+			golden:       "crowdfunding-fund.golden",
+		},
+		{
+			scenarioPath: "../../test/scenarios-self-test/set-check/set-check-esdt.scen.json",
+			testName:     "TestSetCheckEsdt",
+			golden:       "set-check-esdt.golden",
+			run:          true,
+		},
+	}
+
+	// a previous run interrupted before its cleanup leaves the package behind
+	err := os.RemoveAll(generatedPackage)
+	require.Nil(t, err)
+	err = os.Mkdir(generatedPackage, 0755)
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(generatedPackage)
+	}()
+
+	var testsToRun []string
+	for _, testCase := range testCases {
+		source, err := generateGoTest(testCase.scenarioPath, "generated", testCase.testName, generatedPackage)
+		require.Nil(t, err)
+
+		goldenPath := filepath.Join("testdata", testCase.golden)
+		if *updateGolden {
+			err = os.WriteFile(goldenPath, source, 0644)
+			require.Nil(t, err)
+		}
+		expected, err := os.ReadFile(goldenPath)
+		require.Nil(t, err)
+		require.Equal(t, string(expected), string(source), "%s differs from %s, run the test with -update to accept the changes", testCase.scenarioPath, goldenPath)
+
+		testFileName := strings.TrimSuffix(testCase.golden, ".golden") + "_test.go"
+		err = os.WriteFile(filepath.Join(generatedPackage, testFileName), source, 0644)
+		require.Nil(t, err)
+		if testCase.run {
+			testsToRun = append(testsToRun, testCase.testName)
+		}
+	}
+
+	output, err := runGoCommand("vet", "./"+generatedPackage)
+	require.Nil(t, err, output)
+
+	output, err = runGoCommand("test", "-count=1", "-v", "-run", "^("+strings.Join(testsToRun, "|")+")$", "./"+generatedPackage)
+	if err != nil && strings.Contains(output, "-lwasmer") {
+		t.Skip("the generated tests compile, but cannot be run without the wasmer library")
+	}
+	require.Nil(t, err, output)
+	for _, testName := range testsToRun {
+		require.Contains(t, output, "--- PASS: "+testName+" ")
+	}
+}
+
+func runGoCommand(args ...string) (string, error) {
+	output, err := exec.Command("go", args...).CombinedOutput()
+	return fmt.Sprintf("go %s:\n%s", strings.Join(args, " "), string(output)), err
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+// testNameFromPath derives the test function name from the scenario file name, e.g. TestAdderCall for adder-call.scen.json
+func testNameFromPath(scenarioPath string) string {
+	name := filepath.Base(scenarioPath)
+	name = strings.TrimSuffix(name, oj.FormatFromPath(name).Extension())
+	name = strings.TrimSuffix(name, ".scen")
+	name = strings.TrimSuffix(name, ".steps")
+
+	identifier := goIdentifier(name)
+	if len(identifier) == 0 {
+		return "TestScenario"
+	}
+	return "Test" + strings.ToUpper(identifier[:1]) + identifier[1:]
+}
+
+// goIdentifier converts a name to camelCase, dropping everything that is not a letter or a digit;
+// the result is empty if nothing remains, and starts with a letter otherwise
+func goIdentifier(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+
+	var sb strings.Builder
+	for i, word := range words {
+		if i == 0 {
+			sb.WriteString(strings.ToLower(word[:1]))
+		} else {
+			sb.WriteString(strings.ToUpper(word[:1]))
+		}
+		sb.WriteString(word[1:])
+	}
+
+	identifier := sb.String()
+	if len(identifier) > 0 && unicode.IsDigit(rune(identifier[0])) {
+		identifier = "n" + identifier
+	}
+	return identifier
+}
+
+// goBytes yields a Go expression for a byte slice: a string conversion for mostly readable values,
+// such as the "str:", "address:" and "sc:" ones, and a byte list otherwise, e.g. for numbers
+func goBytes(value []byte) string {
+	if len(value) == 0 {
+		return "[]byte{}"
+	}
+
+	numReadable := 0
+	for _, b := range value {
+		if b >= 0x20 && b < 0x7f {
+			numReadable++
+		}
+	}
+	if numReadable*4 >= len(value)*3 {
+		return fmt.Sprintf("[]byte(%s)", strconv.Quote(string(value)))
+	}
+	return "[]byte{" + goByteList(value) + "}"
+}
+
+func goByteList(value []byte) string {
+	items := make([]string, len(value))
+	for i, b := range value {
+		items[i] = fmt.Sprintf("0x%02x", b)
+	}
+	return strings.Join(items, ", ")
+}
+
+// goBigInt yields a Go expression for a *big.Int
+func goBigInt(value *big.Int) string {
+	if value == nil {
+		return "big.NewInt(0)"
+	}
+	if value.IsInt64() {
+		return fmt.Sprintf("big.NewInt(%d)", value.Int64())
+	}
+	if value.Sign() < 0 {
+		return fmt.Sprintf("big.NewInt(0).Neg(big.NewInt(0).SetBytes(%s))", goBytes(value.Bytes()))
+	}
+	return fmt.Sprintf("big.NewInt(0).SetBytes(%s)", goBytes(value.Bytes()))
+}
+
+// goComment yields comment lines, also for multi-line texts
+func goComment(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		lines = append(lines, strings.TrimRight("// "+strings.TrimSpace(line), " "))
+	}
+	return lines
+}
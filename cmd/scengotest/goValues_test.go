@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestNameFromPath(t *testing.T) {
+	require.Equal(t, "TestAdderCall", testNameFromPath("scenarios/adder-call.scen.json"))
+	require.Equal(t, "TestAdderCall", testNameFromPath("adder_call.scen.yaml"))
+	require.Equal(t, "TestInit", testNameFromPath("init.steps.json"))
+	require.Equal(t, "TestN01DnsInit", testNameFromPath("01_dns_init.steps.json"))
+	require.Equal(t, "TestScenario", testNameFromPath("---.scen.json"))
+}
+
+func TestGoIdentifier(t *testing.T) {
+	testCases := map[string]string{
+		"owner":         "owner",
+		"my_address":    "myAddress",
+		"Big-Owner":     "bigOwner",
+		"adder2":        "adder2",
+		"2nd-owner":     "n2ndOwner",
+		"crowd funding": "crowdFunding",
+		"über":          "ber",
+		"":              "",
+		"__":            "",
+	}
+	for name, identifier := range testCases {
+		require.Equal(t, identifier, goIdentifier(name), name)
+	}
+}
+
+func TestGoBytes(t *testing.T) {
+	testCases := []struct {
+		value      []byte
+		expression string
+	}{
+		{nil, "[]byte{}"},
+		{[]byte("owner___"), `[]byte("owner___")`},
+		{append(make([]byte, 8), []byte("adder___________________")...), `[]byte("\x00\x00\x00\x00\x00\x00\x00\x00adder___________________")`},
+		{[]byte{0x05}, "[]byte{0x05}"},
+		{[]byte{0x01, 0xe0, 0x78}, "[]byte{0x01, 0xe0, 0x78}"},
+		{[]byte("quote\"d"), `[]byte("quote\"d")`},
+	}
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expression, goBytes(testCase.value))
+	}
+}
+
+func TestGoBigInt(t *testing.T) {
+	large, _ := big.NewInt(0).SetString("1000000000000000000000", 10)
+
+	require.Equal(t, "big.NewInt(0)", goBigInt(nil))
+	require.Equal(t, "big.NewInt(0)", goBigInt(big.NewInt(0)))
+	require.Equal(t, "big.NewInt(-5)", goBigInt(big.NewInt(-5)))
+	require.Equal(t, "big.NewInt(0).SetBytes([]byte{0x36, 0x35, 0xc9, 0xad, 0xc5, 0xde, 0xa0, 0x00, 0x00})", goBigInt(large))
+	require.Equal(t, "big.NewInt(0).Neg(big.NewInt(0).SetBytes([]byte{0x36, 0x35, 0xc9, 0xad, 0xc5, 0xde, 0xa0, 0x00, 0x00}))", goBigInt(big.NewInt(0).Neg(large)))
+}
+
+func TestGoComment(t *testing.T) {
+	require.Equal(t, []string{"// one line"}, goComment("one line"))
+	require.Equal(t, []string{"// first", "//", "// second"}, goComment("\n  first\n\n  second  \n"))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals success
+	ErrCodeSuccess = iota
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+)
+
+type cliArguments struct {
+	Output   string
+	Package  string
+	TestName string
+}
+
+func main() {
+	app := initializeCLI()
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(ErrCodeSuccess)
+}
+
+func initializeCLI() *cli.App {
+	app := cli.NewApp()
+	app.Name = "scengotest"
+	app.Usage = "converts a scenario into a Go test, built with the testcommon builders and the mock world, which can then be extended with further assertions"
+	app.ArgsUsage = "<scenario file>"
+
+	args := &cliArguments{}
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:        "output",
+			Value:       "",
+			Usage:       "the _test.go file to write; the test is printed to standard output if missing",
+			Destination: &args.Output,
+		},
+		cli.StringFlag{
+			Name:        "package",
+			Value:       "scenarios",
+			Usage:       "the package of the generated test",
+			Destination: &args.Package,
+		},
+		cli.StringFlag{
+			Name:        "name",
+			Value:       "",
+			Usage:       "the name of the generated test function; derived from the scenario file name if missing",
+			Destination: &args.TestName,
+		},
+	}
+
+	app.Authors = []cli.Author{
+		{
+			Name:  "The MultiversX Team",
+			Email: "contact@multiversx.com",
+		},
+	}
+
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() != 1 {
+			return fmt.Errorf("exactly one argument expected - the scenario file")
+		}
+		scenarioPath := context.Args().First()
+
+		testName := args.TestName
+		if len(testName) == 0 {
+			testName = testNameFromPath(scenarioPath)
+		}
+
+		// the contract paths in the test are relative to the directory it runs in, that of the test file
+		outputDir := "."
+		if len(args.Output) > 0 {
+			outputDir = filepath.Dir(args.Output)
+		}
+
+		source, err := generateGoTest(scenarioPath, args.Package, testName, outputDir)
+		if err != nil {
+			return err
+		}
+
+		if len(args.Output) == 0 {
+			_, err = os.Stdout.Write(source)
+			return err
+		}
+		return os.WriteFile(args.Output, source, 0644)
+	}
+
+	return app
+}
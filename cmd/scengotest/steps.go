@@ -0,0 +1,405 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+)
+
+var returnCodeNames = map[int64]string{
+	1:  "FunctionNotFound",
+	2:  "FunctionWrongSignature",
+	3:  "ContractNotFound",
+	4:  "UserError",
+	5:  "OutOfGas",
+	6:  "AccountCollision",
+	7:  "OutOfFunds",
+	8:  "CallStackOverFlow",
+	9:  "ContractInvalid",
+	10: "ExecutionFailed",
+}
+
+func (gen *goTestGenerator) addSetState(step *mj.SetStateStep) {
+	gen.usesWorld = true
+	if len(step.Comment) > 0 {
+		gen.comment(step.Comment)
+	}
+
+	for _, account := range step.Accounts {
+		gen.addAccount(account)
+	}
+
+	for _, newAddressMock := range step.NewAddressMocks {
+		gen.use(importWorldMock)
+		gen.line("world.NewAddressMocks = append(world.NewAddressMocks, &worldmock.NewAddressMock{CreatorAddress: %s, CreatorNonce: %d, NewAddress: %s})",
+			gen.address(newAddressMock.CreatorAddress),
+			newAddressMock.CreatorNonce.Value,
+			gen.address(newAddressMock.NewAddress))
+	}
+	switch step.NewAddressDerivation {
+	case mj.NewAddressDerivationMock:
+		gen.line("world.ProtocolNewAddresses = false")
+	case mj.NewAddressDerivationProtocol:
+		gen.line("world.ProtocolNewAddresses = true")
+	}
+
+	if step.PreviousBlockInfo != nil {
+		gen.line("world.PreviousBlockInfo = %s", gen.blockInfo(step.PreviousBlockInfo))
+	}
+	if step.CurrentBlockInfo != nil {
+		gen.line("world.CurrentBlockInfo = %s", gen.blockInfo(step.CurrentBlockInfo))
+	}
+	if len(step.BlockHashes) > 0 {
+		blockHashes := make([]string, len(step.BlockHashes))
+		for i, blockHash := range step.BlockHashes {
+			blockHashes[i] = goBytes(blockHash.Value)
+		}
+		gen.line("world.Blockhashes = [][]byte{%s}", strings.Join(blockHashes, ", "))
+	}
+}
+
+func (gen *goTestGenerator) addAccount(account *mj.Account) {
+	address := gen.address(account.Address)
+	if len(account.Comment) > 0 {
+		gen.comment(account.Comment)
+	}
+
+	// the expressions are prepared first, since they may declare variables of their own
+	var creation string
+	var settings []string
+	if len(account.Code.Value) > 0 {
+		owner := "nil"
+		if len(account.Owner.Value) > 0 {
+			owner = gen.address(account.Owner)
+		}
+		creation = fmt.Sprintf("world.AcctMap.CreateSmartContractAccount(%s, %s, %s, world)", owner, address, gen.code(account.Code))
+		// scenarios deploy their contracts with all the code metadata flags
+		gen.use(importVMCommon)
+		settings = append(settings, "account.CodeMetadata = (&vmcommon.CodeMetadata{Payable: true, Upgradeable: true, Readable: true}).ToBytes()")
+	} else {
+		creation = fmt.Sprintf("world.AcctMap.CreateAccount(%s, world)", address)
+		if len(account.Owner.Value) > 0 {
+			settings = append(settings, fmt.Sprintf("account.OwnerAddress = %s", gen.address(account.Owner)))
+		}
+	}
+
+	if account.Nonce.Value > 0 {
+		settings = append(settings, fmt.Sprintf("account.Nonce = %d", account.Nonce.Value))
+	}
+	if account.Balance.Value != nil && account.Balance.Value.Sign() != 0 {
+		settings = append(settings, fmt.Sprintf("account.Balance = %s", gen.bigInt(account.Balance)))
+	}
+	if len(account.Username.Value) > 0 {
+		settings = append(settings, fmt.Sprintf("account.Username = %s", goBytes(account.Username.Value)))
+	}
+	if account.Shard.Value > 0 {
+		settings = append(settings, fmt.Sprintf("account.ShardID = %d", account.Shard.Value))
+	}
+	for _, storage := range account.Storage {
+		settings = append(settings, fmt.Sprintf("account.Storage[%q] = %s", string(storage.Key.Value), goBytes(storage.Value.Value)))
+	}
+	for _, esdtData := range account.ESDTData {
+		settings = append(settings, gen.esdtSettings(esdtData)...)
+	}
+	if len(account.AsyncCallData) > 0 {
+		settings = append(settings, goComment("the asyncCallData of the account is not converted")...)
+	}
+
+	if len(settings) == 0 {
+		gen.line("%s", creation)
+		return
+	}
+	gen.use(importWorldMock)
+	gen.declare("account", "*worldmock.Account")
+	gen.line("account = %s", creation)
+	for _, setting := range settings {
+		gen.line("%s", setting)
+	}
+}
+
+func (gen *goTestGenerator) esdtSettings(esdtData *mj.ESDTData) []string {
+	gen.use(importWorldMock)
+	gen.use(importRequire)
+	tokenName := goBytes(esdtData.TokenIdentifier.Value)
+
+	var settings []string
+	for _, instance := range esdtData.Instances {
+		settings = append(settings, fmt.Sprintf("require.Nil(t, account.SetTokenBalance(worldmock.MakeTokenKey(%s, %d), %s))",
+			tokenName, instance.Nonce.Value, gen.bigInt(instance.Balance)))
+		if len(instance.Creator.Value) > 0 || instance.Royalties.Value > 0 || len(instance.Hash.Value) > 0 ||
+			len(instance.Uri.Value) > 0 || len(instance.Attributes.Value) > 0 {
+			settings = append(settings, goComment(fmt.Sprintf("the metadata of %s, nonce %d, is not converted",
+				esdtData.TokenIdentifier.Original, instance.Nonce.Value))...)
+		}
+	}
+	if len(esdtData.Roles) > 0 {
+		roles := make([]string, len(esdtData.Roles))
+		for i, role := range esdtData.Roles {
+			roles[i] = fmt.Sprintf("%q", role)
+		}
+		settings = append(settings, fmt.Sprintf("require.Nil(t, account.SetTokenRolesAsStrings(%s, []string{%s}))",
+			tokenName, strings.Join(roles, ", ")))
+	}
+	if esdtData.LastNonce.Value > 0 {
+		settings = append(settings, fmt.Sprintf("require.Nil(t, account.SetLastNonce(%s, %d))", tokenName, esdtData.LastNonce.Value))
+	}
+	if esdtData.Frozen.Value > 0 {
+		settings = append(settings, fmt.Sprintf("require.Nil(t, account.SetTokenFrozen(%s, true))", tokenName))
+	}
+	if esdtData.Paused.Value > 0 {
+		settings = append(settings, fmt.Sprintf("world.SetTokenPaused(%s, true)", tokenName))
+	}
+	if esdtData.LimitedTransfer.Value > 0 {
+		settings = append(settings, fmt.Sprintf("world.SetTokenLimitedTransfer(%s, true)", tokenName))
+	}
+	return settings
+}
+
+func (gen *goTestGenerator) addTx(step *mj.TxStep) {
+	tx := step.Tx
+	if len(step.TxIdent) > 0 {
+		gen.comment("tx %s", step.TxIdent)
+	} else {
+		gen.comment("tx")
+	}
+	if len(step.Comment) > 0 {
+		gen.comment(step.Comment)
+	}
+
+	var runner string
+	var builder []string
+	switch tx.Type {
+	case mj.ScDeploy:
+		runner = "testcommon.RunContractCreateInWorld"
+		builder = []string{
+			"testcommon.CreateTestContractCreateInputBuilder()",
+			fmt.Sprintf("WithCallerAddr(%s)", gen.address(tx.From)),
+			fmt.Sprintf("WithContractCode(%s)", gen.code(tx.Code)),
+			fmt.Sprintf("WithArguments(%s)", gen.arguments(tx.Arguments)),
+		}
+	case mj.ScCall:
+		runner = "testcommon.RunContractCallInWorld"
+		builder = []string{
+			"testcommon.CreateTestContractCallInputBuilder()",
+			fmt.Sprintf("WithCallerAddr(%s)", gen.address(tx.From)),
+			fmt.Sprintf("WithRecipientAddr(%s)", gen.address(tx.To)),
+			fmt.Sprintf("WithFunction(%q)", tx.Function),
+		}
+		if len(tx.Arguments) > 0 {
+			builder = append(builder, fmt.Sprintf("WithArguments(%s)", gen.arguments(tx.Arguments)))
+		}
+		if tx.ESDTValue != nil {
+			builder = append(builder, fmt.Sprintf("WithESDTTokenName(%s)", goBytes(tx.ESDTValue.TokenIdentifier.Value)))
+			if tx.ESDTValue.Nonce.Value > 0 {
+				builder = append(builder, fmt.Sprintf("WithESDTTokenNonce(%d)", tx.ESDTValue.Nonce.Value))
+			}
+			builder = append(builder, fmt.Sprintf("WithESDTValue(%s)", gen.bigInt(tx.ESDTValue.Value)))
+		}
+		builder = append(builder, fmt.Sprintf("WithCurrentTxHash(%s)", goBytes(txHash(step.TxIdent))))
+	default:
+		gen.comment("%s transactions are not converted", txTypeName(tx.Type))
+		return
+	}
+	gen.usesHost = true
+	gen.usesWorld = true
+
+	builder = append(builder, fmt.Sprintf("WithGasProvided(%d)", tx.GasLimit.Value))
+	if tx.GasPrice.Value > 0 {
+		builder = append(builder, fmt.Sprintf("WithGasPrice(%d)", tx.GasPrice.Value))
+	}
+	hasValue := tx.Value.Value != nil && tx.Value.Value.Sign() != 0
+	if hasValue && tx.Value.Value.IsInt64() {
+		builder = append(builder, fmt.Sprintf("WithCallValue(%d)", tx.Value.Value.Int64()))
+	}
+	builder = append(builder, "Build()")
+	input := strings.Join(builder, ".\n")
+
+	// values that do not fit the builder are set on the built input
+	if hasValue && !tx.Value.Value.IsInt64() {
+		inputVariable, inputType := "callInput", "*vmcommon.ContractCallInput"
+		if tx.Type == mj.ScDeploy {
+			inputVariable, inputType = "createInput", "*vmcommon.ContractCreateInput"
+		}
+		gen.use(importVMCommon)
+		gen.declare(inputVariable, inputType)
+		gen.line("%s = %s", inputVariable, input)
+		gen.line("%s.CallValue = %s", inputVariable, gen.bigInt(tx.Value))
+		input = inputVariable
+	}
+
+	checks := gen.resultChecks(step.ExpectedResult)
+	gen.line("%s(t, host, world, %s)%s", runner, input, strings.Join(checks, ""))
+}
+
+func (gen *goTestGenerator) arguments(arguments []mj.JSONBytesFromTree) string {
+	values := make([]string, len(arguments))
+	for i, argument := range arguments {
+		values[i] = goBytes(argument.Value)
+	}
+	return strings.Join(values, ", ")
+}
+
+// resultChecks yields the verifier calls for the expect block; the checks that cannot be expressed with them
+// are listed in comments instead
+func (gen *goTestGenerator) resultChecks(result *mj.TransactionResult) []string {
+	if result == nil {
+		return nil
+	}
+
+	var checks []string
+	status := result.Status
+	switch {
+	case status.IsStar:
+	case status.Predicate != nil:
+		gen.comment("the expected status, %s, is not converted", status.Original)
+	case status.Value.Sign() == 0:
+		checks = append(checks, ".\nOk()")
+	default:
+		gen.use(importVMCommon)
+		name, known := returnCodeNames[status.Value.Int64()]
+		if !known {
+			name = fmt.Sprintf("ReturnCode(%d)", status.Value.Int64())
+		}
+		checks = append(checks, fmt.Sprintf(".\nReturnCode(vmcommon.%s)", name))
+	}
+
+	message := result.Message
+	switch {
+	case message.IsUnspecified() || message.IsStar:
+	case message.Predicate != nil:
+		gen.comment("the expected message is not converted")
+	default:
+		checks = append(checks, fmt.Sprintf(".\nReturnMessage(%q)", string(message.Value)))
+	}
+
+	outConvertible := true
+	out := make([]string, len(result.Out))
+	for i, expected := range result.Out {
+		if expected.IsStar || expected.Predicate != nil {
+			outConvertible = false
+			break
+		}
+		out[i] = goBytes(expected.Value)
+	}
+	if outConvertible {
+		checks = append(checks, fmt.Sprintf(".\nReturnData(%s)", strings.Join(out, ", ")))
+	} else {
+		gen.comment("the expected out contains \"*\" or predicates, and is not converted")
+	}
+
+	if !result.Gas.IsUnspecified() && !result.Gas.IsStar {
+		gen.comment("the scenario expects %s gas remaining, which depends on its gas schedule; gas is not checked", result.Gas.Original)
+	}
+	if !result.Refund.IsUnspecified() && !result.Refund.IsStar {
+		gen.comment("the expected refund, %s, is not converted", result.Refund.Original)
+	}
+	if !result.LogsUnspecified && !result.LogsStar {
+		gen.comment("the expected logs are not converted")
+	}
+	return checks
+}
+
+func (gen *goTestGenerator) addCheckState(step *mj.CheckStateStep) {
+	gen.usesWorld = true
+	gen.use(importRequire)
+	if len(step.Comment) > 0 {
+		gen.comment(step.Comment)
+	}
+
+	checkAccounts := step.CheckAccounts
+	for _, checkAccount := range checkAccounts.Accounts {
+		address := gen.address(checkAccount.Address)
+		if len(checkAccount.Comment) > 0 {
+			gen.comment(checkAccount.Comment)
+		}
+		gen.use(importWorldMock)
+		gen.declare("account", "*worldmock.Account")
+		gen.line("account = world.AcctMap.GetAccount(%s)", address)
+		gen.line("require.NotNil(t, account)")
+
+		if isPlainCheckUint64(checkAccount.Nonce) {
+			gen.line("require.Equal(t, uint64(%d), account.Nonce)", checkAccount.Nonce.Value)
+		}
+		if isPlainCheckBigInt(checkAccount.Balance) {
+			gen.line("require.Equal(t, %q, account.Balance.String())", checkAccount.Balance.Value.String())
+		}
+		if isPlainCheckBytes(checkAccount.Username) {
+			gen.line("require.Equal(t, %s, account.Username)", goBytes(checkAccount.Username.Value))
+		}
+		if isPlainCheckBytes(checkAccount.Owner) {
+			gen.line("require.Equal(t, %s, account.OwnerAddress)", goBytes(checkAccount.Owner.Value))
+		}
+		if !checkAccount.IgnoreStorage {
+			for _, storage := range checkAccount.CheckStorage {
+				if isPlainCheckBytes(storage.CheckValue) {
+					gen.line("require.Equal(t, %s, account.StorageValue(%q))", goBytes(storage.CheckValue.Value), string(storage.Key.Value))
+				}
+			}
+		}
+		if !checkAccount.IgnoreESDT {
+			for _, esdtData := range checkAccount.CheckESDTData {
+				gen.addTokenBalanceChecks(esdtData)
+			}
+		}
+	}
+
+	if !checkAccounts.MoreAccountsAllowed {
+		gen.line("require.Len(t, world.AcctMap, %d)", len(checkAccounts.Accounts))
+	}
+	if !step.StateRootHash.IsUnspecified() && !step.StateRootHash.IsStar {
+		gen.comment("the expected stateRootHash is not converted")
+	}
+}
+
+func (gen *goTestGenerator) addTokenBalanceChecks(esdtData *mj.CheckESDTData) {
+	for _, instance := range esdtData.Instances {
+		if !isPlainCheckBigInt(instance.Balance) {
+			continue
+		}
+		gen.use(importBig)
+		gen.declare("tokenBalance", "*big.Int")
+		gen.declare("err", "error")
+		gen.line("tokenBalance, err = account.GetTokenBalance(worldmock.MakeTokenKey(%s, %d))",
+			goBytes(esdtData.TokenIdentifier.Value), instance.Nonce.Value)
+		gen.line("require.Nil(t, err)")
+		gen.line("require.Equal(t, %q, tokenBalance.String())", instance.Balance.Value.String())
+	}
+}
+
+func isPlainCheckBytes(check mj.JSONCheckBytes) bool {
+	return !check.IsUnspecified() && !check.IsStar && check.Predicate == nil
+}
+
+func isPlainCheckBigInt(check mj.JSONCheckBigInt) bool {
+	return !check.IsUnspecified() && !check.IsStar && check.Predicate == nil
+}
+
+func isPlainCheckUint64(check mj.JSONCheckUint64) bool {
+	return !check.IsUnspecified() && !check.IsStar && check.Predicate == nil
+}
+
+// txHash is the hash scenarios give to transactions: the tx id, padded to 32 bytes
+func txHash(txIdent string) []byte {
+	hash := []byte(txIdent)
+	if len(hash) > 32 {
+		return hash[:32]
+	}
+	for len(hash) < 32 {
+		hash = append(hash, '.')
+	}
+	return hash
+}
+
+func txTypeName(txType mj.TransactionType) string {
+	switch txType {
+	case mj.ScQuery:
+		return "scQuery"
+	case mj.Transfer:
+		return "transfer"
+	case mj.ValidatorReward:
+		return "validatorReward"
+	default:
+		return fmt.Sprintf("type %d", txType)
+	}
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	am "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec"
 	mc "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/controller"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 )
 
 func resolveArgument(exeDir string, arg string) (string, bool, error) {
@@ -23,7 +27,69 @@ func resolveArgument(exeDir string, arg string) (string, bool, error) {
 	return arg, fi.IsDir(), nil
 }
 
+// parseEnableEpochs parses activation epochs of the form "Flag1=epoch1,Flag2=epoch2"
+func parseEnableEpochs(arg string) (map[core.EnableEpochFlag]uint32, error) {
+	activationEpochs := make(map[core.EnableEpochFlag]uint32)
+	for _, flagActivation := range strings.Split(arg, ",") {
+		flagAndEpoch := strings.Split(flagActivation, "=")
+		if len(flagAndEpoch) != 2 {
+			return nil, fmt.Errorf("invalid flag activation \"%s\", expected Flag=epoch", flagActivation)
+		}
+		epoch, err := strconv.ParseUint(flagAndEpoch[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid activation epoch for flag %s: %w", flagAndEpoch[0], err)
+		}
+		activationEpochs[core.EnableEpochFlag(flagAndEpoch[0])] = uint32(epoch)
+	}
+	return activationEpochs, nil
+}
+
+// runFlagMatrix runs every scenario under all VM flag combinations and prints the differences
+func runFlagMatrix(jsonFilePath string, isDir bool) error {
+	scenarioPaths := []string{jsonFilePath}
+	if isDir {
+		scenarioPaths = nil
+		err := filepath.Walk(jsonFilePath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if oj.HasSuffixInAnyFormat(path, ".scen.json") {
+				scenarioPaths = append(scenarioPaths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	numScenariosWithDifferences := 0
+	for _, scenarioPath := range scenarioPaths {
+		report, err := am.RunScenarioFlagMatrix(scenarioPath)
+		if err != nil {
+			return err
+		}
+		fmt.Print(report.String())
+		if len(report.Differences) > 0 {
+			numScenariosWithDifferences++
+		}
+	}
+
+	fmt.Printf("%d of %d scenarios behave differently under some flag combination\n",
+		numScenariosWithDifferences,
+		len(scenarioPaths))
+	return nil
+}
+
 func main() {
+	enableEpochsArg := flag.String("enable-epochs", "",
+		"activation epochs of VM flags, e.g. \"SCDeployFlag=0,RepairCallbackFlag=5\"; overrides the scenarios")
+	flagMatrix := flag.Bool("flag-matrix", false,
+		"run each scenario under every combination of VM flags and report differences")
+	golden := flag.Bool("golden", false,
+		"golden mode: instead of failing, checkState steps are updated to the actual state, and the scenario files rewritten in canonical form; files not in canonical form, e.g. with comments, are refused")
+	flag.Parse()
+
 	// directory of this executable
 	exeDir, err := os.Getwd()
 	if err != nil {
@@ -32,20 +98,40 @@ func main() {
 	}
 
 	// argument
-	if len(os.Args) != 2 {
+	if flag.NArg() != 1 {
 		panic("One argument expected - the path to the json test.")
 	}
-	jsonFilePath, isDir, err := resolveArgument(exeDir, os.Args[1])
+	jsonFilePath, isDir, err := resolveArgument(exeDir, flag.Arg(0))
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	if *flagMatrix {
+		err = runFlagMatrix(jsonFilePath, isDir)
+		if err != nil {
+			fmt.Printf("ERROR: %s\n", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	// init
 	executor, err := am.NewVMTestExecutor()
 	if err != nil {
 		panic("Could not instantiate VM VM")
 	}
+	executor.SetGoldenMode(*golden)
+	if len(*enableEpochsArg) > 0 {
+		activationEpochs, err := parseEnableEpochs(*enableEpochsArg)
+		if err == nil {
+			err = executor.SetEnableEpochs(activationEpochs)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
 
 	// execute
 	switch {
@@ -59,7 +145,7 @@ func main() {
 			"",
 			".scen.json",
 			[]string{})
-	case strings.HasSuffix(jsonFilePath, ".scen.json"):
+	case oj.HasSuffixInAnyFormat(jsonFilePath, ".scen.json"):
 		runner := mc.NewScenarioRunner(
 			executor,
 			mc.NewDefaultFileResolver(),
@@ -93,6 +93,24 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 		Destination: &args.CodeMetadata,
 	}
 
+	// For estimate
+	flagOptionalContract := cli.StringFlag{
+		Name:        "contract",
+		Usage:       "estimate a call to this contract; estimates a deployment if missing",
+		Destination: &args.ContractAddress,
+	}
+
+	flagOptionalFunction := cli.StringFlag{
+		Name:        "function",
+		Destination: &args.Function,
+	}
+
+	flagSearchMinimalGasLimit := cli.BoolFlag{
+		Name:        "search-minimal",
+		Usage:       "search for the minimal gas limit with which the execution succeeds",
+		Destination: &args.SearchMinimalGasLimit,
+	}
+
 	// For create-account
 	flagAccountAddress := cli.StringFlag{
 		Required:    true,
@@ -216,6 +234,30 @@ func initializeCLI(facade *vmserver.DebugFacade) *cli.App {
 				flagGasLimit,
 			},
 		},
+		{
+			Name:        "estimate",
+			Description: "estimate the gas needed by a deployment or by a smart contract call",
+			Action: func(context *cli.Context) error {
+				_, err := facade.EstimateGas(args.toEstimateRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagOptionalContract,
+				flagImpersonated,
+				flagOptionalFunction,
+				flagCode,
+				flagCodePath,
+				flagCodeMetadata,
+				flagArguments,
+				flagValue,
+				flagGasLimit,
+				flagGasPrice,
+				flagSearchMinimalGasLimit,
+			},
+		},
 		{
 			Name:        "create-account",
 			Description: "create account",
@@ -23,6 +23,8 @@ type cliArguments struct {
 	Value           string
 	GasLimit        uint64
 	GasPrice        uint64
+	// For estimate
+	SearchMinimalGasLimit bool
 	// For blockchain-related action
 	AccountAddress string
 	AccountBalance string
@@ -90,6 +92,16 @@ func (args *cliArguments) toQueryRequest() vmserver.QueryRequest {
 	return *request
 }
 
+func (args *cliArguments) toEstimateRequest() vmserver.EstimateRequest {
+	request := &vmserver.EstimateRequest{}
+	args.populateDeployRequest(&request.DeployRequest)
+
+	request.ContractAddressHex = args.ContractAddress
+	request.Function = args.Function
+	request.SearchMinimalGasLimit = args.SearchMinimalGasLimit
+	return *request
+}
+
 func (args *cliArguments) toCreateAccountRequest() vmserver.CreateAccountRequest {
 	request := &vmserver.CreateAccountRequest{}
 	args.populateRequestBase(&request.RequestBase)
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/math"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/mock"
+	worldhook "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
+	gasSchedules "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec/gasSchedules"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/contexts"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/cryptoapi"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/hostCore"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/vmhooks"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/wasmanalyzer"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/wasmer"
+)
+
+const unknownFamily = "unknown"
+
+// inspectionGasLimit bounds the instantiation of the inspected contract
+const inspectionGasLimit = uint64(1000000000)
+
+// ExportInfo describes a function exported by the contract
+type ExportInfo struct {
+	Name        string `json:"name"`
+	InputArity  int    `json:"inputArity"`
+	OutputArity int    `json:"outputArity"`
+	Reserved    bool   `json:"reserved"`
+}
+
+// MemoryInfo describes the memory declared by the contract
+type MemoryInfo struct {
+	Declared     bool   `json:"declared"`
+	InitialPages uint32 `json:"initialPages"`
+	MaxPages     uint32 `json:"maxPages,omitempty"`
+	HasMax       bool   `json:"hasMax"`
+	LengthBytes  uint32 `json:"lengthBytes"`
+}
+
+// GasEstimation holds the gas costs which depend on the size of the code
+type GasEstimation struct {
+	CodeSize          uint64 `json:"codeSize"`
+	CompilePerByte    uint64 `json:"compilePerByte"`
+	AoTPreparePerByte uint64 `json:"aotPreparePerByte"`
+	DeployGas         uint64 `json:"deployGas"`
+	ExecutionBaseGas  uint64 `json:"executionBaseGas"`
+}
+
+// InspectionReport holds everything wasminspect finds out about a contract
+type InspectionReport struct {
+	Deployable          bool                `json:"deployable"`
+	InstantiationError  string              `json:"instantiationError,omitempty"`
+	Exports             []ExportInfo        `json:"exports"`
+	Imports             map[string][]string `json:"imports"`
+	Memory              MemoryInfo          `json:"memory"`
+	ESDTDisabledImports []string            `json:"esdtDisabledImports"`
+	ReservedConflicts   []string            `json:"reservedConflicts"`
+	Violations          []string            `json:"violations"`
+	Gas                 GasEstimation       `json:"gas"`
+}
+
+type reservedNamesChecker interface {
+	IsReserved(functionName string) bool
+}
+
+type contractInspector struct {
+	host             vmhost.VMHost
+	gasCost          *config.GasCost
+	reserved         reservedNamesChecker
+	analyzer         *wasmanalyzer.Analyzer
+	familiesByImport map[string]string
+}
+
+func newContractInspector(gasScheduleName string) (*contractInspector, error) {
+	gasSchedule, err := loadGasSchedule(gasScheduleName)
+	if err != nil {
+		return nil, err
+	}
+	gasCost, err := config.CreateGasConfig(gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the names of the builtin functions matter here; like for the scenarios, their costs come from
+	// the test gas map, since the gas schedules do not price all the builtin functions
+	world := worldhook.NewMockWorld()
+	err = world.InitBuiltinFunctions(config.MakeGasMapForTests())
+	if err != nil {
+		return nil, err
+	}
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return true
+		},
+	}
+	host, err := hostCore.NewVMHost(world, &vmhost.VMHostParameters{
+		VMType:               []byte{5, 0},
+		BlockGasLimit:        inspectionGasLimit,
+		GasSchedule:          gasSchedule,
+		BuiltInFuncContainer: world.BuiltinFuncs.Container,
+		ProtectedKeyPrefix:   []byte(core.ProtectedKeyPrefix),
+		EnableEpochsHandler:  enableEpochsHandler,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	familiesByImport, err := importFamilies()
+	if err != nil {
+		return nil, err
+	}
+
+	analyzerConfig := hostCore.NewContractAnalyzerConfig(host.GetAPIMethods(), world.BuiltinFuncs.Container, nil)
+	analyzer, err := wasmanalyzer.NewAnalyzer(analyzerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contractInspector{
+		host:             host,
+		gasCost:          gasCost,
+		reserved:         contexts.NewReservedFunctions(host.GetAPIMethods().Names(), world.BuiltinFuncs.Container),
+		analyzer:         analyzer,
+		familiesByImport: familiesByImport,
+	}, nil
+}
+
+func loadGasSchedule(name string) (config.GasScheduleMap, error) {
+	switch name {
+	case "v1":
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV1())
+	case "v2":
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV2())
+	case "v3":
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV3())
+	default:
+		return nil, fmt.Errorf("unknown gas schedule: %s", name)
+	}
+}
+
+// importFamilies maps each EEI function to the API family which declares it
+func importFamilies() (map[string]string, error) {
+	families := make(map[string]string)
+	addFamily := func(family string, imports *wasmer.Imports, err error) error {
+		if err != nil {
+			return err
+		}
+		for name := range imports.Names() {
+			families[name] = family
+		}
+		return nil
+	}
+
+	imports, err := vmhooks.BaseOpsAPIImports()
+	err = addFamily("baseOps", imports, err)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = vmhooks.BigIntImports(wasmer.NewImports())
+	err = addFamily("bigInt", imports, err)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = vmhooks.SmallIntImports(wasmer.NewImports())
+	err = addFamily("smallInt", imports, err)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = cryptoapi.CryptoImports(wasmer.NewImports())
+	err = addFamily("crypto", imports, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return families, nil
+}
+
+func (inspector *contractInspector) inspect(code []byte) *InspectionReport {
+	report := &InspectionReport{
+		Deployable:          true,
+		Exports:             make([]ExportInfo, 0),
+		Imports:             make(map[string][]string),
+		ESDTDisabledImports: make([]string, 0),
+		ReservedConflicts:   make([]string, 0),
+		Violations:          make([]string, 0),
+		Gas:                 inspector.estimateGas(code),
+	}
+
+	inspector.inspectInstance(code, report)
+	inspector.inspectModule(code, report)
+
+	return report
+}
+
+func (inspector *contractInspector) inspectInstance(code []byte, report *InspectionReport) {
+	options := wasmer.CompilationOptions{
+		GasLimit:           inspectionGasLimit,
+		UnmeteredLocals:    uint64(inspector.gasCost.WASMOpcodeCost.LocalsUnmetered),
+		MaxMemoryGrow:      contexts.MaxMemoryGrow,
+		MaxMemoryGrowDelta: contexts.MaxMemoryGrowDelta,
+		OpcodeTrace:        false,
+		Metering:           true,
+		RuntimeBreakpoints: true,
+	}
+	instance, err := wasmer.NewInstanceWithOptions(code, options)
+	if err != nil {
+		report.Deployable = false
+		report.InstantiationError = err.Error()
+		return
+	}
+	defer instance.Clean()
+
+	for name := range instance.GetExports() {
+		export := ExportInfo{
+			Name:     name,
+			Reserved: inspector.reserved.IsReserved(name),
+		}
+		signature, ok := instance.GetSignature(name)
+		if ok {
+			export.InputArity = signature.InputArity
+			export.OutputArity = signature.OutputArity
+		}
+		report.Exports = append(report.Exports, export)
+
+		if export.Reserved {
+			report.ReservedConflicts = append(report.ReservedConflicts, name)
+		}
+	}
+	sort.Slice(report.Exports, func(i, j int) bool {
+		return report.Exports[i].Name < report.Exports[j].Name
+	})
+	sort.Strings(report.ReservedConflicts)
+
+	report.Memory.Declared = instance.HasMemory()
+	if report.Memory.Declared {
+		report.Memory.LengthBytes = instance.GetMemory().Length()
+	}
+
+	for _, functionName := range vmhost.ESDTFunctionsImports {
+		if instance.IsFunctionImported(functionName) {
+			report.ESDTDisabledImports = append(report.ESDTDisabledImports, functionName)
+		}
+	}
+}
+
+func (inspector *contractInspector) inspectModule(code []byte, report *InspectionReport) {
+	analysis, err := inspector.analyzer.Analyze(code)
+	if err != nil {
+		report.Deployable = false
+		report.Violations = append(report.Violations, err.Error())
+		return
+	}
+
+	for _, memory := range analysis.Module.Memories {
+		report.Memory.InitialPages = memory.Min
+		report.Memory.MaxPages = memory.Max
+		report.Memory.HasMax = memory.HasMax
+	}
+
+	for _, imp := range analysis.Module.Imports {
+		if imp.Kind != wasmanalyzer.ExternalFunction {
+			continue
+		}
+		family, ok := inspector.familiesByImport[imp.Name]
+		if !ok {
+			family = unknownFamily
+		}
+		report.Imports[family] = append(report.Imports[family], imp.Name)
+	}
+	for _, names := range report.Imports {
+		sort.Strings(names)
+	}
+
+	for _, violation := range analysis.Violations {
+		report.Violations = append(report.Violations, violation.Error())
+		if isRejectedOnDeploy(violation) {
+			report.Deployable = false
+		}
+	}
+}
+
+// isRejectedOnDeploy tells whether the node itself would reject the contract
+// for this violation, as opposed to the stricter rules of the static analysis
+func isRejectedOnDeploy(violation *wasmanalyzer.Violation) bool {
+	return errors.Is(violation, wasmanalyzer.ErrMemoryDeclarationMissing) ||
+		errors.Is(violation, wasmanalyzer.ErrInvalidFunctionName) ||
+		errors.Is(violation, wasmanalyzer.ErrFunctionNonvoidSignature) ||
+		errors.Is(violation, wasmanalyzer.ErrUnknownImport) ||
+		errors.Is(violation, wasmanalyzer.ErrImportSignatureMismatch)
+}
+
+func (inspector *contractInspector) estimateGas(code []byte) GasEstimation {
+	baseOperationCost := inspector.gasCost.BaseOperationCost
+	codeSize := uint64(len(code))
+
+	deployGas := math.AddUint64(
+		inspector.gasCost.BaseOpsAPICost.CreateContract,
+		math.MulUint64(codeSize, baseOperationCost.CompilePerByte),
+	)
+	executionBaseGas := math.AddUint64(
+		baseOperationCost.GetCode,
+		math.MulUint64(codeSize, baseOperationCost.AoTPreparePerByte),
+	)
+
+	return GasEstimation{
+		CodeSize:          codeSize,
+		CompilePerByte:    baseOperationCost.CompilePerByte,
+		AoTPreparePerByte: baseOperationCost.AoTPreparePerByte,
+		DeployGas:         deployGas,
+		ExecutionBaseGas:  executionBaseGas,
+	}
+}
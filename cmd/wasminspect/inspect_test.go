@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const crowdfundingESDTPath = "../../test/crowdfunding-esdt/output/crowdfunding-esdt.wasm"
+
+// reservedExportPath is built from testdata/reserved-export.wat
+const reservedExportPath = "testdata/reserved-export.wasm"
+
+func inspectFile(t *testing.T, path string) *InspectionReport {
+	inspector, err := newContractInspector("v3")
+	require.Nil(t, err)
+
+	code, err := os.ReadFile(path)
+	require.Nil(t, err)
+
+	return inspector.inspect(code)
+}
+
+func TestNewContractInspector_GasSchedules(t *testing.T) {
+	for _, gasScheduleName := range []string{"v1", "v2", "v3"} {
+		inspector, err := newContractInspector(gasScheduleName)
+		require.Nil(t, err, gasScheduleName)
+		require.NotNil(t, inspector, gasScheduleName)
+	}
+
+	inspector, err := newContractInspector("v4")
+	require.EqualError(t, err, "unknown gas schedule: v4")
+	require.Nil(t, inspector)
+}
+
+func TestInspect_ImportFamilies(t *testing.T) {
+	report := inspectFile(t, reservedExportPath)
+	require.Equal(t, map[string][]string{
+		"baseOps":  {"getNumArguments", "transferESDTExecute"},
+		"bigInt":   {"bigIntAdd"},
+		"smallInt": {"int64getArgument"},
+	}, report.Imports)
+
+	report = inspectFile(t, crowdfundingESDTPath)
+	require.Contains(t, report.Imports, "baseOps")
+	require.Contains(t, report.Imports, "bigInt")
+	require.Contains(t, report.Imports["baseOps"], "transferESDTExecute")
+	require.NotContains(t, report.Imports, unknownFamily)
+}
+
+func TestInspect_ReservedConflicts(t *testing.T) {
+	report := inspectFile(t, reservedExportPath)
+	require.False(t, report.Deployable)
+	require.Equal(t, []string{"getArgument"}, report.ReservedConflicts)
+	require.Equal(t, []ExportInfo{
+		{Name: "getArgument", Reserved: true},
+		{Name: "init"},
+	}, report.Exports)
+	require.Len(t, report.Violations, 1)
+	require.Contains(t, report.Violations[0], "invalid function name")
+	require.Contains(t, report.Violations[0], `"getArgument"`)
+}
+
+func TestInspect_ESDTDisabledImports(t *testing.T) {
+	report := inspectFile(t, reservedExportPath)
+	require.Equal(t, []string{"transferESDTExecute"}, report.ESDTDisabledImports)
+
+	report = inspectFile(t, crowdfundingESDTPath)
+	require.True(t, report.Deployable)
+	require.Empty(t, report.ReservedConflicts)
+	require.Empty(t, report.Violations)
+	require.Contains(t, report.ESDTDisabledImports, "transferESDTExecute")
+	require.Contains(t, report.ESDTDisabledImports, "bigIntGetESDTExternalBalance")
+}
+
+func TestInspect_Memory(t *testing.T) {
+	report := inspectFile(t, reservedExportPath)
+	require.Equal(t, MemoryInfo{
+		Declared:     true,
+		InitialPages: 2,
+		LengthBytes:  2 * 65536,
+	}, report.Memory)
+}
+
+func TestInspect_InvalidCode(t *testing.T) {
+	inspector, err := newContractInspector("v3")
+	require.Nil(t, err)
+
+	report := inspector.inspect([]byte("not wasm"))
+	require.False(t, report.Deployable)
+	require.NotEmpty(t, report.InstantiationError)
+	require.NotEmpty(t, report.Violations)
+	require.Equal(t, uint64(len("not wasm")), report.Gas.CodeSize)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrCodeSuccess signals success
+	ErrCodeSuccess = iota
+	// ErrCodeCriticalError signals a critical error
+	ErrCodeCriticalError
+	// ErrCodeNotDeployable signals that the contract would be rejected on deployment
+	ErrCodeNotDeployable
+)
+
+type cliArguments struct {
+	Format      string
+	GasSchedule string
+}
+
+func main() {
+	app := initializeCLI()
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(ErrCodeCriticalError)
+	}
+
+	os.Exit(ErrCodeSuccess)
+}
+
+func initializeCLI() *cli.App {
+	app := cli.NewApp()
+	app.Name = "wasminspect"
+	app.Usage = "checks whether a contract can be deployed on VM v1.3 and what it uses"
+	app.ArgsUsage = "<contract.wasm>"
+
+	args := &cliArguments{}
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:        "format",
+			Value:       formatText,
+			Usage:       "output format: text or json",
+			Destination: &args.Format,
+		},
+		cli.StringFlag{
+			Name:        "gas-schedule",
+			Value:       "v3",
+			Usage:       "gas schedule used for the deploy cost estimation: v1, v2 or v3",
+			Destination: &args.GasSchedule,
+		},
+	}
+
+	app.Authors = []cli.Author{
+		{
+			Name:  "The MultiversX Team",
+			Email: "contact@multiversx.com",
+		},
+	}
+
+	app.Action = func(context *cli.Context) error {
+		if context.NArg() != 1 {
+			return fmt.Errorf("one argument expected - the path to the .wasm file")
+		}
+
+		code, err := os.ReadFile(context.Args().First())
+		if err != nil {
+			return err
+		}
+
+		inspector, err := newContractInspector(args.GasSchedule)
+		if err != nil {
+			return err
+		}
+
+		report := inspector.inspect(code)
+		err = writeReport(os.Stdout, report, args.Format)
+		if err != nil {
+			return err
+		}
+
+		if !report.Deployable {
+			os.Exit(ErrCodeNotDeployable)
+		}
+		return nil
+	}
+
+	return app
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+func writeReport(writer io.Writer, report *InspectionReport, format string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case formatText:
+		return writeTextReport(writer, report)
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+func writeTextReport(writer io.Writer, report *InspectionReport) error {
+	builder := &strings.Builder{}
+
+	if report.Deployable {
+		builder.WriteString("deployable: yes\n")
+	} else {
+		builder.WriteString("deployable: NO\n")
+	}
+	if len(report.InstantiationError) > 0 {
+		fmt.Fprintf(builder, "instantiation error: %s\n", report.InstantiationError)
+	}
+
+	fmt.Fprintf(builder, "\nexports (%d):\n", len(report.Exports))
+	for _, export := range report.Exports {
+		reserved := ""
+		if export.Reserved {
+			reserved = "  [reserved name]"
+		}
+		fmt.Fprintf(builder, "  %s (%d params, %d results)%s\n", export.Name, export.InputArity, export.OutputArity, reserved)
+	}
+
+	families := make([]string, 0, len(report.Imports))
+	for family := range report.Imports {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	builder.WriteString("\nimports:\n")
+	for _, family := range families {
+		names := report.Imports[family]
+		fmt.Fprintf(builder, "  %s (%d): %s\n", family, len(names), strings.Join(names, ", "))
+	}
+
+	builder.WriteString("\nmemory:\n")
+	if report.Memory.Declared {
+		fmt.Fprintf(builder, "  initial pages: %d\n", report.Memory.InitialPages)
+		if report.Memory.HasMax {
+			fmt.Fprintf(builder, "  max pages: %d\n", report.Memory.MaxPages)
+		}
+		fmt.Fprintf(builder, "  length: %d bytes\n", report.Memory.LengthBytes)
+	} else {
+		builder.WriteString("  not declared\n")
+	}
+
+	writeTextList(builder, "imports rejected while ESDT functions are disabled", report.ESDTDisabledImports)
+	writeTextList(builder, "reserved name conflicts", report.ReservedConflicts)
+	writeTextList(builder, "static analysis violations", report.Violations)
+
+	builder.WriteString("\ngas:\n")
+	fmt.Fprintf(builder, "  code size: %d bytes\n", report.Gas.CodeSize)
+	fmt.Fprintf(builder, "  deploy (CreateContract + CompilePerByte x %d): %d\n", report.Gas.CompilePerByte, report.Gas.DeployGas)
+	fmt.Fprintf(builder, "  execution base (GetCode + AoTPreparePerByte x %d): %d\n", report.Gas.AoTPreparePerByte, report.Gas.ExecutionBaseGas)
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+func writeTextList(builder *strings.Builder, title string, items []string) {
+	fmt.Fprintf(builder, "\n%s (%d):\n", title, len(items))
+	for _, item := range items {
+		fmt.Fprintf(builder, "  %s\n", item)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReport() *InspectionReport {
+	return &InspectionReport{
+		Deployable: false,
+		Exports: []ExportInfo{
+			{Name: "getArgument", Reserved: true},
+			{Name: "init"},
+		},
+		Imports: map[string][]string{
+			"bigInt":  {"bigIntAdd"},
+			"baseOps": {"getNumArguments", "transferESDTExecute"},
+		},
+		Memory: MemoryInfo{
+			Declared:     true,
+			InitialPages: 2,
+			LengthBytes:  131072,
+		},
+		ESDTDisabledImports: []string{"transferESDTExecute"},
+		ReservedConflicts:   []string{"getArgument"},
+		Violations:          []string{`invalid function name: "getArgument"`},
+		Gas: GasEstimation{
+			CodeSize:          100,
+			CompilePerByte:    2,
+			AoTPreparePerByte: 3,
+			DeployGas:         1200,
+			ExecutionBaseGas:  1300,
+		},
+	}
+}
+
+func TestWriteReport_Text(t *testing.T) {
+	output := &bytes.Buffer{}
+	err := writeReport(output, newTestReport(), formatText)
+	require.Nil(t, err)
+
+	expected := `deployable: NO
+
+exports (2):
+  getArgument (0 params, 0 results)  [reserved name]
+  init (0 params, 0 results)
+
+imports:
+  baseOps (2): getNumArguments, transferESDTExecute
+  bigInt (1): bigIntAdd
+
+memory:
+  initial pages: 2
+  length: 131072 bytes
+
+imports rejected while ESDT functions are disabled (1):
+  transferESDTExecute
+
+reserved name conflicts (1):
+  getArgument
+
+static analysis violations (1):
+  invalid function name: "getArgument"
+
+gas:
+  code size: 100 bytes
+  deploy (CreateContract + CompilePerByte x 2): 1200
+  execution base (GetCode + AoTPreparePerByte x 3): 1300
+`
+	require.Equal(t, expected, output.String())
+}
+
+func TestWriteReport_TextNoMemory(t *testing.T) {
+	report := newTestReport()
+	report.Deployable = true
+	report.Memory = MemoryInfo{}
+
+	output := &bytes.Buffer{}
+	err := writeReport(output, report, formatText)
+	require.Nil(t, err)
+	require.Contains(t, output.String(), "deployable: yes\n")
+	require.Contains(t, output.String(), "\nmemory:\n  not declared\n")
+}
+
+func TestWriteReport_JSON(t *testing.T) {
+	report := newTestReport()
+
+	output := &bytes.Buffer{}
+	err := writeReport(output, report, formatJSON)
+	require.Nil(t, err)
+	require.Contains(t, output.String(), `"reservedConflicts": [`)
+	require.NotContains(t, output.String(), "maxPages")
+
+	decoded := &InspectionReport{}
+	err = json.Unmarshal(output.Bytes(), decoded)
+	require.Nil(t, err)
+	require.Equal(t, report, decoded)
+}
+
+func TestWriteReport_UnknownFormat(t *testing.T) {
+	output := &bytes.Buffer{}
+	err := writeReport(output, newTestReport(), "xml")
+	require.EqualError(t, err, "unknown output format: xml")
+	require.Empty(t, output.String())
+}
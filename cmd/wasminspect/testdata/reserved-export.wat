@@ -0,0 +1,15 @@
+;; Small contract which exports the reserved name "getArgument" and imports
+;; functions from the baseOps, bigInt and smallInt families, among which the
+;; ESDT function transferESDTExecute.
+(module
+  (type $void (func))
+  (import "env" "getNumArguments" (func (result i32)))
+  (import "env" "bigIntAdd" (func (param i32 i32 i32)))
+  (import "env" "int64getArgument" (func (param i32) (result i64)))
+  (import "env" "transferESDTExecute"
+    (func (param i32 i32 i32 i32 i64 i32 i32 i32 i32 i32) (result i32)))
+  (memory 2)
+  (export "memory" (memory 0))
+  (func $init (type $void))
+  (export "init" (func $init))
+  (export "getArgument" (func $init)))
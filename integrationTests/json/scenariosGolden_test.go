@@ -0,0 +1,274 @@
+package vmjsonintegrationtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	am "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec"
+	mc "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/controller"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+	"github.com/stretchr/testify/require"
+)
+
+// copySelfTestScenario copies a self-test scenario to the directory, converted to the format of the new name,
+// with some of its contents replaced, e.g. to break an expectation
+func copySelfTestScenario(t *testing.T, dir string, fileName string, newFileName string, replacements ...string) string {
+	contents, err := os.ReadFile(filepath.Join(getTestRoot(), "scenarios-self-test", fileName))
+	require.Nil(t, err)
+	contents = []byte(strings.NewReplacer(replacements...).Replace(string(contents)))
+
+	format := oj.FormatFromPath(newFileName)
+	if format != oj.FormatJSON {
+		scenarioOJ, err := oj.ParseOrderedJSON(contents)
+		require.Nil(t, err)
+		contents = []byte(oj.FormattedString(scenarioOJ, format))
+	}
+
+	scenarioPath := filepath.Join(dir, newFileName)
+	err = os.WriteFile(scenarioPath, contents, 0644)
+	require.Nil(t, err)
+	return scenarioPath
+}
+
+func writeScenarioFile(t *testing.T, dir string, fileName string, contents string) string {
+	scenarioPath := filepath.Join(dir, fileName)
+	err := os.WriteFile(scenarioPath, []byte(contents), 0644)
+	require.Nil(t, err)
+	return scenarioPath
+}
+
+func readScenarioFile(t *testing.T, scenarioPath string) string {
+	contents, err := os.ReadFile(scenarioPath)
+	require.Nil(t, err)
+	return string(contents)
+}
+
+func runScenario(t *testing.T, scenarioPath string, goldenMode bool) error {
+	executor, err := am.NewVMTestExecutor()
+	require.Nil(t, err)
+	executor.SetGoldenMode(goldenMode)
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	return runner.RunSingleJSONScenario(scenarioPath)
+}
+
+func TestGoldenMode_RewritesCheckState(t *testing.T) {
+	dir := t.TempDir()
+	scenarioPath := copySelfTestScenario(t, dir, "transfer-egld.scen.json", "transfer-egld.scen.json",
+		`"balance": "50"`, `"balance": "51"`)
+	before := readScenarioFile(t, scenarioPath)
+	require.NotNil(t, runScenario(t, scenarioPath, false))
+
+	err := runScenario(t, scenarioPath, true)
+	require.Nil(t, err)
+	after := readScenarioFile(t, scenarioPath)
+	require.NotEqual(t, before, after)
+	require.Contains(t, after, `"balance": "50"`)
+	require.NotContains(t, after, `"balance": "51"`)
+	require.Nil(t, runScenario(t, scenarioPath, false))
+
+	// the steps before and after the rewritten one are left as they are
+	stepsBefore := before[:strings.Index(before, `"step": "checkState"`)]
+	require.True(t, strings.HasPrefix(after, stepsBefore))
+	lastStepsBefore := before[strings.Index(before, `"txId": "2"`):]
+	require.True(t, strings.HasSuffix(after, lastStepsBefore))
+
+	// nothing left to update, the file is not written again
+	err = runScenario(t, scenarioPath, true)
+	require.Nil(t, err)
+	require.Equal(t, after, readScenarioFile(t, scenarioPath))
+}
+
+func TestGoldenMode_RewritesCheckStateInBlock(t *testing.T) {
+	dir := t.TempDir()
+	scenarioPath := copySelfTestScenario(t, dir, "blocks.scen.json", "blocks.scen.json",
+		`"balance": "700"`, `"balance": "701"`,
+		`"balance": "400"`, `"balance": "401"`)
+	_ = copySelfTestScenario(t, dir, "blocks-transfer.steps.json", "blocks-transfer.steps.json")
+	require.NotNil(t, runScenario(t, scenarioPath, false))
+
+	err := runScenario(t, scenarioPath, true)
+	require.Nil(t, err)
+	after := readScenarioFile(t, scenarioPath)
+	require.Contains(t, after, `"balance": "700"`)
+	require.Contains(t, after, `"balance": "400"`)
+	require.Contains(t, after, `"comment": "follows the current block"`)
+	require.Nil(t, runScenario(t, scenarioPath, false))
+}
+
+func TestGoldenMode_RewritesExternalSteps(t *testing.T) {
+	dir := t.TempDir()
+	scenarioPath := writeScenarioFile(t, dir, "main.scen.json", `{
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "100"
+                }
+            }
+        },
+        {
+            "step": "block",
+            "steps": [
+                {
+                    "step": "externalSteps",
+                    "path": "check.steps.json"
+                }
+            ]
+        }
+    ]
+}
+`)
+	mainBefore := readScenarioFile(t, scenarioPath)
+	externalPath := writeScenarioFile(t, dir, "check.steps.json", `{
+    "steps": [
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:A": {
+                    "balance": "1"
+                }
+            }
+        }
+    ]
+}
+`)
+
+	err := runScenario(t, scenarioPath, true)
+	require.Nil(t, err)
+	require.Equal(t, mainBefore, readScenarioFile(t, scenarioPath))
+	require.Contains(t, readScenarioFile(t, externalPath), `"balance": "100"`)
+	require.Nil(t, runScenario(t, scenarioPath, false))
+}
+
+func TestGoldenMode_RefusesReplacedPlaceholders(t *testing.T) {
+	dir := t.TempDir()
+	scenarioPath := writeScenarioFile(t, dir, "main.scen.json", `{
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "100"
+                }
+            }
+        },
+        {
+            "step": "externalSteps",
+            "path": "check.steps.json",
+            "parameters": {
+                "BALANCE": "1"
+            }
+        }
+    ]
+}
+`)
+	externalContents := `{
+    "steps": [
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:A": {
+                    "nonce": "0",
+                    "balance": "100"
+                }
+            }
+        },
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:A": {
+                    "balance": "{{BALANCE}}"
+                }
+            }
+        }
+    ]
+}
+`
+	externalPath := writeScenarioFile(t, dir, "check.steps.json", externalContents)
+
+	err := runScenario(t, scenarioPath, true)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cannot update the checkState steps steps[1] in "+externalPath)
+	require.Equal(t, externalContents, readScenarioFile(t, externalPath))
+}
+
+func TestGoldenMode_KeepsFormat(t *testing.T) {
+	for _, fileName := range []string{"transfer-egld.scen.yaml", "transfer-egld.scen.json5"} {
+		t.Run(fileName, func(t *testing.T) {
+			dir := t.TempDir()
+			scenarioPath := copySelfTestScenario(t, dir, "transfer-egld.scen.json", fileName,
+				`"balance": "50"`, `"balance": "51"`)
+			require.NotNil(t, runScenario(t, scenarioPath, false))
+
+			err := runScenario(t, scenarioPath, true)
+			require.Nil(t, err)
+			after := readScenarioFile(t, scenarioPath)
+			require.NotContains(t, after, "51")
+
+			// the file is still written in its own format, not in JSON
+			_, err = oj.ParseOrderedJSON([]byte(after))
+			require.NotNil(t, err)
+			format := oj.FormatFromPath(fileName)
+			afterOJ, err := oj.ParseOrderedFormat([]byte(after), format)
+			require.Nil(t, err)
+			require.Equal(t, strings.TrimSuffix(oj.FormattedString(afterOJ, format), "\n")+"\n", after)
+			require.Nil(t, runScenario(t, scenarioPath, false))
+		})
+	}
+}
+
+func TestGoldenMode_RefusesNonCanonicalFiles(t *testing.T) {
+	dir := t.TempDir()
+	commentedContents := `// hand-written, with comments
+{
+    steps: [
+        {
+            step: 'setState',
+            accounts: {
+                'address:A': {nonce: 0, balance: 100}, // the only account
+            },
+        },
+        {
+            step: 'checkState',
+            accounts: {
+                'address:A': {nonce: 0, balance: 100},
+            },
+        },
+        {
+            step: 'checkState',
+            accounts: {
+                'address:A': {nonce: 0, balance: 1},
+            },
+        },
+    ],
+}
+`
+	commentedPath := writeScenarioFile(t, dir, "commented.scen.json5", commentedContents)
+	compactContents := `{"steps": [
+    {"step": "setState", "accounts": {"address:A": {"nonce": "0", "balance": "100"}}},
+    {"step": "checkState", "accounts": {"address:A": {"nonce": "0", "balance": "1"}}}
+]}
+`
+	compactPath := writeScenarioFile(t, dir, "compact.scen.json", compactContents)
+
+	err := runScenario(t, commentedPath, true)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cannot update the checkState steps steps[2] in "+commentedPath+", because it is not in canonical form")
+	require.Equal(t, commentedContents, readScenarioFile(t, commentedPath))
+
+	err = runScenario(t, compactPath, true)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cannot update the checkState steps steps[1] in "+compactPath+", because it is not in canonical form")
+	require.Equal(t, compactContents, readScenarioFile(t, compactPath))
+
+	// with nothing to update, they run in golden mode as usual
+	fixedContents := strings.Replace(commentedContents, "balance: 1}", "balance: 100}", 1)
+	writeScenarioFile(t, dir, "commented.scen.json5", fixedContents)
+	require.Nil(t, runScenario(t, commentedPath, true))
+	require.Equal(t, fixedContents, readScenarioFile(t, commentedPath))
+}
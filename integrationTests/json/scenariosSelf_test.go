@@ -1,8 +1,15 @@
 package vmjsonintegrationtest
 
 import (
+	"path"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	am "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec"
+	mc "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/controller"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/hostCore"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,6 +19,14 @@ func TestScenariosSelfTest(t *testing.T) {
 		"scenarios-self-test/builtin-func-esdt-transfer.scen.json",
 		"scenarios-self-test/esdt-zero-balance-check-err.scen.json",
 		"scenarios-self-test/esdt-non-zero-balance-check-err.scen.json",
+		"scenarios-self-test/esdt-paused-transfer-err.scen.json",
+		"scenarios-self-test/esdt-frozen-sender-err.scen.json",
+		"scenarios-self-test/esdt-frozen-receiver-err.scen.json",
+		"scenarios-self-test/esdt-limited-transfer-err.scen.json",
+		"scenarios-self-test/block-gas-limit-err.scen.json",
+		"scenarios-self-test/block-gas-limit-custom-err.scen.json",
+		"scenarios-self-test/block-gas-limit-external-err.scen.json",
+		"scenarios-self-test/enable-epochs-unknown-flag-err.scen.json",
 	})
 }
 
@@ -47,87 +62,209 @@ func TestSetAccountSCAddressErr3(t *testing.T) {
 
 func TestScenariosCheckNonceErr(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-nonce.err.json")
-	require.EqualError(t, err,
-		"bad account nonce. Account: address:the-address. Want: \"1002\". Have: \"1001\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    nonce: want \"1002\", have \"1001\"")
 }
 
 func TestScenariosCheckBalanceErr(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-balance.err.json")
-	require.EqualError(t, err,
-		"bad account balance. Account: address:the-address. Want: \"1,000,002\". Have: \"1000001\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    balance: want \"1,000,002\", have \"1000001\"")
 }
 
 func TestScenariosCheckUsernameErr(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-username.err.json")
-	require.EqualError(t, err,
-		"bad account username. Account: address:the-address. Want: \"str:wrong.domain\". Have: \"str:theusername.domain\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    username: want \"str:wrong.domain\", have \"str:theusername.domain\"")
 }
 
 func TestScenariosCheckCodeErr(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-code.err.json")
-	require.EqualError(t, err,
-		"bad account code. Account: sc:contract-address. Want: \"file:set-check-code.scen.json\". Have: \"0x7b0a2020202022636f6d...\"")
+	requireCheckStateDiff(t, err,
+		"  account \"sc:contract-address\":\n"+
+			"    code: want \"file:set-check-code.scen.json\", have \"0x7b0a2020202022636f6d...\"")
 }
 
 func TestScenariosCheckStorageErr1(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-storage.err1.json")
-	require.EqualError(t, err,
-		"wrong account storage for account \"address:the-address\":\n"+
-			"  for key 0x6b65792d63 (str:key-c): Want: \"str:another-value\". Have: \"0x76616c75652d63 (str:value-c)\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    storage \"str:key-c\": want \"str:another-value\", have \"str:value-c\"")
 }
 
 func TestScenariosCheckStorageErr2(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-storage.err2.json")
-	require.EqualError(t, err,
-		"wrong account storage for account \"address:the-address\":\n"+
-			"  for key 0x6b65792d63 (str:key-c): Want: \"\". Have: \"0x76616c75652d63 (str:value-c)\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    storage \"str:key-c\": want \"\", have \"str:value-c\"")
 }
 
 func TestScenariosCheckStorageErr3(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-storage.err3.json")
-	require.EqualError(t, err,
-		"wrong account storage for account \"address:the-address\":\n"+
-			"  for key 0x6b65792d64 (str:key-d): Want: \"str:value-d\". Have: \"\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    storage \"str:key-d\": want \"str:value-d\", have \"\"")
 }
 
 func TestScenariosCheckStorageErr4(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-storage.err4.json")
-	require.EqualError(t, err,
-		"wrong account storage for account \"address:the-address\":\n"+
-			"  for key 0x6b65792d63 (str:key-c): Want: \"\". Have: \"0x76616c75652d63 (str:value-c)\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    storage \"str:key-c\": want \"\", have \"str:value-c\"")
 }
 
 func TestScenariosCheckStorageErr5(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-storage.err5.json")
-	require.EqualError(t, err,
-		"wrong account storage for account \"address:the-address\":\n"+
-			"  for key 0x6b65792d62 (str:key-b): Want: \"str:another-b\". Have: \"0x76616c75652d62 (str:value-b)\"")
+	requireCheckStateDiff(t, err,
+		"  account \"address:the-address\":\n"+
+			"    storage \"str:key-b\": want \"str:another-b\", have \"str:value-b\"")
 }
 
 func TestScenariosCheckESDTErr1(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-esdt.err1.json")
-	require.EqualError(t, err,
-		`mismatch for account "address:the-address":
-  for token: NFT-123456, nonce: 1: Bad balance. Want: "4". Have: "1"
-  for token: NFT-123456, nonce: 1: Bad creator. Want: "address:another-address". Have: "address:the-address"
-  for token: NFT-123456, nonce: 1: Bad royalties. Want: "2001". Have: "2000"
-  for token: NFT-123456, nonce: 1: Bad hash. Want: "keccak256:str:another_hash". Have: 0x54e3ea4bdef3b22154767a2cae081fca2bec2eae1ec62ee71308cb2a300d675d (str:"T\xe3\xeaK\xde\xf3\xb2!Tvz,\xae\b\x1f\xca+\xec.\xae\x1e\xc6.\xe7\x13\b\xcb*0\rg]")
-  for token: NFT-123456, nonce: 1: Bad URI. Want: [
+	requireCheckStateDiff(t, err,
+		`  account "address:the-address":
+    esdt "str:NFT-123456" nonce 1 balance: want "4", have "1"
+    esdt "str:NFT-123456" nonce 1 creator: want "address:another-address", have "address:the-address"
+    esdt "str:NFT-123456" nonce 1 royalties: want "2001", have "2000"
+    esdt "str:NFT-123456" nonce 1 hash: want "keccak256:str:another_hash", have "0x54e3ea4bdef3b22154767a2cae081fca2bec2eae1ec62ee71308cb2a300d675d"
+    esdt "str:NFT-123456" nonce 1 uri: want [
     "str:www.cool_nft.com/another_nft.jpg"
-]. Have: "str:www.cool_nft.com/my_nft.jpg"
-  for token: NFT-123456, nonce: 1: Bad attributes. Want: "str:other_attributes". Have: "str:serialized_attributes"`)
+], have "str:www.cool_nft.com/my_nft.jpg"
+    esdt "str:NFT-123456" nonce 1 attributes: want "str:other_attributes", have "str:serialized_attributes"`)
+}
+
+func TestScenariosCheckESDTErr1Suggestion(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test/set-check", "set-check-esdt.err1.json")
+	var checkStateErr *am.CheckStateError
+	require.ErrorAs(t, err, &checkStateErr)
+	require.Equal(t, `{
+    "step": "checkState",
+    "accounts": {
+        "address:the-address": {
+            "esdt": {
+                "str:NFT-123456": {
+                    "nonce": "1",
+                    "balance": "1",
+                    "creator": "address:the-address",
+                    "royalties": "2000",
+                    "hash": "0x54e3ea4bdef3b22154767a2cae081fca2bec2eae1ec62ee71308cb2a300d675d",
+                    "uri": "str:www.cool_nft.com/my_nft.jpg",
+                    "attributes": "str:serialized_attributes"
+                }
+            },
+            "storage": {}
+        }
+    }
+}`, checkStateErr.SuggestedCheckState())
 }
 
 func TestScenariosEsdtZeroBalance(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test", "esdt-zero-balance-check-err.scen.json")
-	require.EqualError(t, err,
-		`mismatch for account "address:A":
-  for token: TOK-123, nonce: 0: Bad balance. Want: "". Have: "150"`)
+	requireCheckStateDiff(t, err,
+		`  account "address:A":
+    esdt "str:TOK-123" nonce 0 balance: want "", have "150"`)
 }
 
 func TestScenariosEsdtNonZeroBalance(t *testing.T) {
 	err := runSingleTestReturnError("scenarios-self-test", "esdt-non-zero-balance-check-err.scen.json")
+	requireCheckStateDiff(t, err,
+		`  account "address:B":
+    esdt "str:TOK-123" nonce 0 balance: want "100", have "0"`)
+}
+
+func TestScenariosEsdtPausedTransfer(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "esdt-paused-transfer-err.scen.json")
+	require.ErrorIs(t, err, builtInFunctions.ErrESDTTokenIsPaused)
+}
+
+func TestScenariosEsdtFrozenSender(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "esdt-frozen-sender-err.scen.json")
+	require.ErrorIs(t, err, builtInFunctions.ErrESDTIsFrozenForAccount)
+}
+
+func TestScenariosEsdtFrozenReceiver(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "esdt-frozen-receiver-err.scen.json")
+	require.ErrorIs(t, err, builtInFunctions.ErrESDTIsFrozenForAccount)
+}
+
+func TestScenariosEsdtLimitedTransfer(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "esdt-limited-transfer-err.scen.json")
+	require.ErrorIs(t, err, builtInFunctions.ErrActionNotAllowed)
+}
+
+func TestScenariosBlockGasLimit(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "block-gas-limit-err.scen.json")
+	require.ErrorIs(t, err, am.ErrBlockGasLimitExceeded)
+	require.EqualError(t, err,
+		"block gas limit exceeded: tx 2 brings the gas of block 11 to 10000001, limit is 10000000")
+}
+
+func TestScenariosBlockGasLimitSetByBlock(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "block-gas-limit-custom-err.scen.json")
 	require.EqualError(t, err,
-		`mismatch for account "address:B":
-  for token: TOK-123, nonce: 0: Bad balance. Want: "100". Have: "0"`)
+		"block gas limit exceeded: tx 1 brings the gas of block 11 to 1000001, limit is 1000000")
+}
+
+func TestScenariosBlockGasLimitExternalSteps(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "block-gas-limit-external-err.scen.json")
+	require.ErrorIs(t, err, am.ErrBlockGasLimitExceeded)
+	require.EqualError(t, err,
+		"block gas limit exceeded: tx 2 brings the gas of block 11 to 10000001, limit is 10000000")
+}
+
+func TestScenariosEnableEpochsUnknownFlag(t *testing.T) {
+	err := runSingleTestReturnError("scenarios-self-test", "enable-epochs-unknown-flag-err.scen.json")
+	require.ErrorIs(t, err, am.ErrUnknownFlag)
+}
+
+func TestScenariosFlagMatrix(t *testing.T) {
+	scenarioPath := path.Join(getTestRoot(), "scenarios-self-test", "transfer-egld.scen.json")
+	report, err := am.RunScenarioFlagMatrix(scenarioPath)
+	require.Nil(t, err)
+	require.Nil(t, report.Baseline.Err)
+	require.Equal(t, 16, report.NumRuns)
+	require.Empty(t, report.Differences)
+}
+
+func TestScenariosEnableEpochsWithDisabledFlags(t *testing.T) {
+	executor, err := am.NewVMTestExecutor()
+	require.Nil(t, err)
+	// what the flag matrix does, when it disables a flag
+	err = executor.DisableFlags([]core.EnableEpochFlag{hostCore.RepairCallbackFlag})
+	require.Nil(t, err)
+
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	err = runner.RunSingleJSONScenario(path.Join(getTestRoot(), "scenarios-self-test", "enable-epochs.scen.json"))
+	require.Nil(t, err)
+
+	// the scenario activates AheadOfTimeGasUsageFlag in epoch 2, the disabled flag stays inactive
+	host := executor.GetVM().(vmhost.VMHost)
+	require.Equal(t, uint32(1), executor.World.CurrentEpoch())
+	require.False(t, host.IsAheadOfTimeCompileEnabled())
+	require.False(t, host.IsVMV3Enabled())
+	require.True(t, host.IsVMV2Enabled())
+
+	executor.World.CurrentBlockInfo.BlockEpoch = 2
+	require.True(t, host.IsAheadOfTimeCompileEnabled())
+	require.False(t, host.IsVMV3Enabled())
+	require.True(t, host.IsVMV2Enabled())
+}
+
+func TestScenariosFlagMatrixEnableEpochs(t *testing.T) {
+	scenarioPath := path.Join(getTestRoot(), "scenarios-self-test", "enable-epochs.scen.json")
+	report, err := am.RunScenarioFlagMatrix(scenarioPath)
+	require.Nil(t, err)
+	require.Nil(t, report.Baseline.Err)
+	require.Equal(t, 16, report.NumRuns)
+	require.Empty(t, report.Differences)
+}
+
+func requireCheckStateDiff(t *testing.T, err error, expectedDiff string) {
+	var checkStateErr *am.CheckStateError
+	require.ErrorAs(t, err, &checkStateErr)
+	require.Equal(t, expectedDiff, checkStateErr.Diff())
 }
@@ -1,8 +1,6 @@
 package common
 
 import (
-	"os"
-
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
 )
@@ -14,16 +12,16 @@ type VMArguments struct {
 	MessagesMarshalizer marshaling.MarshalizerKind
 }
 
-// SendVMArguments sends initialization arguments through a pipe
-func SendVMArguments(pipe *os.File, pipeArguments VMArguments) error {
+// SendVMArguments sends initialization arguments through a pipe or a connection
+func SendVMArguments(pipe SenderStream, pipeArguments VMArguments) error {
 	sender := NewSender(pipe, createArgumentsMarshalizer())
 	message := NewMessageInitialize(pipeArguments)
 	_, err := sender.Send(message)
 	return err
 }
 
-// GetVMArguments reads initialization arguments from the pipe
-func GetVMArguments(pipe *os.File) (*VMArguments, error) {
+// GetVMArguments reads initialization arguments from the pipe or the connection
+func GetVMArguments(pipe ReceiverStream) (*VMArguments, error) {
 	receiver := NewReceiver(pipe, createArgumentsMarshalizer())
 	message, _, err := receiver.Receive(0)
 	if err != nil {
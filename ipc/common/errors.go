@@ -1,6 +1,7 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -23,10 +24,10 @@ func (err *CriticalError) Unwrap() error {
 	return err.InnerErr
 }
 
-// IsCriticalError returns whether the error is critical
+// IsCriticalError returns whether the error is critical (or wraps a critical error)
 func IsCriticalError(err error) bool {
-	_, ok := err.(*CriticalError)
-	return ok
+	var criticalError *CriticalError
+	return errors.As(err, &criticalError)
 }
 
 // ErrBadVMArguments signals a critical error
@@ -68,6 +69,18 @@ var ErrCannotReceiveHookCallResponse = &CriticalError{InnerErr: fmt.Errorf("cann
 // ErrBadHookResponseFromNode signals a critical error
 var ErrBadHookResponseFromNode = &CriticalError{InnerErr: fmt.Errorf("bad hook response from node")}
 
+// ErrTransportNotNetworked signals a critical error
+var ErrTransportNotNetworked = &CriticalError{InnerErr: fmt.Errorf("transport is not unix or tcp")}
+
+// ErrIncompatibleProtocolVersion signals a critical error
+var ErrIncompatibleProtocolVersion = &CriticalError{InnerErr: fmt.Errorf("incompatible protocol versions of node and vm")}
+
+// ErrIncompatibleMessageKinds signals a critical error
+var ErrIncompatibleMessageKinds = &CriticalError{InnerErr: fmt.Errorf("incompatible message kinds of node and vm")}
+
+// ErrUnsupportedMarshalizer signals a critical error
+var ErrUnsupportedMarshalizer = &CriticalError{InnerErr: fmt.Errorf("marshalizer not supported by vm")}
+
 const (
 	// ErrCodeSuccess signals success
 	ErrCodeSuccess = iota
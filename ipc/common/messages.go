@@ -81,6 +81,9 @@ const (
 	BlockchainRevertToSnapshotResponse
 	BlockchainProcessBuiltInFunctionRequest
 	BlockchainProcessBuiltInFunctionResponse
+	PrefetchRequest
+	DiagnoseMetricsRequest
+	DiagnoseMetricsResponse
 	UndefinedRequestOrResponse
 	LastKind
 )
@@ -159,7 +162,12 @@ func init() {
 	messageKindNameByID[BlockchainRevertToSnapshotRequest] = "BlockchainRevertToSnapshotRequest"
 	messageKindNameByID[BlockchainRevertToSnapshotResponse] = "BlockchainRevertToSnapshotResponse"
 	messageKindNameByID[BlockchainProcessBuiltInFunctionRequest] = "BlockchainProcessBuiltInFunctionRequest"
-	messageKindNameByID[BlockchainProcessBuiltInFunctionResponse] = "BlockchainProcessBuiltInFunctionResponse	"
+	messageKindNameByID[BlockchainProcessBuiltInFunctionResponse] = "BlockchainProcessBuiltInFunctionResponse"
+	messageKindNameByID[PrefetchRequest] = "PrefetchRequest"
+	messageKindNameByID[DiagnoseMetricsRequest] = "DiagnoseMetricsRequest"
+	messageKindNameByID[DiagnoseMetricsResponse] = "DiagnoseMetricsResponse"
+	messageKindNameByID[HandshakeRequest] = "HandshakeRequest"
+	messageKindNameByID[HandshakeResponse] = "HandshakeResponse"
 	messageKindNameByID[UndefinedRequestOrResponse] = "UndefinedRequestOrResponse"
 	messageKindNameByID[LastKind] = "LastKind"
 }
@@ -299,6 +307,16 @@ func IsVersionResponse(message MessageHandler) bool {
 	return message.GetKind() == VersionResponse
 }
 
+// IsHandshakeRequest returns whether a message is a handshake request
+func IsHandshakeRequest(message MessageHandler) bool {
+	return message.GetKind() == HandshakeRequest
+}
+
+// IsHandshakeResponse returns whether a message is a handshake response
+func IsHandshakeResponse(message MessageHandler) bool {
+	return message.GetKind() == HandshakeResponse
+}
+
 // IsContractResponse returns whether a message is a contract response
 func IsContractResponse(message MessageHandler) bool {
 	return message.GetKind() == ContractResponse
@@ -309,8 +327,13 @@ func IsGasScheduleChangeResponse(message MessageHandler) bool {
 	return message.GetKind() == GasScheduleChangeResponse
 }
 
+// IsPrefetchRequest returns whether a message is a prefetch request
+func IsPrefetchRequest(message MessageHandler) bool {
+	return message.GetKind() == PrefetchRequest
+}
+
 // IsDiagnose returns whether a message is a diagnose request
 func IsDiagnose(message MessageHandler) bool {
 	kind := message.GetKind()
-	return kind >= DiagnoseWaitRequest && kind <= DiagnoseWaitResponse
+	return kind == DiagnoseWaitRequest || kind == DiagnoseWaitResponse || kind == DiagnoseMetricsRequest || kind == DiagnoseMetricsResponse
 }
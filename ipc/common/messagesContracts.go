@@ -63,6 +63,8 @@ func NewMessageVersionRequest() *MessageVersionRequest {
 type MessageVersionResponse struct {
 	Message
 	Version string
+	// SupportsHandshake is false when the response comes from a VM that predates the handshake
+	SupportsHandshake bool
 }
 
 // NewMessageVersionResponse creates a MessageVersionResponse
@@ -25,3 +25,29 @@ func NewMessageDiagnoseWaitResponse() *MessageDiagnoseWaitResponse {
 	message.Kind = DiagnoseWaitResponse
 	return message
 }
+
+// MessageDiagnoseMetricsRequest is a request for the IPC metrics of VM (from Node)
+type MessageDiagnoseMetricsRequest struct {
+	Message
+}
+
+// NewMessageDiagnoseMetricsRequest creates a message
+func NewMessageDiagnoseMetricsRequest() *MessageDiagnoseMetricsRequest {
+	message := &MessageDiagnoseMetricsRequest{}
+	message.Kind = DiagnoseMetricsRequest
+	return message
+}
+
+// MessageDiagnoseMetricsResponse holds the IPC metrics of VM (from VM)
+type MessageDiagnoseMetricsResponse struct {
+	Message
+	Metrics *MetricsSnapshot
+}
+
+// NewMessageDiagnoseMetricsResponse creates a message
+func NewMessageDiagnoseMetricsResponse(metrics *MetricsSnapshot) *MessageDiagnoseMetricsResponse {
+	message := &MessageDiagnoseMetricsResponse{}
+	message.Kind = DiagnoseMetricsResponse
+	message.Metrics = metrics
+	return message
+}
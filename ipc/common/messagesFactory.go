@@ -2,6 +2,13 @@ package common
 
 // CreateMessage creates a message given its kind
 func CreateMessage(kind MessageKind) MessageHandler {
+	switch kind {
+	case HandshakeRequest:
+		return &MessageHandshakeRequest{}
+	case HandshakeResponse:
+		return &MessageHandshakeResponse{}
+	}
+
 	kindIndex := uint32(kind)
 	length := uint32(len(messageCreators))
 	if kindIndex < length {
@@ -29,8 +36,13 @@ func init() {
 	messageCreators[ContractResponse] = createMessageContractResponse
 	messageCreators[DiagnoseWaitRequest] = createMessageDiagnoseWaitRequest
 	messageCreators[DiagnoseWaitResponse] = createMessageDiagnoseWaitResponse
+	messageCreators[DiagnoseMetricsRequest] = createMessageDiagnoseMetricsRequest
+	messageCreators[DiagnoseMetricsResponse] = createMessageDiagnoseMetricsResponse
 	messageCreators[VersionRequest] = createMessageVersionRequest
 	messageCreators[VersionResponse] = createMessageVersionResponse
+	messageCreators[PrefetchRequest] = createMessagePrefetchRequest
+	messageCreators[GasScheduleChangeRequest] = createMessageGasScheduleChangeRequest
+	messageCreators[GasScheduleChangeResponse] = createMessageGasScheduleChangeResponse
 
 	messageCreators[BlockchainNewAddressRequest] = createMessageBlockchainNewAddressRequest
 	messageCreators[BlockchainNewAddressResponse] = createMessageBlockchainNewAddressResponse
@@ -121,6 +133,14 @@ func createMessageDiagnoseWaitResponse() MessageHandler {
 	return &MessageDiagnoseWaitResponse{}
 }
 
+func createMessageDiagnoseMetricsRequest() MessageHandler {
+	return &MessageDiagnoseMetricsRequest{}
+}
+
+func createMessageDiagnoseMetricsResponse() MessageHandler {
+	return &MessageDiagnoseMetricsResponse{}
+}
+
 func createMessageVersionRequest() MessageHandler {
 	return &MessageVersionRequest{}
 }
@@ -129,6 +149,18 @@ func createMessageVersionResponse() MessageHandler {
 	return &MessageVersionResponse{}
 }
 
+func createMessagePrefetchRequest() MessageHandler {
+	return &MessagePrefetchRequest{}
+}
+
+func createMessageGasScheduleChangeRequest() MessageHandler {
+	return &MessageGasScheduleChangeRequest{}
+}
+
+func createMessageGasScheduleChangeResponse() MessageHandler {
+	return &Message{}
+}
+
 func createUndefinedMessage() MessageHandler {
 	return NewUndefinedMessage()
 }
@@ -0,0 +1,217 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+)
+
+// ProtocolVersion is the version of the protocol between Node's part and VM's part;
+// it must be increased on any change that is not covered by the negotiation of message kinds
+const ProtocolVersion = uint32(1)
+
+// MinProtocolVersion is the oldest version of the protocol that this build still speaks
+const MinProtocolVersion = uint32(1)
+
+// The handshake kinds are fixed, outside of the positional kinds, so that the handshake is understood
+// even by a part whose positional kinds differ (that's exactly what the handshake detects).
+// A VM that predates the handshake cannot handle them, so Node only sends a handshake request
+// after VM announced its support, in the response to a version request (an old, positional kind).
+const (
+	HandshakeRequest MessageKind = 0x10000 + iota
+	HandshakeResponse
+)
+
+// optionalMessageKinds are the requests that Node's part only sends if VM's part supports them
+var optionalMessageKinds = map[MessageKind]struct{}{
+	PrefetchRequest:         {},
+	DiagnoseWaitRequest:     {},
+	DiagnoseWaitResponse:    {},
+	DiagnoseMetricsRequest:  {},
+	DiagnoseMetricsResponse: {},
+}
+
+// Capabilities describes what a part supports: the protocol versions, the message kinds (by name, as the values might differ between builds)
+// and the marshalizers of the messages
+type Capabilities struct {
+	ProtocolVersion    uint32
+	MinProtocolVersion uint32
+	MessageKinds       map[string]MessageKind
+	Marshalizers       []marshaling.MarshalizerKind
+}
+
+// NewCapabilities creates the capabilities of this build
+func NewCapabilities() *Capabilities {
+	capabilities := &Capabilities{
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
+		MessageKinds:       make(map[string]MessageKind),
+		Marshalizers:       []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob},
+	}
+
+	for kind, name := range messageKindNameByID {
+		if kind == FirstKind || kind == UndefinedRequestOrResponse || kind == LastKind {
+			continue
+		}
+		capabilities.MessageKinds[name] = kind
+	}
+
+	return capabilities
+}
+
+// CheckProtocolVersion returns an error if the two parts cannot speak the same version of the protocol
+func (capabilities *Capabilities) CheckProtocolVersion(other *Capabilities) error {
+	if capabilities.ProtocolVersion >= other.MinProtocolVersion && other.ProtocolVersion >= capabilities.MinProtocolVersion {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d (min %d) vs. %d (min %d)", ErrIncompatibleProtocolVersion,
+		capabilities.ProtocolVersion, capabilities.MinProtocolVersion,
+		other.ProtocolVersion, other.MinProtocolVersion,
+	)
+}
+
+// NegotiatedCapabilities is the outcome of the handshake: the message kinds that both parts support;
+// a nil *NegotiatedCapabilities (no handshake took place) supports all message kinds
+type NegotiatedCapabilities struct {
+	ProtocolVersion uint32
+	VMVersion       string
+	messageKinds    map[MessageKind]struct{}
+}
+
+// Supports returns whether a message kind is supported by both parts
+func (negotiated *NegotiatedCapabilities) Supports(kind MessageKind) bool {
+	if negotiated == nil {
+		return true
+	}
+
+	_, ok := negotiated.messageKinds[kind]
+	return ok
+}
+
+// NewBaselineCapabilities creates the capabilities assumed for a VM that predates the handshake:
+// the message kinds of this build, except the optional ones
+func NewBaselineCapabilities(vmVersion string) *NegotiatedCapabilities {
+	negotiated := &NegotiatedCapabilities{
+		VMVersion:    vmVersion,
+		messageKinds: make(map[MessageKind]struct{}),
+	}
+
+	for _, kind := range NewCapabilities().MessageKinds {
+		if _, isOptional := optionalMessageKinds[kind]; !isOptional {
+			negotiated.messageKinds[kind] = struct{}{}
+		}
+	}
+
+	return negotiated
+}
+
+// NegotiateCapabilities checks, on Node's part, the capabilities of VM's part against the local ones (and the marshalizer in use);
+// it fails if the protocol versions are incompatible, if a message kind has different values in the two parts,
+// if VM lacks a message kind that is not optional, or if VM might send a message kind that Node doesn't know
+func NegotiateCapabilities(node *Capabilities, vm *Capabilities, vmVersion string, marshalizer marshaling.MarshalizerKind) (*NegotiatedCapabilities, error) {
+	err := node.CheckProtocolVersion(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsMarshalizer(vm.Marshalizers, marshalizer) {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedMarshalizer, marshalizer)
+	}
+
+	negotiated := &NegotiatedCapabilities{
+		ProtocolVersion: minProtocolVersion(node.ProtocolVersion, vm.ProtocolVersion),
+		VMVersion:       vmVersion,
+		messageKinds:    make(map[MessageKind]struct{}),
+	}
+
+	var problems []string
+	for name, kind := range node.MessageKinds {
+		vmKind, ok := vm.MessageKinds[name]
+		if !ok {
+			if _, isOptional := optionalMessageKinds[kind]; !isOptional && !isHookCallKind(kind) {
+				problems = append(problems, fmt.Sprintf("%s is missing on VM", name))
+			}
+			continue
+		}
+		if vmKind != kind {
+			problems = append(problems, fmt.Sprintf("%s is %d on Node, %d on VM", name, kind, vmKind))
+			continue
+		}
+
+		negotiated.messageKinds[kind] = struct{}{}
+	}
+
+	for name := range vm.MessageKinds {
+		_, ok := node.MessageKinds[name]
+		if !ok && isHookCallName(name) {
+			problems = append(problems, fmt.Sprintf("%s is missing on Node", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("%w: %s", ErrIncompatibleMessageKinds, strings.Join(problems, "; "))
+	}
+
+	return negotiated, nil
+}
+
+func isHookCallKind(kind MessageKind) bool {
+	return IsHookCall(&Message{Kind: kind})
+}
+
+// isHookCallName classifies the kinds unknown to this build, whose values are meaningless here
+func isHookCallName(name string) bool {
+	return strings.HasPrefix(name, "Blockchain")
+}
+
+func containsMarshalizer(marshalizers []marshaling.MarshalizerKind, marshalizer marshaling.MarshalizerKind) bool {
+	for _, item := range marshalizers {
+		if item == marshalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+func minProtocolVersion(first uint32, second uint32) uint32 {
+	if first < second {
+		return first
+	}
+	return second
+}
+
+// MessageHandshakeRequest is a message sent by Node, before any other request, with its capabilities
+type MessageHandshakeRequest struct {
+	Message
+	Capabilities *Capabilities
+}
+
+// NewMessageHandshakeRequest creates a message
+func NewMessageHandshakeRequest(capabilities *Capabilities) *MessageHandshakeRequest {
+	message := &MessageHandshakeRequest{}
+	message.Kind = HandshakeRequest
+	message.Capabilities = capabilities
+	return message
+}
+
+// MessageHandshakeResponse is a message sent by VM, with its capabilities and version
+type MessageHandshakeResponse struct {
+	Message
+	Capabilities *Capabilities
+	Version      string
+}
+
+// NewMessageHandshakeResponse creates a message
+func NewMessageHandshakeResponse(capabilities *Capabilities, version string, err error) *MessageHandshakeResponse {
+	message := &MessageHandshakeResponse{}
+	message.Kind = HandshakeResponse
+	message.Capabilities = capabilities
+	message.Version = version
+	message.SetError(err)
+	return message
+}
@@ -0,0 +1,112 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCapabilities_SameBuild(t *testing.T) {
+	negotiated, err := NegotiateCapabilities(NewCapabilities(), NewCapabilities(), "v1.3", marshaling.Gob)
+	require.Nil(t, err)
+	require.Equal(t, ProtocolVersion, negotiated.ProtocolVersion)
+	require.Equal(t, "v1.3", negotiated.VMVersion)
+	require.True(t, negotiated.Supports(ContractCallRequest))
+	require.True(t, negotiated.Supports(DiagnoseMetricsRequest))
+	require.False(t, negotiated.Supports(UndefinedRequestOrResponse))
+}
+
+func TestNegotiateCapabilities_IncompatibleProtocolVersion(t *testing.T) {
+	vm := NewCapabilities()
+	vm.ProtocolVersion = ProtocolVersion + 1
+	vm.MinProtocolVersion = ProtocolVersion + 1
+
+	_, err := NegotiateCapabilities(NewCapabilities(), vm, "", marshaling.JSON)
+	require.True(t, errors.Is(err, ErrIncompatibleProtocolVersion))
+	require.True(t, IsCriticalError(err))
+}
+
+func TestNegotiateCapabilities_UnsupportedMarshalizer(t *testing.T) {
+	vm := NewCapabilities()
+	vm.Marshalizers = []marshaling.MarshalizerKind{marshaling.JSON}
+
+	_, err := NegotiateCapabilities(NewCapabilities(), vm, "", marshaling.Gob)
+	require.True(t, errors.Is(err, ErrUnsupportedMarshalizer))
+}
+
+func TestNegotiateCapabilities_ShiftedMessageKinds(t *testing.T) {
+	vm := NewCapabilities()
+	vm.MessageKinds["VersionRequest"] = VersionRequest + 2
+
+	_, err := NegotiateCapabilities(NewCapabilities(), vm, "", marshaling.JSON)
+	require.True(t, errors.Is(err, ErrIncompatibleMessageKinds))
+	require.Contains(t, err.Error(), "VersionRequest is")
+}
+
+func TestNegotiateCapabilities_MissingMessageKinds(t *testing.T) {
+	vm := NewCapabilities()
+	delete(vm.MessageKinds, "ContractCallRequest")
+	_, err := NegotiateCapabilities(NewCapabilities(), vm, "", marshaling.JSON)
+	require.True(t, errors.Is(err, ErrIncompatibleMessageKinds))
+	require.Contains(t, err.Error(), "ContractCallRequest is missing on VM")
+
+	vm = NewCapabilities()
+	vm.MessageKinds["BlockchainFutureRequest"] = BlockchainGetStorageDataRequest + 1000
+	_, err = NegotiateCapabilities(NewCapabilities(), vm, "", marshaling.JSON)
+	require.True(t, errors.Is(err, ErrIncompatibleMessageKinds))
+}
+
+func TestNegotiateCapabilities_OptionalMessageKindsDegrade(t *testing.T) {
+	vm := NewCapabilities()
+	delete(vm.MessageKinds, "PrefetchRequest")
+	delete(vm.MessageKinds, "DiagnoseMetricsRequest")
+	delete(vm.MessageKinds, "DiagnoseMetricsResponse")
+
+	negotiated, err := NegotiateCapabilities(NewCapabilities(), vm, "", marshaling.JSON)
+	require.Nil(t, err)
+	require.False(t, negotiated.Supports(PrefetchRequest))
+	require.False(t, negotiated.Supports(DiagnoseMetricsRequest))
+	require.True(t, negotiated.Supports(ContractCallRequest))
+}
+
+func TestNewBaselineCapabilities(t *testing.T) {
+	baseline := NewBaselineCapabilities("v1.2")
+	require.Equal(t, "v1.2", baseline.VMVersion)
+	require.True(t, baseline.Supports(ContractCallRequest))
+	require.True(t, baseline.Supports(BlockchainGetStorageDataRequest))
+	require.True(t, baseline.Supports(VersionRequest))
+	require.False(t, baseline.Supports(PrefetchRequest))
+	require.False(t, baseline.Supports(DiagnoseMetricsRequest))
+	require.False(t, baseline.Supports(UndefinedRequestOrResponse))
+}
+
+func TestMessageVersionResponse_WithoutSupportsHandshake(t *testing.T) {
+	// as sent by a VM that predates the handshake
+	oldResponse := &struct {
+		Message
+		Version string
+	}{Version: "v1.2"}
+	oldResponse.Kind = VersionResponse
+
+	for _, kind := range []marshaling.MarshalizerKind{marshaling.JSON, marshaling.Gob} {
+		marshalizer := marshaling.CreateMarshalizer(kind)
+		data, err := marshalizer.Marshal(oldResponse)
+		require.Nil(t, err)
+
+		response := &MessageVersionResponse{}
+		err = marshalizer.Unmarshal(response, data)
+		require.Nil(t, err)
+		require.Equal(t, "v1.2", response.Version)
+		require.False(t, response.SupportsHandshake)
+	}
+}
+
+func TestMessageHandshake_FixedKindsAreCreated(t *testing.T) {
+	require.IsType(t, &MessageHandshakeRequest{}, CreateMessage(HandshakeRequest))
+	require.IsType(t, &MessageHandshakeResponse{}, CreateMessage(HandshakeResponse))
+
+	message := NewMessageHandshakeResponse(NewCapabilities(), "v1.3", nil)
+	requireSerializationConsistency(t, message, &MessageHandshakeResponse{})
+}
@@ -0,0 +1,32 @@
+package common
+
+// PrefetchedCode is the code of a contract, pushed by the Node ahead of a contract request
+type PrefetchedCode struct {
+	Address []byte
+	Code    []byte
+}
+
+// PrefetchedStorageEntry is a storage entry, pushed by the Node ahead of a contract request
+type PrefetchedStorageEntry struct {
+	Address []byte
+	Key     []byte
+	Value   []byte
+}
+
+// MessagePrefetchRequest is a prefetch message (from Node).
+// It is optional, it precedes a contract request (within the same dialogue) and it gets no response:
+// it only fills the hook cache of VM, sparing the corresponding hook calls.
+type MessagePrefetchRequest struct {
+	Message
+	Codes   []*PrefetchedCode
+	Storage []*PrefetchedStorageEntry
+}
+
+// NewMessagePrefetchRequest creates a message
+func NewMessagePrefetchRequest(codes []*PrefetchedCode, storage []*PrefetchedStorageEntry) *MessagePrefetchRequest {
+	message := &MessagePrefetchRequest{}
+	message.Kind = PrefetchRequest
+	message.Codes = codes
+	message.Storage = storage
+	return message
+}
@@ -83,3 +83,41 @@ func requireSerializationConsistency(t *testing.T, message interface{}, intoMess
 		require.FailNow(t, "Serialization is not consistent.")
 	}
 }
+
+func TestMessageKinds_KeepTheirValues(t *testing.T) {
+	// parts built before a kind was added still need to understand the kinds they know,
+	// so new kinds are only appended, never inserted
+	require.Equal(t, MessageKind(60), VersionRequest)
+	require.Equal(t, MessageKind(71), BlockchainProcessBuiltInFunctionResponse)
+	require.Equal(t, MessageKind(72), PrefetchRequest)
+	require.Equal(t, MessageKind(73), DiagnoseMetricsRequest)
+	require.Equal(t, MessageKind(74), DiagnoseMetricsResponse)
+	require.Equal(t, MessageKind(75), UndefinedRequestOrResponse)
+}
+
+func TestCreateMessage_KnownKinds(t *testing.T) {
+	// the "Builtin" kinds are not used, the "BuiltIn" ones are
+	kindsWithoutMessages := map[MessageKind]bool{
+		BlockchainProcessBuiltinFunctionRequest:  true,
+		BlockchainProcessBuiltinFunctionResponse: true,
+		UndefinedRequestOrResponse:               true,
+	}
+
+	for kind := FirstKind + 1; kind < LastKind; kind++ {
+		if kindsWithoutMessages[kind] {
+			continue
+		}
+
+		message := CreateMessage(kind)
+		_, isUndefined := message.(*UndefinedMessage)
+		require.False(t, isUndefined, "no creator for %s", messageKindNameByID[kind])
+		require.Equal(t, kind, message.GetKind())
+	}
+
+	_, isGasScheduleChangeRequest := CreateMessage(GasScheduleChangeRequest).(*MessageGasScheduleChangeRequest)
+	require.True(t, isGasScheduleChangeRequest)
+	_, isHandshakeRequest := CreateMessage(HandshakeRequest).(*MessageHandshakeRequest)
+	require.True(t, isHandshakeRequest)
+	_, isUndefined := CreateMessage(LastKind + 1).(*UndefinedMessage)
+	require.True(t, isUndefined)
+}
@@ -2,7 +2,6 @@ package common
 
 import (
 	"fmt"
-	"os"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
@@ -16,24 +15,35 @@ type Messenger struct {
 	Nonce    uint32
 	receiver *Receiver
 	sender   *Sender
+	metrics  *Metrics
 }
 
-// NewMessengerPipes creates a new messenger from pipes
-func NewMessengerPipes(name string, reader *os.File, writer *os.File, marshalizer marshaling.Marshalizer) *Messenger {
-	return &Messenger{
-		Name:     name,
-		receiver: NewReceiver(reader, marshalizer),
-		sender:   NewSender(writer, marshalizer),
-	}
+// NewMessengerPipes creates a new messenger from pipes, or from the streams of a connection
+func NewMessengerPipes(name string, reader ReceiverStream, writer SenderStream, marshalizer marshaling.Marshalizer) *Messenger {
+	return NewMessenger(name, NewReceiver(reader, marshalizer), NewSender(writer, marshalizer))
 }
 
 // NewMessenger creates a new messenger
 func NewMessenger(name string, receiver *Receiver, sender *Sender) *Messenger {
-	return &Messenger{
+	messenger := &Messenger{
 		Name:     name,
 		receiver: receiver,
 		sender:   sender,
 	}
+	messenger.SetMetrics(NewMetrics())
+	return messenger
+}
+
+// SetMetrics sets the collector of the metrics of the messenger (e.g. to keep collecting into the same one after a restart)
+func (messenger *Messenger) SetMetrics(metrics *Metrics) {
+	messenger.metrics = metrics
+	messenger.receiver.metrics = metrics
+	messenger.sender.metrics = metrics
+}
+
+// GetMetrics gets the collector of the metrics of the messenger
+func (messenger *Messenger) GetMetrics() *Metrics {
+	return messenger.metrics
 }
 
 // Send sends a message over the pipe
@@ -0,0 +1,196 @@
+package common
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBucketBounds are the upper bounds of the buckets of a LatencyHistogram; the last bucket has no upper bound
+var LatencyBucketBounds = []time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// LatencyHistogram counts durations in the buckets given by LatencyBucketBounds
+type LatencyHistogram struct {
+	Counts []uint64
+	Total  time.Duration
+	Max    time.Duration
+}
+
+func (histogram *LatencyHistogram) add(duration time.Duration) {
+	if histogram.Counts == nil {
+		histogram.Counts = make([]uint64, len(LatencyBucketBounds)+1)
+	}
+
+	bucket := sort.Search(len(LatencyBucketBounds), func(i int) bool {
+		return duration <= LatencyBucketBounds[i]
+	})
+	histogram.Counts[bucket]++
+	histogram.Total += duration
+	if duration > histogram.Max {
+		histogram.Max = duration
+	}
+}
+
+// Count returns the number of recorded durations
+func (histogram *LatencyHistogram) Count() uint64 {
+	count := uint64(0)
+	for _, bucketCount := range histogram.Counts {
+		count += bucketCount
+	}
+
+	return count
+}
+
+// Average returns the average of the recorded durations
+func (histogram *LatencyHistogram) Average() time.Duration {
+	count := histogram.Count()
+	if count == 0 {
+		return 0
+	}
+
+	return histogram.Total / time.Duration(count)
+}
+
+// MessageKindMetrics holds the metrics of the messages of a kind, as seen by one of the parts:
+// marshaling and writing (for sent messages), reading and unmarshaling (for received messages, excluding the wait for them),
+// replying (the work of a replier, e.g. the blockchain hook on Node's part) and round trips (from sending a request until its response arrives)
+type MessageKindMetrics struct {
+	Kind          MessageKind
+	KindName      string
+	NumSent       uint64
+	NumReceived   uint64
+	BytesSent     uint64
+	BytesReceived uint64
+	Marshal       LatencyHistogram
+	Write         LatencyHistogram
+	Read          LatencyHistogram
+	Unmarshal     LatencyHistogram
+	Reply         LatencyHistogram
+	RoundTrip     LatencyHistogram
+}
+
+// MetricsSnapshot is a copy of the metrics of a part, ordered by message kind
+type MetricsSnapshot struct {
+	Kinds []*MessageKindMetrics
+}
+
+// GetKindMetrics returns the metrics of a message kind, or nil if no message of that kind was recorded
+func (snapshot *MetricsSnapshot) GetKindMetrics(kind MessageKind) *MessageKindMetrics {
+	for _, kindMetrics := range snapshot.Kinds {
+		if kindMetrics.Kind == kind {
+			return kindMetrics
+		}
+	}
+
+	return nil
+}
+
+// Metrics collects the metrics of a part, per message kind; a nil *Metrics ignores all records
+type Metrics struct {
+	mutex  sync.Mutex
+	byKind map[MessageKind]*MessageKindMetrics
+}
+
+// NewMetrics creates an empty metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{
+		byKind: make(map[MessageKind]*MessageKindMetrics),
+	}
+}
+
+func (metrics *Metrics) record(kind MessageKind, recordFunc func(kindMetrics *MessageKindMetrics)) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	kindMetrics, ok := metrics.byKind[kind]
+	if !ok {
+		kindMetrics = &MessageKindMetrics{
+			Kind:     kind,
+			KindName: messageKindNameByID[kind],
+		}
+		metrics.byKind[kind] = kindMetrics
+	}
+
+	recordFunc(kindMetrics)
+}
+
+// RecordSent records a sent message, with the durations of marshaling and writing it
+func (metrics *Metrics) RecordSent(kind MessageKind, length int, marshalDuration time.Duration, writeDuration time.Duration) {
+	metrics.record(kind, func(kindMetrics *MessageKindMetrics) {
+		kindMetrics.NumSent++
+		kindMetrics.BytesSent += uint64(length)
+		kindMetrics.Marshal.add(marshalDuration)
+		kindMetrics.Write.add(writeDuration)
+	})
+}
+
+// RecordReceived records a received message, with the durations of reading and unmarshaling it
+func (metrics *Metrics) RecordReceived(kind MessageKind, length int, readDuration time.Duration, unmarshalDuration time.Duration) {
+	metrics.record(kind, func(kindMetrics *MessageKindMetrics) {
+		kindMetrics.NumReceived++
+		kindMetrics.BytesReceived += uint64(length)
+		kindMetrics.Read.add(readDuration)
+		kindMetrics.Unmarshal.add(unmarshalDuration)
+	})
+}
+
+// RecordReply records the time taken to reply to a request
+func (metrics *Metrics) RecordReply(kind MessageKind, duration time.Duration) {
+	metrics.record(kind, func(kindMetrics *MessageKindMetrics) {
+		kindMetrics.Reply.add(duration)
+	})
+}
+
+// RecordRoundTrip records the time from sending a request until receiving its response
+func (metrics *Metrics) RecordRoundTrip(kind MessageKind, duration time.Duration) {
+	metrics.record(kind, func(kindMetrics *MessageKindMetrics) {
+		kindMetrics.RoundTrip.add(duration)
+	})
+}
+
+// GetSnapshot returns a copy of the metrics
+func (metrics *Metrics) GetSnapshot() *MetricsSnapshot {
+	snapshot := &MetricsSnapshot{
+		Kinds: make([]*MessageKindMetrics, 0),
+	}
+	if metrics == nil {
+		return snapshot
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	for _, kindMetrics := range metrics.byKind {
+		kindMetricsCopy := *kindMetrics
+		kindMetricsCopy.Marshal = kindMetrics.Marshal.copy()
+		kindMetricsCopy.Write = kindMetrics.Write.copy()
+		kindMetricsCopy.Read = kindMetrics.Read.copy()
+		kindMetricsCopy.Unmarshal = kindMetrics.Unmarshal.copy()
+		kindMetricsCopy.Reply = kindMetrics.Reply.copy()
+		kindMetricsCopy.RoundTrip = kindMetrics.RoundTrip.copy()
+		snapshot.Kinds = append(snapshot.Kinds, &kindMetricsCopy)
+	}
+
+	sort.Slice(snapshot.Kinds, func(i, j int) bool {
+		return snapshot.Kinds[i].Kind < snapshot.Kinds[j].Kind
+	})
+
+	return snapshot
+}
+
+func (histogram *LatencyHistogram) copy() LatencyHistogram {
+	histogramCopy := *histogram
+	histogramCopy.Counts = append([]uint64(nil), histogram.Counts...)
+	return histogramCopy
+}
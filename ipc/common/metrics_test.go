@@ -0,0 +1,74 @@
+package common
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyHistogram_Buckets(t *testing.T) {
+	histogram := LatencyHistogram{}
+	histogram.add(5 * time.Microsecond)
+	histogram.add(10 * time.Microsecond)
+	histogram.add(50 * time.Millisecond)
+	histogram.add(2 * time.Second)
+
+	require.Equal(t, []uint64{2, 0, 0, 0, 1, 0, 1}, histogram.Counts)
+	require.Equal(t, uint64(4), histogram.Count())
+	require.Equal(t, 2*time.Second, histogram.Max)
+	require.Equal(t, (2*time.Second+50*time.Millisecond+15*time.Microsecond)/4, histogram.Average())
+}
+
+func TestMetrics_NilIgnoresRecords(t *testing.T) {
+	var metrics *Metrics
+	metrics.RecordReply(VersionRequest, time.Millisecond)
+	require.Empty(t, metrics.GetSnapshot().Kinds)
+}
+
+func TestMetrics_SnapshotIsACopy(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.RecordReply(VersionRequest, time.Millisecond)
+	snapshot := metrics.GetSnapshot()
+	metrics.RecordReply(VersionRequest, time.Millisecond)
+
+	require.Equal(t, uint64(1), snapshot.GetKindMetrics(VersionRequest).Reply.Count())
+	require.Equal(t, uint64(2), metrics.GetSnapshot().GetKindMetrics(VersionRequest).Reply.Count())
+	require.Nil(t, snapshot.GetKindMetrics(VersionResponse))
+}
+
+func TestMessenger_RecordsMetricsPerKind(t *testing.T) {
+	readerOfSecond, writerOfFirst, err := os.Pipe()
+	require.Nil(t, err)
+	readerOfFirst, writerOfSecond, err := os.Pipe()
+	require.Nil(t, err)
+
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	first := NewMessengerPipes("first", readerOfFirst, writerOfFirst, marshalizer)
+	second := NewMessengerPipes("second", readerOfSecond, writerOfSecond, marshalizer)
+
+	require.Nil(t, first.Send(NewMessageVersionRequest()))
+	_, err = second.Receive(0)
+	require.Nil(t, err)
+	require.Nil(t, second.Send(NewMessageVersionResponse("foo")))
+	_, err = first.Receive(0)
+	require.Nil(t, err)
+
+	firstMetrics := first.GetMetrics().GetSnapshot()
+	require.Len(t, firstMetrics.Kinds, 2)
+	request := firstMetrics.GetKindMetrics(VersionRequest)
+	require.Equal(t, "VersionRequest", request.KindName)
+	require.Equal(t, uint64(1), request.NumSent)
+	require.Equal(t, uint64(0), request.NumReceived)
+	require.Equal(t, uint64(1), request.Marshal.Count())
+	require.Equal(t, uint64(1), request.Write.Count())
+	response := firstMetrics.GetKindMetrics(VersionResponse)
+	require.Equal(t, uint64(1), response.NumReceived)
+	require.Equal(t, uint64(1), response.Unmarshal.Count())
+
+	secondMetrics := second.GetMetrics().GetSnapshot()
+	require.Equal(t, request.BytesSent, secondMetrics.GetKindMetrics(VersionRequest).BytesReceived)
+	require.Equal(t, response.BytesReceived, secondMetrics.GetKindMetrics(VersionResponse).BytesSent)
+}
@@ -3,20 +3,20 @@ package common
 import (
 	"encoding/binary"
 	"io"
-	"os"
 	"time"
 
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
 )
 
-// Receiver intermediates communication (message receiving) via pipes
+// Receiver intermediates communication (message receiving) via pipes or connections
 type Receiver struct {
-	reader      *os.File
+	reader      ReceiverStream
 	marshalizer marshaling.Marshalizer
+	metrics     *Metrics
 }
 
 // NewReceiver creates a new receiver
-func NewReceiver(reader *os.File, marshalizer marshaling.Marshalizer) *Receiver {
+func NewReceiver(reader ReceiverStream, marshalizer marshaling.Marshalizer) *Receiver {
 	return &Receiver{
 		reader:      reader,
 		marshalizer: marshalizer,
@@ -47,14 +47,15 @@ func (receiver *Receiver) Receive(timeout int) (MessageHandler, int, error) {
 	return message, length, nil
 }
 
+// setReceiveDeadline only concerns reading, which makes it behave the same for pipes and connections
 func (receiver *Receiver) setReceiveDeadline(timeout int) error {
 	duration := time.Duration(timeout) * time.Millisecond
 	future := time.Now().Add(duration)
-	return receiver.reader.SetDeadline(future)
+	return receiver.reader.SetReadDeadline(future)
 }
 
 func (receiver *Receiver) resetReceiveDeadlineQuietly() {
-	_ = receiver.reader.SetDeadline(time.Time{})
+	_ = receiver.reader.SetReadDeadline(time.Time{})
 }
 
 func (receiver *Receiver) receiveMessageLengthAndKind() (int, MessageKind, error) {
@@ -69,19 +70,23 @@ func (receiver *Receiver) receiveMessageLengthAndKind() (int, MessageKind, error
 	return int(length), kind, nil
 }
 
+// readMessage is timed from the arrival of the header: the wait for a message is not part of the read duration
 func (receiver *Receiver) readMessage(kind MessageKind, length int) (MessageHandler, error) {
+	start := time.Now()
 	buffer := make([]byte, length)
 	_, err := io.ReadFull(receiver.reader, buffer)
 	if err != nil {
 		return nil, err
 	}
 
+	read := time.Now()
 	message := CreateMessage(kind)
 	err = receiver.marshalizer.Unmarshal(message, buffer)
 	if err != nil {
 		return nil, err
 	}
 
+	receiver.metrics.RecordReceived(kind, length, read.Sub(start), time.Since(read))
 	return message, nil
 }
 
@@ -2,19 +2,20 @@ package common
 
 import (
 	"encoding/binary"
-	"os"
+	"time"
 
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
 )
 
-// Sender intermediates communication (message sending) via pipes
+// Sender intermediates communication (message sending) via pipes or connections
 type Sender struct {
-	writer      *os.File
+	writer      SenderStream
 	marshalizer marshaling.Marshalizer
+	metrics     *Metrics
 }
 
 // NewSender creates a new sender
-func NewSender(writer *os.File, marshalizer marshaling.Marshalizer) *Sender {
+func NewSender(writer SenderStream, marshalizer marshaling.Marshalizer) *Sender {
 	return &Sender{
 		writer:      writer,
 		marshalizer: marshalizer,
@@ -23,11 +24,13 @@ func NewSender(writer *os.File, marshalizer marshaling.Marshalizer) *Sender {
 
 // Send sends a message over the pipe
 func (sender *Sender) Send(message MessageHandler) (int, error) {
+	start := time.Now()
 	dataBytes, err := sender.marshalizer.Marshal(message)
 	if err != nil {
 		return 0, err
 	}
 
+	marshaled := time.Now()
 	length := len(dataBytes)
 	err = sender.sendMessageLengthAndKind(length, message.GetKind())
 	if err != nil {
@@ -39,6 +42,7 @@ func (sender *Sender) Send(message MessageHandler) (int, error) {
 		return 0, err
 	}
 
+	sender.metrics.RecordSent(message.GetKind(), length, marshaled.Sub(start), time.Since(marshaled))
 	return length, err
 }
 
@@ -0,0 +1,176 @@
+package common
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ReceiverStream is the stream a Receiver reads messages from: a pipe or one side of a connection
+type ReceiverStream interface {
+	io.ReadCloser
+	SetReadDeadline(t time.Time) error
+}
+
+// SenderStream is the stream a Sender writes messages to: a pipe or one side of a connection
+type SenderStream interface {
+	io.WriteCloser
+}
+
+// ConnectionHealth is implemented by the streams of a connection, which tell whether the connection is still usable
+type ConnectionHealth interface {
+	IsBroken() bool
+}
+
+// TransportKind is the kind of channel between the Node's part and the VM's part
+type TransportKind string
+
+const (
+	// TransportPipe uses anonymous pipes, inherited by the VM process started by the driver
+	TransportPipe TransportKind = "pipe"
+	// TransportUnix uses a Unix domain socket, the address is the path of the socket
+	TransportUnix TransportKind = "unix"
+	// TransportTCP uses a TCP connection, the address is "host:port"
+	TransportTCP TransportKind = "tcp"
+)
+
+// IsNetworked returns whether the transport connects to a VM through an address,
+// instead of pipes to a child process. The empty kind means pipes.
+func (kind TransportKind) IsNetworked() bool {
+	return kind == TransportUnix || kind == TransportTCP
+}
+
+// Listen starts listening for a Node's part on a networked transport
+func Listen(kind TransportKind, address string) (net.Listener, error) {
+	if !kind.IsNetworked() {
+		return nil, ErrTransportNotNetworked
+	}
+
+	return net.Listen(string(kind), address)
+}
+
+// Dial connects to a VM's part listening on a networked transport, and returns the two directions of the connection as streams
+func Dial(kind TransportKind, address string, timeout time.Duration) (ReceiverStream, SenderStream, error) {
+	if !kind.IsNetworked() {
+		return nil, nil, ErrTransportNotNetworked
+	}
+
+	conn, err := net.DialTimeout(string(kind), address, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, writer := NewConnectionStreams(conn)
+	return reader, writer, nil
+}
+
+// NewConnectionStreams splits a connection into a receiving and a sending stream.
+// Closing one of the streams only shuts down its direction (if the connection supports it),
+// so that, just like with pipes, closing the sending stream signals EOF to the other party.
+// The connection itself is closed when both streams are closed.
+func NewConnectionStreams(conn net.Conn) (ReceiverStream, SenderStream) {
+	shared := &sharedConnection{
+		conn:          conn,
+		numOpenHalves: 2,
+	}
+
+	return &connectionReader{sharedConnection: shared}, &connectionWriter{sharedConnection: shared}
+}
+
+type sharedConnection struct {
+	conn          net.Conn
+	mutex         sync.Mutex
+	numOpenHalves int
+	broken        bool
+}
+
+func (shared *sharedConnection) markBrokenOnError(err error) {
+	if err == nil {
+		return
+	}
+
+	shared.mutex.Lock()
+	shared.broken = true
+	shared.mutex.Unlock()
+}
+
+func (shared *sharedConnection) isBroken() bool {
+	shared.mutex.Lock()
+	defer shared.mutex.Unlock()
+
+	return shared.broken
+}
+
+func (shared *sharedConnection) closeHalf(shutdownHalf func(conn halfCloser) error) error {
+	shared.mutex.Lock()
+	defer shared.mutex.Unlock()
+
+	if shared.numOpenHalves == 0 {
+		return nil
+	}
+
+	shared.numOpenHalves--
+	if shared.numOpenHalves == 0 {
+		return shared.conn.Close()
+	}
+
+	conn, ok := shared.conn.(halfCloser)
+	if !ok {
+		return nil
+	}
+
+	return shutdownHalf(conn)
+}
+
+type halfCloser interface {
+	CloseRead() error
+	CloseWrite() error
+}
+
+type connectionReader struct {
+	*sharedConnection
+}
+
+// Read reads from the connection
+func (reader *connectionReader) Read(buffer []byte) (int, error) {
+	numBytes, err := reader.conn.Read(buffer)
+	reader.markBrokenOnError(err)
+	return numBytes, err
+}
+
+// IsBroken returns whether reading from or writing to the connection failed.
+// A connection closed by the other party is only detected by the next read, which fails.
+func (reader *connectionReader) IsBroken() bool {
+	return reader.isBroken()
+}
+
+// SetReadDeadline sets the read deadline of the connection
+func (reader *connectionReader) SetReadDeadline(t time.Time) error {
+	return reader.conn.SetReadDeadline(t)
+}
+
+// Close shuts down the reading direction of the connection
+func (reader *connectionReader) Close() error {
+	return reader.closeHalf(func(conn halfCloser) error {
+		return conn.CloseRead()
+	})
+}
+
+type connectionWriter struct {
+	*sharedConnection
+}
+
+// Write writes to the connection
+func (writer *connectionWriter) Write(buffer []byte) (int, error) {
+	numBytes, err := writer.conn.Write(buffer)
+	writer.markBrokenOnError(err)
+	return numBytes, err
+}
+
+// Close shuts down the writing direction of the connection
+func (writer *connectionWriter) Close() error {
+	return writer.closeHalf(func(conn halfCloser) error {
+		return conn.CloseWrite()
+	})
+}
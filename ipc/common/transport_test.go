@@ -0,0 +1,153 @@
+package common
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+type streamPair struct {
+	reader ReceiverStream
+	writer SenderStream
+}
+
+func createPipeStreams(t *testing.T) (streamPair, streamPair) {
+	readerA, writerB, err := os.Pipe()
+	require.Nil(t, err)
+	readerB, writerA, err := os.Pipe()
+	require.Nil(t, err)
+
+	return streamPair{reader: readerA, writer: writerA}, streamPair{reader: readerB, writer: writerB}
+}
+
+func createNetworkStreams(t *testing.T, kind TransportKind, address string) (streamPair, streamPair) {
+	listener, err := Listen(kind, address)
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	accepted := make(chan net.Conn)
+	go func() {
+		conn, _ := listener.Accept()
+		accepted <- conn
+	}()
+
+	reader, writer, err := Dial(kind, listener.Addr().String(), time.Second)
+	require.Nil(t, err)
+	conn := <-accepted
+	require.NotNil(t, conn)
+	otherReader, otherWriter := NewConnectionStreams(conn)
+
+	return streamPair{reader: reader, writer: writer}, streamPair{reader: otherReader, writer: otherWriter}
+}
+
+func forEachTransport(t *testing.T, test func(t *testing.T, first streamPair, second streamPair)) {
+	t.Run("pipe", func(t *testing.T) {
+		first, second := createPipeStreams(t)
+		test(t, first, second)
+	})
+	t.Run("unix", func(t *testing.T) {
+		first, second := createNetworkStreams(t, TransportUnix, filepath.Join(t.TempDir(), "vm.sock"))
+		test(t, first, second)
+	})
+	t.Run("tcp", func(t *testing.T) {
+		first, second := createNetworkStreams(t, TransportTCP, "127.0.0.1:0")
+		test(t, first, second)
+	})
+}
+
+func TestTransport_SendAndReceive(t *testing.T) {
+	forEachTransport(t, func(t *testing.T, first streamPair, second streamPair) {
+		marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+		sender := NewSender(first.writer, marshalizer)
+		receiver := NewReceiver(second.reader, marshalizer)
+
+		_, err := sender.Send(NewMessageVersionResponse("foo"))
+		require.Nil(t, err)
+		_, err = sender.Send(NewMessageDiagnoseWaitRequest(42))
+		require.Nil(t, err)
+
+		message, _, err := receiver.Receive(1000)
+		require.Nil(t, err)
+		require.Equal(t, "foo", message.(*MessageVersionResponse).Version)
+		message, _, err = receiver.Receive(0)
+		require.Nil(t, err)
+		require.Equal(t, uint32(42), message.(*MessageDiagnoseWaitRequest).Milliseconds)
+	})
+}
+
+func TestTransport_ReceiveTimeout(t *testing.T) {
+	forEachTransport(t, func(t *testing.T, first streamPair, second streamPair) {
+		marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+		receiver := NewReceiver(second.reader, marshalizer)
+
+		_, _, err := receiver.Receive(50)
+		require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+		// the deadline is reset after receiving
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, _ = NewSender(first.writer, marshalizer).Send(NewMessageVersionResponse("late"))
+		}()
+		message, _, err := receiver.Receive(0)
+		require.Nil(t, err)
+		require.Equal(t, "late", message.(*MessageVersionResponse).Version)
+	})
+}
+
+func TestTransport_ShutdownSenderSignalsEOF(t *testing.T) {
+	forEachTransport(t, func(t *testing.T, first streamPair, second streamPair) {
+		marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+		sender := NewSender(first.writer, marshalizer)
+		receiver := NewReceiver(second.reader, marshalizer)
+
+		require.Nil(t, sender.Shutdown())
+		_, _, err := receiver.Receive(1000)
+		require.Equal(t, io.EOF, err)
+	})
+}
+
+func TestTransport_NotNetworked(t *testing.T) {
+	_, err := Listen(TransportPipe, "")
+	require.Equal(t, ErrTransportNotNetworked, err)
+	_, _, err = Dial("", "", time.Second)
+	require.Equal(t, ErrTransportNotNetworked, err)
+}
+
+func TestTransport_ConnectionHealth(t *testing.T) {
+	kinds := map[TransportKind]string{
+		TransportUnix: filepath.Join(t.TempDir(), "vm.sock"),
+		TransportTCP:  "127.0.0.1:0",
+	}
+	for kind, address := range kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			first, second := createNetworkStreams(t, kind, address)
+			marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+			connection := second.reader.(ConnectionHealth)
+			require.False(t, connection.IsBroken())
+
+			receiver := NewReceiver(second.reader, marshalizer)
+			_, err := NewSender(first.writer, marshalizer).Send(NewMessageVersionResponse("foo"))
+			require.Nil(t, err)
+			message, _, err := receiver.Receive(1000)
+			require.Nil(t, err)
+			require.Equal(t, "foo", message.(*MessageVersionResponse).Version)
+			require.False(t, connection.IsBroken())
+
+			// the other party closes the connection, which is noticed by the next read
+			require.Nil(t, first.writer.Close())
+			require.Nil(t, first.reader.Close())
+			require.False(t, connection.IsBroken())
+			_, _, err = receiver.Receive(1000)
+			require.NotNil(t, err)
+			require.True(t, connection.IsBroken())
+		})
+	}
+}
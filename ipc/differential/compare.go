@@ -0,0 +1,194 @@
+package differential
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// CompareVMOutputs compares two outputs field by field, and describes each difference as "field: expected -> actual".
+// Nil and empty values (slices, maps, big ints) are considered equal, since marshaling does not preserve the difference.
+func CompareVMOutputs(expected *vmcommon.VMOutput, actual *vmcommon.VMOutput) []string {
+	if expected == nil || actual == nil {
+		if expected == nil && actual == nil {
+			return nil
+		}
+		return []string{fmt.Sprintf("output: %s -> %s", describeNilOutput(expected), describeNilOutput(actual))}
+	}
+
+	comparer := &outputComparer{}
+	comparer.compareByteSlices("returnData", expected.ReturnData, actual.ReturnData)
+	comparer.compareValues("returnCode", expected.ReturnCode, actual.ReturnCode)
+	comparer.compareValues("returnMessage", expected.ReturnMessage, actual.ReturnMessage)
+	comparer.compareValues("gasRemaining", expected.GasRemaining, actual.GasRemaining)
+	comparer.compareBigInts("gasRefund", expected.GasRefund, actual.GasRefund)
+	comparer.compareOutputAccounts(expected.OutputAccounts, actual.OutputAccounts)
+	comparer.compareByteSlices("deletedAccounts", expected.DeletedAccounts, actual.DeletedAccounts)
+	comparer.compareByteSlices("touchedAccounts", expected.TouchedAccounts, actual.TouchedAccounts)
+	comparer.compareLogs(expected.Logs, actual.Logs)
+	return comparer.differences
+}
+
+func describeNilOutput(output *vmcommon.VMOutput) string {
+	if output == nil {
+		return "none"
+	}
+	return "present"
+}
+
+type outputComparer struct {
+	differences []string
+}
+
+func (comparer *outputComparer) addDifference(field string, expected interface{}, actual interface{}) {
+	comparer.differences = append(comparer.differences, fmt.Sprintf("%s: %v -> %v", field, expected, actual))
+}
+
+func (comparer *outputComparer) compareValues(field string, expected interface{}, actual interface{}) {
+	if expected != actual {
+		comparer.addDifference(field, expected, actual)
+	}
+}
+
+func (comparer *outputComparer) compareBytes(field string, expected []byte, actual []byte) {
+	if !bytes.Equal(expected, actual) {
+		comparer.addDifference(field, formatBytes(expected), formatBytes(actual))
+	}
+}
+
+func (comparer *outputComparer) compareByteSlices(field string, expected [][]byte, actual [][]byte) {
+	if len(expected) != len(actual) {
+		comparer.addDifference(field, formatByteSlices(expected), formatByteSlices(actual))
+		return
+	}
+
+	for i := range expected {
+		comparer.compareBytes(fmt.Sprintf("%s[%d]", field, i), expected[i], actual[i])
+	}
+}
+
+func (comparer *outputComparer) compareBigInts(field string, expected *big.Int, actual *big.Int) {
+	if bigIntOrZero(expected).Cmp(bigIntOrZero(actual)) != 0 {
+		comparer.addDifference(field, bigIntOrZero(expected), bigIntOrZero(actual))
+	}
+}
+
+func (comparer *outputComparer) compareOutputAccounts(expected map[string]*vmcommon.OutputAccount, actual map[string]*vmcommon.OutputAccount) {
+	for _, address := range sortedKeys(expected, actual) {
+		field := fmt.Sprintf("outputAccounts[%s]", formatBytes([]byte(address)))
+		expectedAccount, expectedFound := expected[address]
+		actualAccount, actualFound := actual[address]
+		if !expectedFound || !actualFound {
+			comparer.addDifference(field, describePresence(expectedFound), describePresence(actualFound))
+			continue
+		}
+
+		comparer.compareBytes(field+".address", expectedAccount.Address, actualAccount.Address)
+		comparer.compareValues(field+".nonce", expectedAccount.Nonce, actualAccount.Nonce)
+		comparer.compareBigInts(field+".balance", expectedAccount.Balance, actualAccount.Balance)
+		comparer.compareBigInts(field+".balanceDelta", expectedAccount.BalanceDelta, actualAccount.BalanceDelta)
+		comparer.compareStorageUpdates(field+".storageUpdates", expectedAccount.StorageUpdates, actualAccount.StorageUpdates)
+		comparer.compareBytes(field+".code", expectedAccount.Code, actualAccount.Code)
+		comparer.compareBytes(field+".codeMetadata", expectedAccount.CodeMetadata, actualAccount.CodeMetadata)
+		comparer.compareBytes(field+".codeDeployerAddress", expectedAccount.CodeDeployerAddress, actualAccount.CodeDeployerAddress)
+		comparer.compareValues(field+".gasUsed", expectedAccount.GasUsed, actualAccount.GasUsed)
+		comparer.compareOutputTransfers(field+".outputTransfers", expectedAccount.OutputTransfers, actualAccount.OutputTransfers)
+	}
+}
+
+func (comparer *outputComparer) compareStorageUpdates(field string, expected map[string]*vmcommon.StorageUpdate, actual map[string]*vmcommon.StorageUpdate) {
+	for _, key := range sortedKeys(expected, actual) {
+		updateField := fmt.Sprintf("%s[%s]", field, formatBytes([]byte(key)))
+		expectedUpdate, expectedFound := expected[key]
+		actualUpdate, actualFound := actual[key]
+		if !expectedFound || !actualFound {
+			comparer.addDifference(updateField, describePresence(expectedFound), describePresence(actualFound))
+			continue
+		}
+
+		comparer.compareBytes(updateField+".offset", expectedUpdate.Offset, actualUpdate.Offset)
+		comparer.compareBytes(updateField+".data", expectedUpdate.Data, actualUpdate.Data)
+		comparer.compareValues(updateField+".written", expectedUpdate.Written, actualUpdate.Written)
+	}
+}
+
+func (comparer *outputComparer) compareOutputTransfers(field string, expected []vmcommon.OutputTransfer, actual []vmcommon.OutputTransfer) {
+	if len(expected) != len(actual) {
+		comparer.addDifference(field+".length", len(expected), len(actual))
+		return
+	}
+
+	for i := range expected {
+		transferField := fmt.Sprintf("%s[%d]", field, i)
+		comparer.compareValues(transferField+".index", expected[i].Index, actual[i].Index)
+		comparer.compareBigInts(transferField+".value", expected[i].Value, actual[i].Value)
+		comparer.compareValues(transferField+".gasLimit", expected[i].GasLimit, actual[i].GasLimit)
+		comparer.compareValues(transferField+".gasLocked", expected[i].GasLocked, actual[i].GasLocked)
+		comparer.compareBytes(transferField+".asyncData", expected[i].AsyncData, actual[i].AsyncData)
+		comparer.compareBytes(transferField+".data", expected[i].Data, actual[i].Data)
+		comparer.compareValues(transferField+".callType", expected[i].CallType, actual[i].CallType)
+		comparer.compareBytes(transferField+".senderAddress", expected[i].SenderAddress, actual[i].SenderAddress)
+	}
+}
+
+func (comparer *outputComparer) compareLogs(expected []*vmcommon.LogEntry, actual []*vmcommon.LogEntry) {
+	if len(expected) != len(actual) {
+		comparer.addDifference("logs.length", len(expected), len(actual))
+		return
+	}
+
+	for i := range expected {
+		field := fmt.Sprintf("logs[%d]", i)
+		comparer.compareBytes(field+".identifier", expected[i].Identifier, actual[i].Identifier)
+		comparer.compareBytes(field+".address", expected[i].Address, actual[i].Address)
+		comparer.compareByteSlices(field+".topics", expected[i].Topics, actual[i].Topics)
+		comparer.compareByteSlices(field+".data", expected[i].Data, actual[i].Data)
+	}
+}
+
+func sortedKeys[V any](first map[string]V, second map[string]V) []string {
+	keys := make([]string, 0, len(first)+len(second))
+	for key := range first {
+		keys = append(keys, key)
+	}
+	for key := range second {
+		if _, found := first[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func bigIntOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
+
+func describePresence(found bool) string {
+	if found {
+		return "present"
+	}
+	return "missing"
+}
+
+func formatBytes(value []byte) string {
+	return fmt.Sprintf("0x%x", value)
+}
+
+func formatByteSlices(values [][]byte) string {
+	formatted := "["
+	for i, value := range values {
+		if i > 0 {
+			formatted += ", "
+		}
+		formatted += formatBytes(value)
+	}
+	return formatted + "]"
+}
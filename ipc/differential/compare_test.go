@@ -0,0 +1,61 @@
+package differential
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVMOutputs_NilAndEmptyAreEqual(t *testing.T) {
+	expected := &vmcommon.VMOutput{
+		ReturnData:     [][]byte{},
+		OutputAccounts: map[string]*vmcommon.OutputAccount{},
+	}
+	actual := &vmcommon.VMOutput{
+		GasRefund: big.NewInt(0),
+	}
+
+	require.Empty(t, CompareVMOutputs(expected, actual))
+	require.Empty(t, CompareVMOutputs(nil, nil))
+	require.Equal(t, []string{"output: present -> none"}, CompareVMOutputs(expected, nil))
+}
+
+func TestCompareVMOutputs_FieldByField(t *testing.T) {
+	expected := &vmcommon.VMOutput{
+		ReturnData:   [][]byte{{1}},
+		GasRemaining: 100,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"alice": {
+				Address: []byte("alice"),
+				Balance: big.NewInt(10),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte{2}},
+				},
+			},
+			"bob": {Address: []byte("bob")},
+		},
+		Logs: []*vmcommon.LogEntry{{Identifier: []byte("event")}},
+	}
+	actual := &vmcommon.VMOutput{
+		ReturnData:   [][]byte{{1}},
+		GasRemaining: 90,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"alice": {
+				Address: []byte("alice"),
+				Balance: big.NewInt(10),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"key": {Offset: []byte("key"), Data: []byte{3}},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []string{
+		"gasRemaining: 100 -> 90",
+		"outputAccounts[0x616c696365].storageUpdates[0x6b6579].data: 0x02 -> 0x03",
+		"outputAccounts[0x626f62]: present -> missing",
+		"logs.length: 1 -> 0",
+	}, CompareVMOutputs(expected, actual))
+}
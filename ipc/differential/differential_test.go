@@ -0,0 +1,41 @@
+package differential
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	"github.com/stretchr/testify/require"
+)
+
+// the self-test scenarios run no contracts, so the two executions are compared even where wasm cannot be executed;
+// applying the gas schedule of the scenario goes through the pipes anyway
+func TestDifferential_SelfTestScenarios(t *testing.T) {
+	reports, err := RunScenariosInFolder("../../test/scenarios-self-test", nil, marshaling.JSON)
+	require.Nil(t, err)
+	require.NotEmpty(t, reports)
+
+	numTxs := 0
+	for _, report := range reports {
+		numTxs += report.NumTxs
+		if report.HasDivergences() {
+			t.Error(report.String())
+		}
+	}
+	require.Greater(t, numTxs, 0)
+}
+
+func TestDifferential_AllScenarios(t *testing.T) {
+	if testing.Short() {
+		t.Skip("not a short test")
+	}
+
+	reports, err := RunScenariosInFolder("../../test", nil, marshaling.JSON)
+	require.Nil(t, err)
+	require.NotEmpty(t, reports)
+
+	for _, report := range reports {
+		if report.HasDivergences() {
+			t.Error(report.String())
+		}
+	}
+}
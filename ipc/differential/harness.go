@@ -0,0 +1,181 @@
+package differential
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	worldmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
+	am "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec"
+	mc "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/controller"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+)
+
+var log = logger.GetOrCreate("vm/differential")
+
+// ScenarioReport holds the divergences between running a scenario with the in-process host and with the piped VM
+type ScenarioReport struct {
+	ScenarioPath string
+	InProcessErr error
+	PipedErr     error
+	NumTxs       int
+	Divergences  []string
+}
+
+// HasDivergences returns true if the two executions of the scenario differ
+func (report *ScenarioReport) HasDivergences() bool {
+	return len(report.Divergences) > 0
+}
+
+// String describes the report, one divergence per line
+func (report *ScenarioReport) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s: %d txs, %d divergences", report.ScenarioPath, report.NumTxs, len(report.Divergences)))
+	for _, divergence := range report.Divergences {
+		builder.WriteString("\n\t")
+		builder.WriteString(divergence)
+	}
+
+	return builder.String()
+}
+
+type txOutput struct {
+	txIdent string
+	output  *vmcommon.VMOutput
+}
+
+type scenarioRun struct {
+	err       error
+	txOutputs []*txOutput
+}
+
+// RunScenario runs a scenario file twice, each time with a fresh executor: once with the in-process host,
+// and once with the piped VM (using the given marshalizer), then compares the outcomes and the outputs of all transactions
+func RunScenario(scenarioPath string, marshalizerKind marshaling.MarshalizerKind) (*ScenarioReport, error) {
+	inProcessExecutor, err := am.NewVMTestExecutor()
+	if err != nil {
+		return nil, err
+	}
+	inProcessRun := runScenarioWithExecutor(scenarioPath, inProcessExecutor)
+
+	pipedExecutor, err := am.NewVMTestExecutorWithVMFactory(func(world *worldmock.MockWorld, hostParameters *vmhost.VMHostParameters) (vmcommon.VMExecutionHandler, error) {
+		return NewPipedVM(world, hostParameters, marshaling.CreateMarshalizer(marshalizerKind))
+	})
+	if err != nil {
+		return nil, err
+	}
+	pipedRun := runScenarioWithExecutor(scenarioPath, pipedExecutor)
+	err = pipedExecutor.GetVM().(*PipedVM).Close()
+	if err != nil {
+		log.Warn("RunScenario: could not close the piped VM", "err", err)
+	}
+
+	report := &ScenarioReport{
+		ScenarioPath: scenarioPath,
+		InProcessErr: inProcessRun.err,
+		PipedErr:     pipedRun.err,
+		NumTxs:       len(inProcessRun.txOutputs),
+		Divergences:  compareScenarioRuns(inProcessRun, pipedRun),
+	}
+	return report, nil
+}
+
+// RunScenariosInFolder runs all the scenarios under a folder, in any format, through RunScenario;
+// the exclusions are file patterns, relative to the folder
+func RunScenariosInFolder(folder string, exclusions []string, marshalizerKind marshaling.MarshalizerKind) ([]*ScenarioReport, error) {
+	var reports []*ScenarioReport
+	err := filepath.Walk(folder, func(scenarioPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !oj.HasSuffixInAnyFormat(scenarioPath, ".scen.json") {
+			return nil
+		}
+
+		isExcluded, err := isScenarioExcluded(folder, scenarioPath, exclusions)
+		if err != nil || isExcluded {
+			return err
+		}
+
+		report, err := RunScenario(scenarioPath, marshalizerKind)
+		if err != nil {
+			return err
+		}
+
+		reports = append(reports, report)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func isScenarioExcluded(folder string, scenarioPath string, exclusions []string) (bool, error) {
+	for _, exclusion := range exclusions {
+		isMatch, err := filepath.Match(filepath.Join(folder, exclusion), scenarioPath)
+		if err != nil {
+			return false, err
+		}
+		if isMatch {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func runScenarioWithExecutor(scenarioPath string, executor *am.VMTestExecutor) *scenarioRun {
+	run := &scenarioRun{}
+	executor.SetTxOutputObserver(func(step *mj.TxStep, output *vmcommon.VMOutput) {
+		run.txOutputs = append(run.txOutputs, &txOutput{
+			txIdent: step.TxIdent,
+			output:  output,
+		})
+	})
+
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	run.err = runner.RunSingleJSONScenario(scenarioPath)
+	return run
+}
+
+func compareScenarioRuns(inProcessRun *scenarioRun, pipedRun *scenarioRun) []string {
+	var divergences []string
+
+	inProcessErr := errorToString(inProcessRun.err)
+	pipedErr := errorToString(pipedRun.err)
+	if inProcessErr != pipedErr {
+		divergences = append(divergences, fmt.Sprintf("result: %s -> %s", inProcessErr, pipedErr))
+	}
+
+	numTxs := len(inProcessRun.txOutputs)
+	if len(pipedRun.txOutputs) != numTxs {
+		divergences = append(divergences, fmt.Sprintf("number of executed txs: %d -> %d", numTxs, len(pipedRun.txOutputs)))
+		if len(pipedRun.txOutputs) < numTxs {
+			numTxs = len(pipedRun.txOutputs)
+		}
+	}
+
+	for i := 0; i < numTxs; i++ {
+		txIdent := inProcessRun.txOutputs[i].txIdent
+		for _, difference := range CompareVMOutputs(inProcessRun.txOutputs[i].output, pipedRun.txOutputs[i].output) {
+			divergences = append(divergences, fmt.Sprintf("tx %s: %s", txIdent, difference))
+		}
+	}
+
+	return divergences
+}
+
+func errorToString(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	return err.Error()
+}
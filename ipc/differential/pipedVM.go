@@ -0,0 +1,142 @@
+package differential
+
+import (
+	"os"
+	"sync"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/nodepart"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/vmpart"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+)
+
+var _ vmcommon.VMExecutionHandler = (*PipedVM)(nil)
+
+// maxLoopTime is generous, since scenarios are not about timing
+const maxLoopTime = 60000
+
+// PipedVM executes contracts out of process, the way the VMDriver does: requests, hook calls and outputs
+// go through the Node's part and the VM's part, marshaled over pipes. The VM's part runs in a goroutine instead of
+// a separate process, so that the host parameters don't need to be marshaled (which is not supported for all of them).
+type PipedVM struct {
+	nodePart   *nodepart.NodePart
+	vmLoopDone chan error
+	mutex      sync.Mutex
+}
+
+// NewPipedVM creates a VM whose blockchain hook is only reachable through the pipes
+func NewPipedVM(
+	blockchainHook vmcommon.BlockchainHook,
+	hostParameters *vmhost.VMHostParameters,
+	marshalizer marshaling.Marshalizer,
+) (*PipedVM, error) {
+	inputOfVM, outputOfNode, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	inputOfNode, outputOfVM, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	vmPart, err := vmpart.NewVMPart("differential", inputOfVM, outputOfVM, hostParameters, marshalizer)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePart, err := nodepart.NewNodePart(
+		inputOfNode,
+		outputOfNode,
+		blockchainHook,
+		nodepart.Config{MaxLoopTime: maxLoopTime},
+		marshalizer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := &PipedVM{
+		nodePart:   nodePart,
+		vmLoopDone: make(chan error, 1),
+	}
+	go func() {
+		vm.vmLoopDone <- vmPart.StartLoop()
+	}()
+
+	return vm, nil
+}
+
+// RunSmartContractCreate sends a deploy request to the VM's part and waits for the output
+func (vm *PipedVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	return vm.runContractRequest(common.NewMessageContractDeployRequest(input))
+}
+
+// RunSmartContractCall sends an execution request to the VM's part and waits for the output
+func (vm *PipedVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return vm.runContractRequest(common.NewMessageContractCallRequest(input))
+}
+
+func (vm *PipedVM) runContractRequest(request common.MessageHandler) (*vmcommon.VMOutput, error) {
+	vm.mutex.Lock()
+	defer vm.mutex.Unlock()
+
+	response, err := vm.nodePart.StartLoop(request)
+	if err != nil {
+		return nil, common.WrapCriticalError(err)
+	}
+
+	typedResponse := response.(*common.MessageContractResponse)
+	vmOutput, err := typedResponse.SerializableVMOutput.ConvertToVMOutput(), response.GetError()
+	if err != nil {
+		return nil, err
+	}
+
+	return vmOutput, nil
+}
+
+// GasScheduleChange sends a "gas change" request to the VM's part and waits for it to be applied
+func (vm *PipedVM) GasScheduleChange(newGasSchedule map[string]map[string]uint64) {
+	vm.mutex.Lock()
+	defer vm.mutex.Unlock()
+
+	_, err := vm.nodePart.StartLoop(common.NewMessageGasScheduleChangeRequest(newGasSchedule))
+	if err != nil {
+		log.Error("GasScheduleChange", "err", err)
+	}
+}
+
+// GetVersion gets the version of the VM's part
+func (vm *PipedVM) GetVersion() string {
+	vm.mutex.Lock()
+	defer vm.mutex.Unlock()
+
+	response, err := vm.nodePart.StartLoop(common.NewMessageVersionRequest())
+	if err != nil {
+		log.Error("GetVersion", "err", err)
+		return ""
+	}
+
+	return response.(*common.MessageVersionResponse).Version
+}
+
+// Close stops the VM's part and closes the pipes
+func (vm *PipedVM) Close() error {
+	vm.mutex.Lock()
+	defer vm.mutex.Unlock()
+
+	err := vm.nodePart.SendStopSignal()
+	if err != nil {
+		return err
+	}
+
+	<-vm.vmLoopDone
+	vm.nodePart.Messenger.Shutdown()
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (vm *PipedVM) IsInterfaceNil() bool {
+	return vm == nil
+}
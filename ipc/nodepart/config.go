@@ -1,6 +1,13 @@
 package nodepart
 
+import "github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+
 // Config is the configuration for the driver and for Node's part
 type Config struct {
 	MaxLoopTime int
+
+	// Transport is how the driver reaches the VM: by default, through pipes to a VM process started by the driver;
+	// for "unix" and "tcp", by attaching to an already running VM, listening at VMAddress
+	Transport common.TransportKind
+	VMAddress string
 }
@@ -1,7 +1,6 @@
 package nodepart
 
 import (
-	"os"
 	"time"
 
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
@@ -14,7 +13,7 @@ type NodeMessenger struct {
 }
 
 // NewNodeMessenger creates a new messenger
-func NewNodeMessenger(reader *os.File, writer *os.File, marshalizer marshaling.Marshalizer) *NodeMessenger {
+func NewNodeMessenger(reader common.ReceiverStream, writer common.SenderStream, marshalizer marshaling.Marshalizer) *NodeMessenger {
 	return &NodeMessenger{
 		Messenger: *common.NewMessengerPipes("NODE", reader, writer, marshalizer),
 	}
@@ -0,0 +1,9 @@
+package nodepart
+
+import "github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+
+// Metrics holds the IPC metrics (per message kind) of Node's part and of VM's part
+type Metrics struct {
+	Node *common.MetricsSnapshot
+	VM   *common.MetricsSnapshot
+}
@@ -2,9 +2,9 @@ package nodepart
 
 import (
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
@@ -20,8 +20,8 @@ type NodePart struct {
 
 // NewNodePart creates the Node part
 func NewNodePart(
-	input *os.File,
-	output *os.File,
+	input common.ReceiverStream,
+	output common.SenderStream,
 	blockchain vmcommon.BlockchainHook,
 	config Config,
 	marshalizer marshaling.Marshalizer,
@@ -75,8 +75,22 @@ func (part *NodePart) noopReplier(_ common.MessageHandler) common.MessageHandler
 
 // StartLoop runs the main loop
 func (part *NodePart) StartLoop(request common.MessageHandler) (common.MessageHandler, error) {
+	return part.StartLoopWithPrefetch(nil, request)
+}
+
+// StartLoopWithPrefetch runs the main loop, sending a prefetch message (if any) just before the request
+func (part *NodePart) StartLoopWithPrefetch(prefetch *common.MessagePrefetchRequest, request common.MessageHandler) (common.MessageHandler, error) {
 	defer part.timeTrack(time.Now(), "[NODE] end of loop")
 
+	if prefetch != nil {
+		err := part.Messenger.SendContractRequest(prefetch)
+		if err != nil {
+			part.Messenger.ResetDialogue()
+			return nil, err
+		}
+	}
+
+	start := time.Now()
 	err := part.Messenger.SendContractRequest(request)
 	if err != nil {
 		return nil, err
@@ -85,6 +99,8 @@ func (part *NodePart) StartLoop(request common.MessageHandler) (common.MessageHa
 	response, err := part.doLoop()
 	if err != nil {
 		log.Warn("[NODE]: end of loop", "err", err)
+	} else {
+		part.Messenger.GetMetrics().RecordRoundTrip(request.GetKind(), time.Since(start))
 	}
 
 	part.Messenger.ResetDialogue()
@@ -117,6 +133,9 @@ func (part *NodePart) doLoop() (common.MessageHandler, error) {
 			continue
 		}
 
+		if common.IsHandshakeResponse(message) {
+			return message, nil
+		}
 		if common.IsVersionResponse(message) {
 			return message, nil
 		}
@@ -134,11 +153,74 @@ func (part *NodePart) doLoop() (common.MessageHandler, error) {
 	}
 }
 
+// Handshake exchanges capabilities with VM, before any other request, and negotiates the message kinds both parts support;
+// it fails if the parts cannot talk to each other (e.g. they were built with different values of the message kinds).
+// A VM that predates the handshake is told apart by its version response, and gets the baseline capabilities.
+func (part *NodePart) Handshake(marshalizer marshaling.MarshalizerKind) (*common.NegotiatedCapabilities, error) {
+	response, err := part.StartLoop(common.NewMessageVersionRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	versionResponse, ok := response.(*common.MessageVersionResponse)
+	if !ok {
+		return nil, common.ErrBadMessageFromVM
+	}
+	if !versionResponse.SupportsHandshake {
+		return common.NewBaselineCapabilities(versionResponse.Version), nil
+	}
+
+	capabilities := common.NewCapabilities()
+	response, err = part.StartLoop(common.NewMessageHandshakeRequest(capabilities))
+	if err != nil {
+		return nil, err
+	}
+
+	typedResponse, ok := response.(*common.MessageHandshakeResponse)
+	if !ok || typedResponse.Capabilities == nil {
+		return nil, common.ErrBadMessageFromVM
+	}
+
+	err = response.GetError()
+	if err != nil {
+		return nil, fmt.Errorf("%w: rejected by vm: %v", common.ErrIncompatibleProtocolVersion, err)
+	}
+
+	return common.NegotiateCapabilities(capabilities, typedResponse.Capabilities, typedResponse.Version, marshalizer)
+}
+
+// CreatePrefetch gathers the code of a contract and the given (hot) keys of its storage, to be pushed to VM ahead of a call;
+// what cannot be read is left out, and will be requested by VM as usual
+func (part *NodePart) CreatePrefetch(contractAddress []byte, storageKeys [][]byte) *common.MessagePrefetchRequest {
+	codes := make([]*common.PrefetchedCode, 0, 1)
+	account, err := part.blockchain.GetUserAccount(contractAddress)
+	if err == nil && !check.IfNil(account) {
+		code := part.blockchain.GetCode(account)
+		if len(code) > 0 {
+			codes = append(codes, &common.PrefetchedCode{Address: contractAddress, Code: code})
+		}
+	}
+
+	storage := make([]*common.PrefetchedStorageEntry, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		value, _, err := part.blockchain.GetStorageData(contractAddress, key)
+		if err != nil {
+			continue
+		}
+
+		storage = append(storage, &common.PrefetchedStorageEntry{Address: contractAddress, Key: key, Value: value})
+	}
+
+	return common.NewMessagePrefetchRequest(codes, storage)
+}
+
 func (part *NodePart) replyToHookCallRequest(request common.MessageHandler) error {
 	defer part.timeTrack(time.Now(), fmt.Sprintf("replyToHookCallRequest %s", request.GetKindName()))
 
+	start := time.Now()
 	replier := part.Repliers[request.GetKind()]
 	hookResponse := replier(request)
+	part.Messenger.GetMetrics().RecordReply(request.GetKind(), time.Since(start))
 	err := part.Messenger.SendHookCallResponse(hookResponse)
 	return err
 }
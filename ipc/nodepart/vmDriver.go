@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"sync"
 	"syscall"
+	"time"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-logger-go/pipes"
@@ -18,7 +19,9 @@ var log = logger.GetOrCreate("vmDriver")
 
 var _ vmcommon.VMExecutionHandler = (*VMDriver)(nil)
 
-// VMDriver manages the execution of the VM process
+const vmDialTimeout = 5 * time.Second
+
+// VMDriver manages the execution of the VM process, or the connection to an already running VM (see Config.Transport)
 type VMDriver struct {
 	blockchainHook      vmcommon.BlockchainHook
 	vmArguments         common.VMArguments
@@ -40,6 +43,15 @@ type VMDriver struct {
 	part     *NodePart
 	logsPart ParentLogsPart
 
+	// for an attached VM, tells whether its connection is still usable
+	connection common.ConnectionHealth
+
+	// kept across restarts of VM
+	nodeMetrics *common.Metrics
+
+	// negotiated on each start of VM
+	capabilities *common.NegotiatedCapabilities
+
 	// When the VMDriver is used to resolve contract queries, it might happen that a query request executes concurrently with other operations (such as "GasScheduleChange").
 	// Query requests are ordered sequentially within the API layer (see the QueryService dispatcher and other related components), but this sequence of queries might
 	// interleave with VM-management operations, which are or might be triggered within a different flow (e.g. the processing flow). For example, "GasScheduleChange" is triggered synchronously
@@ -64,6 +76,7 @@ func NewVMDriver(
 		config:              config,
 		logsMarshalizer:     marshaling.CreateMarshalizer(vmArguments.LogsMarshalizer),
 		messagesMarshalizer: marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer),
+		nodeMetrics:         common.NewMetrics(),
 	}
 
 	err := driver.startVM()
@@ -75,6 +88,10 @@ func NewVMDriver(
 }
 
 func (driver *VMDriver) startVM() error {
+	if driver.config.Transport.IsNetworked() {
+		return driver.attachVM()
+	}
+
 	log.Info("VMDriver.startVM()")
 
 	logsProfileReader, logsWriter, err := driver.resetLogsPart()
@@ -133,15 +150,78 @@ func (driver *VMDriver) startVM() error {
 	if err != nil {
 		return err
 	}
+	driver.part.Messenger.SetMetrics(driver.nodeMetrics)
 
 	err = driver.logsPart.StartLoop(vmStdout, vmStderr)
 	if err != nil {
 		return err
 	}
 
+	return driver.handshake()
+}
+
+// attachVM connects to a running VM, instead of starting a VM process;
+// the VM arguments are sent over the connection, before the dialogue starts
+func (driver *VMDriver) attachVM() error {
+	log.Info("VMDriver.attachVM()", "transport", driver.config.Transport, "address", driver.config.VMAddress)
+
+	// drop the previous connection, if it broke
+	_ = driver.stopVM()
+
+	reader, writer, err := common.Dial(driver.config.Transport, driver.config.VMAddress, vmDialTimeout)
+	if err != nil {
+		return err
+	}
+
+	err = common.SendVMArguments(writer, driver.vmArguments)
+	if err != nil {
+		_ = reader.Close()
+		_ = writer.Close()
+		return err
+	}
+
+	driver.blockchainHook.ClearCompiledCodes()
+
+	driver.part, err = NewNodePart(
+		reader,
+		writer,
+		driver.blockchainHook,
+		driver.config,
+		driver.messagesMarshalizer,
+	)
+	if err != nil {
+		_ = reader.Close()
+		_ = writer.Close()
+		return err
+	}
+	driver.part.Messenger.SetMetrics(driver.nodeMetrics)
+	driver.connection, _ = reader.(common.ConnectionHealth)
+
+	return driver.handshake()
+}
+
+// handshake fails fast (stopping VM) if Node and VM cannot talk to each other
+func (driver *VMDriver) handshake() error {
+	capabilities, err := driver.part.Handshake(driver.vmArguments.MessagesMarshalizer)
+	if err != nil {
+		log.Error("VMDriver.handshake()", "err", err)
+		_ = driver.Close()
+		return err
+	}
+
+	log.Info("VMDriver.handshake()", "protocol", capabilities.ProtocolVersion, "vm", capabilities.VMVersion)
+	driver.capabilities = capabilities
 	return nil
 }
 
+// GetCapabilities gets the capabilities negotiated with VM on its last start
+func (driver *VMDriver) GetCapabilities() *common.NegotiatedCapabilities {
+	driver.operationsMutex.Lock()
+	defer driver.operationsMutex.Unlock()
+
+	return driver.capabilities
+}
+
 func (driver *VMDriver) resetLogsPart() (*os.File, *os.File, error) {
 	logsPart, err := pipes.NewParentPart("VM", driver.logsMarshalizer)
 	if err != nil {
@@ -200,8 +280,12 @@ func (driver *VMDriver) RestartVMIfNecessary() error {
 	return err
 }
 
-// IsClosed checks whether the VM process is closed
+// IsClosed checks whether the VM process is closed (or, for an attached VM, whether the connection is closed or broken)
 func (driver *VMDriver) IsClosed() bool {
+	if driver.config.Transport.IsNetworked() {
+		return driver.part == nil || driver.connection == nil || driver.connection.IsBroken()
+	}
+
 	pid := driver.command.Process.Pid
 	process, err := os.FindProcess(pid)
 	if err != nil {
@@ -297,6 +381,16 @@ func (driver *VMDriver) RunSmartContractCreate(input *vmcommon.ContractCreateInp
 
 // RunSmartContractCall sends an execution request to VM and waits for the output
 func (driver *VMDriver) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return driver.runSmartContractCall(input, false, nil)
+}
+
+// RunSmartContractCallWithPrefetch is like RunSmartContractCall, but it also pushes to VM, along with the request,
+// the code of the contract and the given (known to be hot) keys of its storage, sparing the corresponding hook calls
+func (driver *VMDriver) RunSmartContractCallWithPrefetch(input *vmcommon.ContractCallInput, hotStorageKeys [][]byte) (*vmcommon.VMOutput, error) {
+	return driver.runSmartContractCall(input, true, hotStorageKeys)
+}
+
+func (driver *VMDriver) runSmartContractCall(input *vmcommon.ContractCallInput, withPrefetch bool, hotStorageKeys [][]byte) (*vmcommon.VMOutput, error) {
 	driver.operationsMutex.Lock()
 	defer driver.operationsMutex.Unlock()
 
@@ -308,8 +402,13 @@ func (driver *VMDriver) RunSmartContractCall(input *vmcommon.ContractCallInput)
 		return nil, common.WrapCriticalError(err)
 	}
 
+	var prefetch *common.MessagePrefetchRequest
+	if withPrefetch && driver.capabilities.Supports(common.PrefetchRequest) {
+		prefetch = driver.part.CreatePrefetch(input.RecipientAddr, hotStorageKeys)
+	}
+
 	request := common.NewMessageContractCallRequest(input)
-	response, err := driver.part.StartLoop(request)
+	response, err := driver.part.StartLoopWithPrefetch(prefetch, request)
 	if err != nil {
 		log.Warn("RunSmartContractCall", "err", err)
 		_ = driver.Close()
@@ -346,9 +445,45 @@ func (driver *VMDriver) DiagnoseWait(milliseconds uint32) error {
 	return response.GetError()
 }
 
+// GetMetrics gets the IPC metrics of both parts: those of Node's part (since the driver was created),
+// and those of VM's part (since VM was last started), requested with a diagnose message (if VM supports it, otherwise left nil)
+func (driver *VMDriver) GetMetrics() (*Metrics, error) {
+	driver.operationsMutex.Lock()
+	defer driver.operationsMutex.Unlock()
+
+	err := driver.RestartVMIfNecessary()
+	if err != nil {
+		return nil, common.WrapCriticalError(err)
+	}
+
+	if !driver.capabilities.Supports(common.DiagnoseMetricsRequest) {
+		return &Metrics{Node: driver.nodeMetrics.GetSnapshot()}, nil
+	}
+
+	request := common.NewMessageDiagnoseMetricsRequest()
+	response, err := driver.part.StartLoop(request)
+	if err != nil {
+		log.Error("GetMetrics", "err", err)
+		_ = driver.Close()
+		return nil, common.WrapCriticalError(err)
+	}
+
+	typedResponse, ok := response.(*common.MessageDiagnoseMetricsResponse)
+	if !ok {
+		return nil, common.ErrBadMessageFromVM
+	}
+
+	return &Metrics{
+		Node: driver.nodeMetrics.GetSnapshot(),
+		VM:   typedResponse.Metrics,
+	}, nil
+}
+
 // Close stops VM
 func (driver *VMDriver) Close() error {
-	driver.logsPart.StopLoop()
+	if driver.logsPart != nil {
+		driver.logsPart.StopLoop()
+	}
 
 	err := driver.stopVM()
 	if err != nil {
@@ -360,6 +495,16 @@ func (driver *VMDriver) Close() error {
 }
 
 func (driver *VMDriver) stopVM() error {
+	if driver.config.Transport.IsNetworked() {
+		// an attached VM keeps running, and waits for the next connection
+		if driver.part != nil {
+			driver.part.Messenger.Shutdown()
+			driver.part = nil
+			driver.connection = nil
+		}
+		return nil
+	}
+
 	err := driver.command.Process.Kill()
 	if err != nil {
 		return err
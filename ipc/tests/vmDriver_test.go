@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"fmt"
+	"net"
+	"path/filepath"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -9,7 +12,9 @@ import (
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/config"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/nodepart"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/vmpart"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/mock"
 	contextmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/context"
 	worldmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
@@ -105,6 +110,106 @@ func TestVMDriver_GetVersion(t *testing.T) {
 	require.NotEqual(t, "undefined", version)
 }
 
+func TestVMDriver_ReattachesAfterServerCloses(t *testing.T) {
+	listener, err := common.Listen(common.TransportUnix, filepath.Join(t.TempDir(), "vm.sock"))
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	go serveVersionOnce(listener, true)
+
+	driver := newServedDriver(t, listener)
+	require.Equal(t, "connection 1", driver.GetVersion())
+
+	// the server closed the connection, after replying, which fails the next request
+	require.False(t, driver.IsClosed())
+	require.Equal(t, "", driver.GetVersion())
+	require.True(t, driver.IsClosed())
+
+	// Per this request, the driver attaches to VM again
+	require.Equal(t, "connection 2", driver.GetVersion())
+}
+
+func TestVMDriver_AttachesToVMWithoutHandshake(t *testing.T) {
+	listener, err := common.Listen(common.TransportUnix, filepath.Join(t.TempDir(), "vm.sock"))
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+	go serveVersionOnce(listener, false)
+
+	driver := newServedDriver(t, listener)
+	capabilities := driver.GetCapabilities()
+	require.Equal(t, "fake", capabilities.VMVersion)
+	require.True(t, capabilities.Supports(common.ContractCallRequest))
+	require.False(t, capabilities.Supports(common.PrefetchRequest))
+	require.False(t, capabilities.Supports(common.DiagnoseMetricsRequest))
+
+	// the handshake was not attempted, so the connection is still there for the actual request
+	require.Equal(t, "connection 1", driver.GetVersion())
+}
+
+func newServedDriver(t *testing.T, listener net.Listener) *nodepart.VMDriver {
+	driver, err := nodepart.NewVMDriver(
+		&contextmock.BlockchainHookStub{},
+		common.VMArguments{
+			VMHostParameters:    vmhost.VMHostParameters{VMType: mxVirtualMachine},
+			MessagesMarshalizer: marshaling.JSON,
+		},
+		nodepart.Config{
+			MaxLoopTime: 1000,
+			Transport:   common.TransportUnix,
+			VMAddress:   listener.Addr().String(),
+		},
+	)
+	require.Nil(t, err)
+	require.False(t, driver.IsClosed())
+	return driver
+}
+
+// serveVersionOnce stands in for a VM served by vmpart.ListenAndServe, which stops after one request on each connection:
+// it replies to the version probe and the handshake (if supported), then to one version request, and closes the connection
+func serveVersionOnce(listener net.Listener, supportsHandshake bool) {
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	for numConnections := 1; ; numConnections++ {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		reader, writer := common.NewConnectionStreams(conn)
+		_, _ = common.GetVMArguments(reader)
+		messenger := vmpart.NewVMMessenger(reader, writer, marshalizer)
+		probed := false
+		for {
+			request, err := messenger.ReceiveNodeRequest()
+			if err != nil {
+				break
+			}
+			if request.GetKind() == common.HandshakeRequest {
+				if !supportsHandshake {
+					// a VM that predates the handshake cannot reply to it
+					break
+				}
+				_ = messenger.SendContractResponse(common.NewMessageHandshakeResponse(common.NewCapabilities(), "fake", nil))
+				messenger.ResetDialogue()
+				continue
+			}
+			if !probed {
+				probed = true
+				response := common.NewMessageVersionResponse("fake")
+				response.SupportsHandshake = supportsHandshake
+				_ = messenger.SendContractResponse(response)
+				messenger.ResetDialogue()
+				continue
+			}
+			_ = messenger.SendContractResponse(common.NewMessageVersionResponse(fmt.Sprintf("connection %d", numConnections)))
+			break
+		}
+		_ = conn.Close()
+	}
+}
+
 func newDriver(tb testing.TB, blockchain *contextmock.BlockchainHookStub) *nodepart.VMDriver {
 	driver, err := nodepart.NewVMDriver(
 		blockchain,
@@ -1,9 +1,12 @@
 package tests
 
 import (
+	"net"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-vm-common-go"
@@ -23,10 +26,10 @@ import (
 )
 
 type testFiles struct {
-	outputOfNode *os.File
-	inputOfVM    *os.File
-	outputOfVM   *os.File
-	inputOfNode  *os.File
+	outputOfNode common.SenderStream
+	inputOfVM    common.ReceiverStream
+	outputOfVM   common.SenderStream
+	inputOfNode  common.ReceiverStream
 }
 
 func TestVMPart_SendDeployRequest(t *testing.T) {
@@ -57,13 +60,82 @@ func TestVMPart_SendCallRequest(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestVMPart_SendDiagnoseMetricsRequest(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+
+	response, err := doContractRequest(t, "4", common.NewMessageDiagnoseMetricsRequest(), blockchain)
+	require.Nil(t, err)
+	metrics := response.(*common.MessageDiagnoseMetricsResponse).Metrics
+	require.Equal(t, uint64(1), metrics.GetKindMetrics(common.DiagnoseMetricsRequest).NumReceived)
+}
+
+func TestVMPart_SendHandshakeRequest(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+
+	response, err := doContractRequest(t, "5", common.NewMessageHandshakeRequest(common.NewCapabilities()), blockchain)
+	require.Nil(t, err)
+	typedResponse := response.(*common.MessageHandshakeResponse)
+	require.Nil(t, typedResponse.GetError())
+	require.Equal(t, "testversion", typedResponse.Version)
+
+	negotiated, err := common.NegotiateCapabilities(common.NewCapabilities(), typedResponse.Capabilities, typedResponse.Version, marshaling.JSON)
+	require.Nil(t, err)
+	require.True(t, negotiated.Supports(common.ContractCallRequest))
+	require.True(t, negotiated.Supports(common.PrefetchRequest))
+}
+
+func TestVMPart_SendHandshakeRequestWithIncompatibleVersion(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+
+	capabilities := common.NewCapabilities()
+	capabilities.ProtocolVersion = 0
+	capabilities.MinProtocolVersion = 0
+	response, err := doContractRequest(t, "6", common.NewMessageHandshakeRequest(capabilities), blockchain)
+	require.Nil(t, err)
+	require.NotNil(t, response.GetError())
+}
+
+func TestVMPart_SendCallRequestOverUnixSocket(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Code: bytecodeCounter}, nil
+	}
+
+	files := createTestConnection(t, common.TransportUnix, filepath.Join(t.TempDir(), "vm.sock"))
+	response, err := doContractRequestOverFiles(t, files, createCallRequest("increment"), blockchain)
+	require.NotNil(t, response)
+	require.Nil(t, err)
+}
+
+func TestVMPart_SendCallRequestOverTCP(t *testing.T) {
+	blockchain := &contextmock.BlockchainHookStub{}
+
+	blockchain.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &worldmock.Account{Code: bytecodeCounter}, nil
+	}
+
+	files := createTestConnection(t, common.TransportTCP, "127.0.0.1:0")
+	response, err := doContractRequestOverFiles(t, files, createCallRequest("increment"), blockchain)
+	require.NotNil(t, response)
+	require.Nil(t, err)
+}
+
 func doContractRequest(
 	t *testing.T,
 	tag string,
 	request common.MessageHandler,
 	blockchain vmcommon.BlockchainHook,
 ) (common.MessageHandler, error) {
-	files := createTestFiles(t, tag)
+	return doContractRequestOverFiles(t, createTestFiles(t, tag), request, blockchain)
+}
+
+func doContractRequestOverFiles(
+	t *testing.T,
+	files testFiles,
+	request common.MessageHandler,
+	blockchain vmcommon.BlockchainHook,
+) (common.MessageHandler, error) {
 	var response common.MessageHandler
 	var responseError error
 
@@ -127,6 +199,29 @@ func createTestFiles(t *testing.T, _ string) testFiles {
 	return files
 }
 
+func createTestConnection(t *testing.T, transport common.TransportKind, address string) testFiles {
+	listener, err := common.Listen(transport, address)
+	require.Nil(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	accepted := make(chan net.Conn)
+	go func() {
+		conn, _ := listener.Accept()
+		accepted <- conn
+	}()
+
+	files := testFiles{}
+	files.inputOfNode, files.outputOfNode, err = common.Dial(transport, listener.Addr().String(), time.Second)
+	require.Nil(t, err)
+	conn := <-accepted
+	require.NotNil(t, conn)
+	files.inputOfVM, files.outputOfVM = common.NewConnectionStreams(conn)
+
+	return files
+}
+
 func createDeployRequest(contractCode []byte) common.MessageHandler {
 	return common.NewMessageContractDeployRequest(createDeployInput(contractCode))
 }
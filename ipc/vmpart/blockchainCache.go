@@ -0,0 +1,157 @@
+package vmpart
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+)
+
+// CacheStatistics counts the hook calls answered by the cache of the gateway, and those forwarded to Node
+type CacheStatistics struct {
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64
+	Prefetched    uint64
+}
+
+type storageKey struct {
+	address string
+	key     string
+}
+
+type esdtTokenKey struct {
+	address string
+	tokenID string
+	nonce   uint64
+}
+
+type compiledCode struct {
+	found bool
+	code  []byte
+}
+
+// blockchainCache holds the results of idempotent hook calls, for the duration of one execution (one contract request).
+// The VM keeps its own writes in the output context, so the state of Node only changes under the VM
+// through built-in functions and snapshot reverts: these invalidate everything that is read from accounts.
+type blockchainCache struct {
+	// results of the calls without arguments (block info, built-in function names), by request kind
+	noArguments   map[common.MessageKind]interface{}
+	blockhashes   map[uint64][]byte
+	shards        map[string]uint32
+	codes         map[string][]byte
+	compiledCodes map[string]compiledCode
+
+	storage        map[storageKey][]byte
+	accounts       map[string]vmcommon.UserAccountHandler
+	smartContracts map[string]bool
+	payable        map[string]bool
+	esdtTokens     map[esdtTokenKey]*esdt.ESDigitalToken
+
+	statistics CacheStatistics
+}
+
+func newBlockchainCache() *blockchainCache {
+	cache := &blockchainCache{}
+	cache.reset()
+	return cache
+}
+
+// reset empties the cache (but keeps the statistics), at the end of an execution
+func (cache *blockchainCache) reset() {
+	cache.noArguments = make(map[common.MessageKind]interface{})
+	cache.blockhashes = make(map[uint64][]byte)
+	cache.shards = make(map[string]uint32)
+	cache.codes = make(map[string][]byte)
+	cache.compiledCodes = make(map[string]compiledCode)
+	cache.clearAccounts()
+}
+
+// invalidateAccounts drops all data read from accounts, when the state of Node might have changed
+func (cache *blockchainCache) invalidateAccounts() {
+	cache.clearAccounts()
+	cache.statistics.Invalidations++
+}
+
+func (cache *blockchainCache) clearAccounts() {
+	cache.storage = make(map[storageKey][]byte)
+	cache.accounts = make(map[string]vmcommon.UserAccountHandler)
+	cache.smartContracts = make(map[string]bool)
+	cache.payable = make(map[string]bool)
+	cache.esdtTokens = make(map[esdtTokenKey]*esdt.ESDigitalToken)
+}
+
+func (cache *blockchainCache) prefetch(request *common.MessagePrefetchRequest) {
+	for _, prefetchedCode := range request.Codes {
+		cache.codes[string(prefetchedCode.Address)] = prefetchedCode.Code
+		cache.statistics.Prefetched++
+	}
+
+	for _, entry := range request.Storage {
+		cache.storage[storageKey{address: string(entry.Address), key: string(entry.Key)}] = entry.Value
+		cache.statistics.Prefetched++
+	}
+}
+
+func (cache *blockchainCache) countLookup(found bool) bool {
+	if found {
+		cache.statistics.Hits++
+	} else {
+		cache.statistics.Misses++
+	}
+
+	return found
+}
+
+func (cache *blockchainCache) getNoArguments(kind common.MessageKind) (interface{}, bool) {
+	value, found := cache.noArguments[kind]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getBlockhash(nonce uint64) ([]byte, bool) {
+	value, found := cache.blockhashes[nonce]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getShard(address []byte) (uint32, bool) {
+	value, found := cache.shards[string(address)]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getCode(address []byte) ([]byte, bool) {
+	value, found := cache.codes[string(address)]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getCompiledCode(codeHash []byte) (compiledCode, bool) {
+	value, found := cache.compiledCodes[string(codeHash)]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getStorage(address []byte, key []byte) ([]byte, bool) {
+	value, found := cache.storage[storageKey{address: string(address), key: string(key)}]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) putStorage(address []byte, key []byte, value []byte) {
+	cache.storage[storageKey{address: string(address), key: string(key)}] = value
+}
+
+func (cache *blockchainCache) getAccount(address []byte) (vmcommon.UserAccountHandler, bool) {
+	value, found := cache.accounts[string(address)]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getSmartContract(address []byte) (bool, bool) {
+	value, found := cache.smartContracts[string(address)]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getPayable(address []byte) (bool, bool) {
+	value, found := cache.payable[string(address)]
+	return value, cache.countLookup(found)
+}
+
+func (cache *blockchainCache) getESDTToken(key esdtTokenKey) (*esdt.ESDigitalToken, bool) {
+	value, found := cache.esdtTokens[key]
+	return value, cache.countLookup(found)
+}
@@ -0,0 +1,120 @@
+package vmpart
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/nodepart"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayCache_BlockInfo(t *testing.T) {
+	gateway, numHookCalls := createGatewayWithServingNode(t, func(request common.MessageHandler) common.MessageHandler {
+		require.Equal(t, common.BlockchainCurrentEpochRequest, request.GetKind())
+		return common.NewMessageBlockchainCurrentEpochResponse(42)
+	})
+
+	require.Equal(t, uint32(42), gateway.CurrentEpoch())
+	require.Equal(t, uint32(42), gateway.CurrentEpoch())
+	require.Equal(t, int32(1), atomic.LoadInt32(numHookCalls))
+	require.Equal(t, uint64(1), gateway.GetCacheStatistics().Hits)
+	require.Equal(t, uint64(1), gateway.GetCacheStatistics().Misses)
+
+	gateway.ResetCache()
+	require.Equal(t, uint32(42), gateway.CurrentEpoch())
+	require.Equal(t, int32(2), atomic.LoadInt32(numHookCalls))
+}
+
+func TestGatewayCache_StorageInvalidatedByWrites(t *testing.T) {
+	storageValue := []byte("foo")
+	gateway, numHookCalls := createGatewayWithServingNode(t, func(request common.MessageHandler) common.MessageHandler {
+		switch request.GetKind() {
+		case common.BlockchainGetStorageDataRequest:
+			return common.NewMessageBlockchainGetStorageDataResponse(storageValue, nil)
+		case common.BlockchainProcessBuiltInFunctionRequest:
+			storageValue = []byte("bar")
+			return common.NewMessageBlockchainProcessBuiltInFunctionResponse(&vmcommon.VMOutput{}, nil)
+		case common.BlockchainRevertToSnapshotRequest:
+			storageValue = []byte("foo")
+			return common.NewMessageBlockchainRevertToSnapshotResponse(nil)
+		}
+		return common.NewUndefinedMessage()
+	})
+
+	requireStorage := func(expected string) {
+		value, _, err := gateway.GetStorageData([]byte("alice"), []byte("key"))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(value))
+	}
+
+	requireStorage("foo")
+	requireStorage("foo")
+	require.Equal(t, int32(1), atomic.LoadInt32(numHookCalls))
+
+	_, err := gateway.ProcessBuiltInFunction(&vmcommon.ContractCallInput{Function: "fooFunction"})
+	require.NoError(t, err)
+	requireStorage("bar")
+	require.Equal(t, int32(3), atomic.LoadInt32(numHookCalls))
+
+	err = gateway.RevertToSnapshot(0)
+	require.NoError(t, err)
+	requireStorage("foo")
+	require.Equal(t, int32(5), atomic.LoadInt32(numHookCalls))
+	require.Equal(t, uint64(2), gateway.GetCacheStatistics().Invalidations)
+}
+
+func TestGatewayCache_Prefetch(t *testing.T) {
+	gateway, numHookCalls := createGatewayWithServingNode(t, func(request common.MessageHandler) common.MessageHandler {
+		return common.NewMessageBlockchainGetStorageDataResponse([]byte("not prefetched"), nil)
+	})
+
+	gateway.Prefetch(common.NewMessagePrefetchRequest(
+		[]*common.PrefetchedCode{{Address: []byte("contract"), Code: []byte("code")}},
+		[]*common.PrefetchedStorageEntry{{Address: []byte("contract"), Key: []byte("key"), Value: []byte("value")}},
+	))
+
+	code := gateway.GetCode(&common.Account{Address: []byte("contract")})
+	require.Equal(t, "code", string(code))
+	value, _, err := gateway.GetStorageData([]byte("contract"), []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, "value", string(value))
+	value, _, err = gateway.GetStorageData([]byte("contract"), []byte("other"))
+	require.NoError(t, err)
+	require.Equal(t, "not prefetched", string(value))
+
+	require.Equal(t, int32(1), atomic.LoadInt32(numHookCalls))
+	require.Equal(t, uint64(2), gateway.GetCacheStatistics().Prefetched)
+}
+
+func createGatewayWithServingNode(t *testing.T, handleHookCall func(common.MessageHandler) common.MessageHandler) (*BlockchainHookGateway, *int32) {
+	testFiles := createTestFiles(t)
+	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
+	nodeMessenger := nodepart.NewNodeMessenger(testFiles.inputOfNode, testFiles.outputOfNode, marshalizer)
+	vmMessenger := NewVMMessenger(testFiles.inputOfVM, testFiles.outputOfVM, marshalizer)
+	gateway := NewBlockchainHookGateway(vmMessenger)
+	numHookCalls := int32(0)
+
+	go func() {
+		for {
+			request, err := nodeMessenger.Receive(0)
+			if err != nil {
+				return
+			}
+
+			atomic.AddInt32(&numHookCalls, 1)
+			err = nodeMessenger.SendHookCallResponse(handleHookCall(request))
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	t.Cleanup(func() {
+		vmMessenger.Shutdown()
+	})
+
+	return gateway, &numHookCalls
+}
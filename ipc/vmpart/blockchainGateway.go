@@ -9,14 +9,34 @@ import (
 
 var _ vmcommon.BlockchainHook = (*BlockchainHookGateway)(nil)
 
-// BlockchainHookGateway forwards requests to the actual hook
+// BlockchainHookGateway forwards requests to the actual hook.
+// The results of idempotent requests are cached until ResetCache is called, at the end of each execution.
 type BlockchainHookGateway struct {
 	messenger *VMMessenger
+	cache     *blockchainCache
 }
 
 // NewBlockchainHookGateway creates a new gateway
 func NewBlockchainHookGateway(messenger *VMMessenger) *BlockchainHookGateway {
-	return &BlockchainHookGateway{messenger: messenger}
+	return &BlockchainHookGateway{
+		messenger: messenger,
+		cache:     newBlockchainCache(),
+	}
+}
+
+// ResetCache empties the cache of hook call results
+func (blockchain *BlockchainHookGateway) ResetCache() {
+	blockchain.cache.reset()
+}
+
+// Prefetch fills the cache with the data pushed by Node ahead of a contract request
+func (blockchain *BlockchainHookGateway) Prefetch(request *common.MessagePrefetchRequest) {
+	blockchain.cache.prefetch(request)
+}
+
+// GetCacheStatistics returns the statistics of the cache, accumulated since the gateway was created
+func (blockchain *BlockchainHookGateway) GetCacheStatistics() CacheStatistics {
+	return blockchain.cache.statistics
 }
 
 // NewAddress forwards a message to the actual hook
@@ -38,6 +58,10 @@ func (blockchain *BlockchainHookGateway) NewAddress(creatorAddress []byte, creat
 
 // GetStorageData forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	cached, found := blockchain.cache.getStorage(accountAddress, index)
+	if found {
+		return cached, 0, nil
+	}
 
 	request := common.NewMessageBlockchainGetStorageDataRequest(accountAddress, index)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -50,11 +74,20 @@ func (blockchain *BlockchainHookGateway) GetStorageData(accountAddress []byte, i
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetStorageDataResponse)
-	return response.Data, 0, response.GetError()
+	err = response.GetError()
+	if err == nil {
+		blockchain.cache.putStorage(accountAddress, index, response.Data)
+	}
+
+	return response.Data, 0, err
 }
 
 // GetBlockhash forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetBlockhash(nonce uint64) ([]byte, error) {
+	cached, found := blockchain.cache.getBlockhash(nonce)
+	if found {
+		return cached, nil
+	}
 
 	request := common.NewMessageBlockchainGetBlockhashRequest(nonce)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -67,11 +100,20 @@ func (blockchain *BlockchainHookGateway) GetBlockhash(nonce uint64) ([]byte, err
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetBlockhashResponse)
-	return response.Result, response.GetError()
+	err = response.GetError()
+	if err == nil {
+		blockchain.cache.blockhashes[nonce] = response.Result
+	}
+
+	return response.Result, err
 }
 
 // LastNonce forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) LastNonce() uint64 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainLastNonceRequest)
+	if found {
+		return cached.(uint64)
+	}
 
 	request := common.NewMessageBlockchainLastNonceRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -84,11 +126,16 @@ func (blockchain *BlockchainHookGateway) LastNonce() uint64 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainLastNonceResponse)
+	blockchain.cache.noArguments[common.BlockchainLastNonceRequest] = response.Result
 	return response.Result
 }
 
 // LastRound forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) LastRound() uint64 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainLastRoundRequest)
+	if found {
+		return cached.(uint64)
+	}
 
 	request := common.NewMessageBlockchainLastRoundRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -101,11 +148,16 @@ func (blockchain *BlockchainHookGateway) LastRound() uint64 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainLastRoundResponse)
+	blockchain.cache.noArguments[common.BlockchainLastRoundRequest] = response.Result
 	return response.Result
 }
 
 // LastTimeStamp forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) LastTimeStamp() uint64 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainLastTimeStampRequest)
+	if found {
+		return cached.(uint64)
+	}
 
 	request := common.NewMessageBlockchainLastTimeStampRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -118,11 +170,16 @@ func (blockchain *BlockchainHookGateway) LastTimeStamp() uint64 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainLastTimeStampResponse)
+	blockchain.cache.noArguments[common.BlockchainLastTimeStampRequest] = response.Result
 	return response.Result
 }
 
 // LastRandomSeed forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) LastRandomSeed() []byte {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainLastRandomSeedRequest)
+	if found {
+		return cached.([]byte)
+	}
 
 	request := common.NewMessageBlockchainLastRandomSeedRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -135,11 +192,16 @@ func (blockchain *BlockchainHookGateway) LastRandomSeed() []byte {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainLastRandomSeedResponse)
+	blockchain.cache.noArguments[common.BlockchainLastRandomSeedRequest] = response.Result
 	return response.Result
 }
 
 // LastEpoch forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) LastEpoch() uint32 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainLastEpochRequest)
+	if found {
+		return cached.(uint32)
+	}
 
 	request := common.NewMessageBlockchainLastEpochRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -152,11 +214,16 @@ func (blockchain *BlockchainHookGateway) LastEpoch() uint32 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainLastEpochResponse)
+	blockchain.cache.noArguments[common.BlockchainLastEpochRequest] = response.Result
 	return response.Result
 }
 
 // GetStateRootHash forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetStateRootHash() []byte {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainGetStateRootHashRequest)
+	if found {
+		return cached.([]byte)
+	}
 
 	request := common.NewMessageBlockchainGetStateRootHashRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -169,11 +236,16 @@ func (blockchain *BlockchainHookGateway) GetStateRootHash() []byte {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetStateRootHashResponse)
+	blockchain.cache.noArguments[common.BlockchainGetStateRootHashRequest] = response.Result
 	return response.Result
 }
 
 // CurrentNonce forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) CurrentNonce() uint64 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainCurrentNonceRequest)
+	if found {
+		return cached.(uint64)
+	}
 
 	request := common.NewMessageBlockchainCurrentNonceRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -186,11 +258,16 @@ func (blockchain *BlockchainHookGateway) CurrentNonce() uint64 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainCurrentNonceResponse)
+	blockchain.cache.noArguments[common.BlockchainCurrentNonceRequest] = response.Result
 	return response.Result
 }
 
 // CurrentRound forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) CurrentRound() uint64 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainCurrentRoundRequest)
+	if found {
+		return cached.(uint64)
+	}
 
 	request := common.NewMessageBlockchainCurrentRoundRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -203,11 +280,16 @@ func (blockchain *BlockchainHookGateway) CurrentRound() uint64 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainCurrentRoundResponse)
+	blockchain.cache.noArguments[common.BlockchainCurrentRoundRequest] = response.Result
 	return response.Result
 }
 
 // CurrentTimeStamp forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) CurrentTimeStamp() uint64 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainCurrentTimeStampRequest)
+	if found {
+		return cached.(uint64)
+	}
 
 	request := common.NewMessageBlockchainCurrentTimeStampRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -220,11 +302,16 @@ func (blockchain *BlockchainHookGateway) CurrentTimeStamp() uint64 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainCurrentTimeStampResponse)
+	blockchain.cache.noArguments[common.BlockchainCurrentTimeStampRequest] = response.Result
 	return response.Result
 }
 
 // CurrentRandomSeed forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) CurrentRandomSeed() []byte {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainCurrentRandomSeedRequest)
+	if found {
+		return cached.([]byte)
+	}
 
 	request := common.NewMessageBlockchainCurrentRandomSeedRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -237,11 +324,16 @@ func (blockchain *BlockchainHookGateway) CurrentRandomSeed() []byte {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainCurrentRandomSeedResponse)
+	blockchain.cache.noArguments[common.BlockchainCurrentRandomSeedRequest] = response.Result
 	return response.Result
 }
 
 // CurrentEpoch forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) CurrentEpoch() uint32 {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainCurrentEpochRequest)
+	if found {
+		return cached.(uint32)
+	}
 
 	request := common.NewMessageBlockchainCurrentEpochRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -254,11 +346,14 @@ func (blockchain *BlockchainHookGateway) CurrentEpoch() uint32 {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainCurrentEpochResponse)
+	blockchain.cache.noArguments[common.BlockchainCurrentEpochRequest] = response.Result
 	return response.Result
 }
 
 // ProcessBuiltInFunction forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	// built-in functions modify accounts on Node's side
+	blockchain.cache.invalidateAccounts()
 
 	request := common.NewMessageBlockchainProcessBuiltInFunctionRequest(input)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -276,6 +371,10 @@ func (blockchain *BlockchainHookGateway) ProcessBuiltInFunction(input *vmcommon.
 
 // GetBuiltinFunctionNames forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	cached, found := blockchain.cache.getNoArguments(common.BlockchainGetBuiltinFunctionNamesRequest)
+	if found {
+		return cached.(vmcommon.FunctionNames)
+	}
 
 	request := common.NewMessageBlockchainGetBuiltinFunctionNamesRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -288,6 +387,7 @@ func (blockchain *BlockchainHookGateway) GetBuiltinFunctionNames() vmcommon.Func
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetBuiltinFunctionNamesResponse)
+	blockchain.cache.noArguments[common.BlockchainGetBuiltinFunctionNamesRequest] = response.Result
 	return response.Result
 }
 
@@ -310,6 +410,10 @@ func (blockchain *BlockchainHookGateway) GetAllState(address []byte) (map[string
 
 // GetUserAccount forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	cached, found := blockchain.cache.getAccount(address)
+	if found {
+		return cached, nil
+	}
 
 	request := common.NewMessageBlockchainGetUserAccountRequest(address)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -322,11 +426,20 @@ func (blockchain *BlockchainHookGateway) GetUserAccount(address []byte) (vmcommo
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetUserAccountResponse)
-	return response.Result, response.GetError()
+	err = response.GetError()
+	if err == nil {
+		blockchain.cache.accounts[string(address)] = response.Result
+	}
+
+	return response.Result, err
 }
 
 // GetCode forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetCode(account vmcommon.UserAccountHandler) []byte {
+	cached, found := blockchain.cache.getCode(account.AddressBytes())
+	if found {
+		return cached
+	}
 
 	requestAccount := &common.Account{
 		Nonce:           account.GetNonce(),
@@ -350,11 +463,16 @@ func (blockchain *BlockchainHookGateway) GetCode(account vmcommon.UserAccountHan
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetCodeResponse)
+	blockchain.cache.codes[string(account.AddressBytes())] = response.Code
 	return response.Code
 }
 
 // GetShardOfAddress forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetShardOfAddress(address []byte) uint32 {
+	cached, found := blockchain.cache.getShard(address)
+	if found {
+		return cached
+	}
 
 	request := common.NewMessageBlockchainGetShardOfAddressRequest(address)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -367,11 +485,16 @@ func (blockchain *BlockchainHookGateway) GetShardOfAddress(address []byte) uint3
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetShardOfAddressResponse)
+	blockchain.cache.shards[string(address)] = response.Result
 	return response.Result
 }
 
 // IsSmartContract forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) IsSmartContract(address []byte) bool {
+	cached, found := blockchain.cache.getSmartContract(address)
+	if found {
+		return cached
+	}
 
 	request := common.NewMessageBlockchainIsSmartContractRequest(address)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -384,11 +507,16 @@ func (blockchain *BlockchainHookGateway) IsSmartContract(address []byte) bool {
 	}
 
 	response := rawResponse.(*common.MessageBlockchainIsSmartContractResponse)
+	blockchain.cache.smartContracts[string(address)] = response.Result
 	return response.Result
 }
 
 // IsPayable forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) IsPayable(_, address []byte) (bool, error) {
+	cached, found := blockchain.cache.getPayable(address)
+	if found {
+		return cached, nil
+	}
 
 	request := common.NewMessageBlockchainIsPayableRequest(address)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -401,11 +529,17 @@ func (blockchain *BlockchainHookGateway) IsPayable(_, address []byte) (bool, err
 	}
 
 	response := rawResponse.(*common.MessageBlockchainIsPayableResponse)
-	return response.Result, response.GetError()
+	err = response.GetError()
+	if err == nil {
+		blockchain.cache.payable[string(address)] = response.Result
+	}
+
+	return response.Result, err
 }
 
 // SaveCompiledCode forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) SaveCompiledCode(codeHash []byte, code []byte) {
+	blockchain.cache.compiledCodes[string(codeHash)] = compiledCode{found: true, code: code}
 
 	request := common.NewMessageBlockchainSaveCompiledCodeRequest(codeHash, code)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -422,6 +556,10 @@ func (blockchain *BlockchainHookGateway) SaveCompiledCode(codeHash []byte, code
 
 // GetCompiledCode forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	cached, found := blockchain.cache.getCompiledCode(codeHash)
+	if found {
+		return cached.found, cached.code
+	}
 
 	request := common.NewMessageBlockchainGetCompiledCodeRequest(codeHash)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -434,11 +572,13 @@ func (blockchain *BlockchainHookGateway) GetCompiledCode(codeHash []byte) (bool,
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetCompiledCodeResponse)
+	blockchain.cache.compiledCodes[string(codeHash)] = compiledCode{found: response.Found, code: response.Code}
 	return response.Found, response.Code
 }
 
 // ClearCompiledCodes forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) ClearCompiledCodes() {
+	blockchain.cache.compiledCodes = make(map[string]compiledCode)
 
 	request := common.NewMessageBlockchainClearCompiledCodesRequest()
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -455,6 +595,11 @@ func (blockchain *BlockchainHookGateway) ClearCompiledCodes() {
 
 // GetESDTToken forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	tokenKey := esdtTokenKey{address: string(address), tokenID: string(tokenID), nonce: nonce}
+	cached, found := blockchain.cache.getESDTToken(tokenKey)
+	if found {
+		return cached, nil
+	}
 
 	request := common.NewMessageBlockchainGetESDTTokenRequest(address, tokenID, nonce)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
@@ -467,7 +612,12 @@ func (blockchain *BlockchainHookGateway) GetESDTToken(address []byte, tokenID []
 	}
 
 	response := rawResponse.(*common.MessageBlockchainGetESDTTokenResponse)
-	return response.Result, response.GetError()
+	err = response.GetError()
+	if err == nil {
+		blockchain.cache.esdtTokens[tokenKey] = response.Result
+	}
+
+	return response.Result, err
 }
 
 // IsPaused not used in v1.3
@@ -504,6 +654,7 @@ func (blockchain *BlockchainHookGateway) GetSnapshot() int {
 
 // RevertToSnapshot forwards a message to the actual hook
 func (blockchain *BlockchainHookGateway) RevertToSnapshot(snapshot int) error {
+	blockchain.cache.invalidateAccounts()
 
 	request := common.NewMessageBlockchainRevertToSnapshotRequest(snapshot)
 	rawResponse, err := blockchain.messenger.SendHookCallRequest(request)
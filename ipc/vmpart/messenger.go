@@ -1,7 +1,7 @@
 package vmpart
 
 import (
-	"os"
+	"time"
 
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
@@ -13,7 +13,7 @@ type VMMessenger struct {
 }
 
 // NewVMMessenger creates a new messenger
-func NewVMMessenger(reader *os.File, writer *os.File, marshalizer marshaling.Marshalizer) *VMMessenger {
+func NewVMMessenger(reader common.ReceiverStream, writer common.SenderStream, marshalizer marshaling.Marshalizer) *VMMessenger {
 	return &VMMessenger{
 		Messenger: *common.NewMessengerPipes("VM", reader, writer, marshalizer),
 	}
@@ -45,6 +45,7 @@ func (messenger *VMMessenger) SendContractResponse(response common.MessageHandle
 func (messenger *VMMessenger) SendHookCallRequest(request common.MessageHandler) (common.MessageHandler, error) {
 	log.Trace("[VM]: SendHookCallRequest", "request", request.DebugString())
 
+	start := time.Now()
 	err := messenger.Send(request)
 	if err != nil {
 		return nil, common.ErrCannotSendHookCallRequest
@@ -55,5 +56,6 @@ func (messenger *VMMessenger) SendHookCallRequest(request common.MessageHandler)
 		return nil, common.ErrCannotReceiveHookCallResponse
 	}
 
+	messenger.GetMetrics().RecordRoundTrip(request.GetKind(), time.Since(start))
 	return response, nil
 }
@@ -1,7 +1,6 @@
 package vmpart
 
 import (
-	"os"
 	"time"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -16,17 +15,18 @@ var log = logger.GetOrCreate("vm/part")
 
 // VMPart is the endpoint that implements the message loop on VM's side
 type VMPart struct {
-	Messenger *VMMessenger
-	VMHost    vmcommon.VMExecutionHandler
-	Repliers  []common.MessageReplier
-	Version   string
+	Messenger  *VMMessenger
+	Blockchain *BlockchainHookGateway
+	VMHost     vmcommon.VMExecutionHandler
+	Repliers   []common.MessageReplier
+	Version    string
 }
 
 // NewVMPart creates the VM part
 func NewVMPart(
 	version string,
-	input *os.File,
-	output *os.File,
+	input common.ReceiverStream,
+	output common.SenderStream,
 	vmHostParameters *vmhost.VMHostParameters,
 	marshalizer marshaling.Marshalizer,
 ) (*VMPart, error) {
@@ -42,15 +42,17 @@ func NewVMPart(
 	}
 
 	part := &VMPart{
-		Messenger: messenger,
-		VMHost:    newVMHost,
-		Version:   version,
+		Messenger:  messenger,
+		Blockchain: blockchain,
+		VMHost:     newVMHost,
+		Version:    version,
 	}
 
 	part.Repliers = common.CreateReplySlots(part.noopReplier)
 	part.Repliers[common.ContractDeployRequest] = part.replyToRunSmartContractCreate
 	part.Repliers[common.ContractCallRequest] = part.replyToRunSmartContractCall
 	part.Repliers[common.DiagnoseWaitRequest] = part.replyToDiagnoseWait
+	part.Repliers[common.DiagnoseMetricsRequest] = part.replyToDiagnoseMetrics
 	part.Repliers[common.VersionRequest] = part.replyToVersionRequest
 	part.Repliers[common.GasScheduleChangeRequest] = part.replyToGasScheduleChange
 
@@ -81,6 +83,11 @@ func (part *VMPart) doLoop() error {
 		if common.IsStopRequest(request) {
 			return common.ErrStopPerNodeRequest
 		}
+		if common.IsPrefetchRequest(request) {
+			// no response, the contract request follows
+			part.Blockchain.Prefetch(request.(*common.MessagePrefetchRequest))
+			continue
+		}
 
 		response := part.replyToNodeRequest(request)
 
@@ -91,12 +98,54 @@ func (part *VMPart) doLoop() error {
 		}
 
 		part.Messenger.ResetDialogue()
+		part.resetBlockchainCache()
 	}
 }
 
+func (part *VMPart) resetBlockchainCache() {
+	statistics := part.Blockchain.GetCacheStatistics()
+	log.Trace("end of request, reset hook cache",
+		"hits", statistics.Hits,
+		"misses", statistics.Misses,
+		"invalidations", statistics.Invalidations,
+		"prefetched", statistics.Prefetched,
+	)
+	part.Blockchain.ResetCache()
+}
+
 func (part *VMPart) replyToNodeRequest(request common.MessageHandler) common.MessageHandler {
-	replier := part.Repliers[request.GetKind()]
-	return replier(request)
+	start := time.Now()
+	replier := part.getReplier(request.GetKind())
+	response := replier(request)
+	part.Messenger.GetMetrics().RecordReply(request.GetKind(), time.Since(start))
+	return response
+}
+
+// getReplier tolerates unknown kinds (e.g. sent by a newer Node, which should have checked the capabilities of VM first)
+func (part *VMPart) getReplier(kind common.MessageKind) common.MessageReplier {
+	if kind == common.HandshakeRequest {
+		return part.replyToHandshake
+	}
+	if int(kind) >= len(part.Repliers) {
+		return part.noopReplier
+	}
+
+	return part.Repliers[kind]
+}
+
+func (part *VMPart) replyToHandshake(request common.MessageHandler) common.MessageHandler {
+	typedRequest := request.(*common.MessageHandshakeRequest)
+	capabilities := common.NewCapabilities()
+	if typedRequest.Capabilities == nil {
+		return common.NewMessageHandshakeResponse(capabilities, part.Version, common.ErrBadRequestFromNode)
+	}
+
+	err := capabilities.CheckProtocolVersion(typedRequest.Capabilities)
+	if err != nil {
+		log.Error("handshake", "err", err)
+	}
+
+	return common.NewMessageHandshakeResponse(capabilities, part.Version, err)
 }
 
 func (part *VMPart) replyToRunSmartContractCreate(request common.MessageHandler) common.MessageHandler {
@@ -118,8 +167,14 @@ func (part *VMPart) replyToDiagnoseWait(request common.MessageHandler) common.Me
 	return common.NewMessageDiagnoseWaitResponse()
 }
 
+func (part *VMPart) replyToDiagnoseMetrics(_ common.MessageHandler) common.MessageHandler {
+	return common.NewMessageDiagnoseMetricsResponse(part.Messenger.GetMetrics().GetSnapshot())
+}
+
 func (part *VMPart) replyToVersionRequest(_ common.MessageHandler) common.MessageHandler {
-	return common.NewMessageVersionResponse(part.Version)
+	response := common.NewMessageVersionResponse(part.Version)
+	response.SupportsHandshake = true
+	return response
 }
 
 func (part *VMPart) replyToGasScheduleChange(request common.MessageHandler) common.MessageHandler {
@@ -0,0 +1,66 @@
+package vmpart
+
+import (
+	"net"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/common"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/ipc/marshaling"
+)
+
+// ListenAndServe runs a VM part that a driver attaches to, over a Unix domain socket or TCP,
+// which allows the VM to run apart from the node (e.g. in another container).
+// Connections are served one at a time: when the driver stops (or restarts) the VM, its connection ends and the next one is awaited.
+// The logs of the VM are not forwarded to the node, as they are when the VM is started by the driver.
+func ListenAndServe(version string, transport common.TransportKind, address string) error {
+	listener, err := common.Listen(transport, address)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	log.Info("VM part listening", "transport", transport, "address", listener.Addr())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		err = ServeConnection(version, conn)
+		if err == common.ErrStopPerNodeRequest {
+			return err
+		}
+
+		log.Debug("connection ended", "err", err)
+	}
+}
+
+// ServeConnection runs the VM part over a connection, until the connection ends or the node requests a stop.
+// Same as with pipes, the driver first sends the VM arguments, then the dialogue starts.
+func ServeConnection(version string, conn net.Conn) error {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	reader, writer := common.NewConnectionStreams(conn)
+
+	vmArguments, err := common.GetVMArguments(reader)
+	if err != nil {
+		return err
+	}
+
+	part, err := NewVMPart(
+		version,
+		reader,
+		writer,
+		&vmArguments.VMHostParameters,
+		marshaling.CreateMarshalizer(vmArguments.MessagesMarshalizer),
+	)
+	if err != nil {
+		return err
+	}
+
+	return part.StartLoop()
+}
@@ -166,6 +166,11 @@ func (m *MeteringContextMock) BlockGasLimit() uint64 {
 	return m.BlockGasLimitMock
 }
 
+// SetBlockGasLimit mocked method
+func (m *MeteringContextMock) SetBlockGasLimit(gasLimit uint64) {
+	m.BlockGasLimitMock = gasLimit
+}
+
 // DeductInitialGasForExecution mocked method
 func (m *MeteringContextMock) DeductInitialGasForExecution(_ []byte) error {
 	return m.Err
@@ -196,6 +196,15 @@ func (r *RuntimeContextMock) GetRuntimeBreakpointValue() vmhost.BreakpointValue
 	return r.CurrentBreakpointValue
 }
 
+// SetExecutionInterrupt mocked method
+func (r *RuntimeContextMock) SetExecutionInterrupt(_ vmhost.BreakpointValue) {
+}
+
+// GetExecutionInterrupt mocked method
+func (r *RuntimeContextMock) GetExecutionInterrupt() vmhost.BreakpointValue {
+	return vmhost.BreakpointNone
+}
+
 // ExecuteAsyncCall mocked method
 func (r *RuntimeContextMock) ExecuteAsyncCall(address []byte, data []byte, value []byte) error {
 	return r.Err
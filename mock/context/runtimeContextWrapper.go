@@ -52,6 +52,10 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetRuntimeBreakpointValueFunc func() vmhost.BreakpointValue
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetExecutionInterruptFunc func(value vmhost.BreakpointValue)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetExecutionInterruptFunc func() vmhost.BreakpointValue
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	IsContractOnTheStackFunc func(address []byte) bool
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetAsyncCallInfoFunc func() *vmhost.AsyncCallInfo
@@ -215,6 +219,14 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *Runti
 		return runtimeWrapper.runtimeContext.GetRuntimeBreakpointValue()
 	}
 
+	runtimeWrapper.SetExecutionInterruptFunc = func(value vmhost.BreakpointValue) {
+		runtimeWrapper.runtimeContext.SetExecutionInterrupt(value)
+	}
+
+	runtimeWrapper.GetExecutionInterruptFunc = func() vmhost.BreakpointValue {
+		return runtimeWrapper.runtimeContext.GetExecutionInterrupt()
+	}
+
 	runtimeWrapper.IsContractOnTheStackFunc = func(address []byte) bool {
 		return runtimeWrapper.runtimeContext.IsContractOnTheStack(address)
 	}
@@ -470,6 +482,16 @@ func (contextWrapper *RuntimeContextWrapper) GetRuntimeBreakpointValue() vmhost.
 	return contextWrapper.GetRuntimeBreakpointValueFunc()
 }
 
+// SetExecutionInterrupt calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetExecutionInterrupt(value vmhost.BreakpointValue) {
+	contextWrapper.SetExecutionInterruptFunc(value)
+}
+
+// GetExecutionInterrupt calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetExecutionInterrupt() vmhost.BreakpointValue {
+	return contextWrapper.GetExecutionInterruptFunc()
+}
+
 // IsContractOnTheStack calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) IsContractOnTheStack(address []byte) bool {
 	return contextWrapper.IsContractOnTheStackFunc(address)
@@ -1,6 +1,7 @@
 package worldmock
 
 import (
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	"github.com/multiversx/mx-chain-vm-common-go/mock"
@@ -36,7 +37,7 @@ func NewBuiltinFunctionsWrapper(
 		Marshalizer:                      WorldMarshalizer,
 		Accounts:                         world.AccountsAdapter,
 		ShardCoordinator:                 world,
-		EnableEpochsHandler:              &mock.EnableEpochsHandlerStub{},
+		EnableEpochsHandler:              newBuiltinFunctionsEnableEpochsHandler(),
 		GuardedAccountHandler:            world.GuardedAccountHandler,
 		MaxNumOfAddressesForTransferRole: 100,
 	}
@@ -65,6 +66,17 @@ func NewBuiltinFunctionsWrapper(
 	return builtinFuncsWrapper, nil
 }
 
+// newBuiltinFunctionsEnableEpochsHandler enables only the builtin function fixes the mock world relies on:
+// without CheckCorrectTokenIDForTransferRoleFlag, limited transfers look for the transfer role under the wrong key,
+// and are never allowed
+func newBuiltinFunctionsEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == builtInFunctions.CheckCorrectTokenIDForTransferRoleFlag
+		},
+	}
+}
+
 // ProcessBuiltInFunction delegates the execution of a real builtin function to
 // the inner BuiltInFunctionContainer.
 func (bf *BuiltinFunctionsWrapper) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 )
 
 // ErrNegativeValue signals that a negative value has been detected and it is not allowed
@@ -222,6 +223,66 @@ func (a *Account) GetTokenRoles(tokenName []byte) ([][]byte, error) {
 
 }
 
+// SetTokenFrozen freezes or unfreezes a token for the account, on all the
+// instances it holds; a frozen account can neither send nor receive the token.
+// If the account holds no instance yet, an empty one is saved to carry the flag.
+func (a *Account) SetTokenFrozen(tokenName []byte, frozen bool) error {
+	tokenKeys := a.getTokenInstanceKeys(tokenName)
+	if len(tokenKeys) == 0 {
+		tokenKeys = append(tokenKeys, MakeTokenKey(tokenName, 0))
+	}
+
+	for _, tokenKey := range tokenKeys {
+		tokenData, err := a.GetTokenData(tokenKey)
+		if err != nil {
+			return err
+		}
+
+		userMetadata := builtInFunctions.ESDTUserMetadataFromBytes(tokenData.Properties)
+		userMetadata.Frozen = frozen
+		tokenData.Properties = userMetadata.ToBytes()
+
+		err = a.SetTokenData(tokenKey, tokenData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsTokenFrozen returns true if any instance of the token held by the account is frozen.
+func (a *Account) IsTokenFrozen(tokenName []byte) (bool, error) {
+	for _, tokenKey := range a.getTokenInstanceKeys(tokenName) {
+		tokenData, err := a.GetTokenData(tokenKey)
+		if err != nil {
+			return false, err
+		}
+
+		if builtInFunctions.ESDTUserMetadataFromBytes(tokenData.Properties).Frozen {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getTokenInstanceKeys returns the storage keys of all the instances of a token held by the account.
+func (a *Account) getTokenInstanceKeys(tokenName []byte) [][]byte {
+	tokenKeys := make([][]byte, 0)
+	for _, tokenKey := range a.GetTokenKeys() {
+		instanceTokenName, _, err := a.loadMockESDTDataInstance(tokenKey)
+		if err != nil {
+			continue
+		}
+		if instanceTokenName == string(tokenName) {
+			tokenKeys = append(tokenKeys, tokenKey)
+		}
+	}
+
+	return tokenKeys
+}
+
 // GetTokenKeys returns the storage keys of all the ESDT tokens owned by the account.
 func (a *Account) GetTokenKeys() [][]byte {
 	tokenKeys := make([][]byte, 0)
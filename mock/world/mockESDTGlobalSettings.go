@@ -0,0 +1,44 @@
+package worldmock
+
+import (
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+// GetTokenGlobalMetadata returns the token-level settings of an ESDT (paused,
+// limited transfer), as kept in the storage of the system account; the
+// builtin functions read the settings from the same place when transferring.
+func (b *MockWorld) GetTokenGlobalMetadata(tokenName []byte) builtInFunctions.ESDTGlobalMetadata {
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		return builtInFunctions.ESDTGlobalMetadata{}
+	}
+
+	tokenKey := MakeTokenKey(tokenName, 0)
+	return builtInFunctions.ESDTGlobalMetadataFromBytes(systemAccount.Storage[string(tokenKey)])
+}
+
+// SetTokenPaused pauses or unpauses all transfers of the given token.
+func (b *MockWorld) SetTokenPaused(tokenName []byte, paused bool) {
+	metadata := b.GetTokenGlobalMetadata(tokenName)
+	metadata.Paused = paused
+	b.setTokenGlobalMetadata(tokenName, &metadata)
+}
+
+// SetTokenLimitedTransfer restricts the transfers of the given token to
+// accounts holding the ESDTTransferRole, or lifts the restriction.
+func (b *MockWorld) SetTokenLimitedTransfer(tokenName []byte, limitedTransfer bool) {
+	metadata := b.GetTokenGlobalMetadata(tokenName)
+	metadata.LimitedTransfer = limitedTransfer
+	b.setTokenGlobalMetadata(tokenName, &metadata)
+}
+
+func (b *MockWorld) setTokenGlobalMetadata(tokenName []byte, metadata *builtInFunctions.ESDTGlobalMetadata) {
+	systemAccount := b.AcctMap.GetAccount(vmcommon.SystemAccountAddress)
+	if systemAccount == nil {
+		systemAccount = b.AcctMap.CreateAccount(vmcommon.SystemAccountAddress, b)
+	}
+
+	tokenKey := MakeTokenKey(tokenName, 0)
+	systemAccount.Storage[string(tokenKey)] = metadata.ToBytes()
+}
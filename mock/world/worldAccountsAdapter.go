@@ -3,6 +3,7 @@ package worldmock
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -10,22 +11,32 @@ import (
 	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
 )
 
-// ErrTrieHandlingNotImplemented indicates that no trie-related operations are
-// currently implemented.
-var ErrTrieHandlingNotImplemented = errors.New("trie handling not implemented")
+// ErrRootHashNotFound indicates that the requested root hash was never committed, or is no longer kept.
+var ErrRootHashNotFound = errors.New("root hash not found")
+
+// DefaultMaxCommittedStates is how many of the latest committed states are kept for RecreateTrie by default.
+const DefaultMaxCommittedStates = 8
 
 // MockAccountsAdapter is an implementation of AccountsAdapter based on
 // MockWorld and the accounts within it.
 type MockAccountsAdapter struct {
 	World     *MockWorld
 	Snapshots []AccountMap
+
+	// MaxCommittedStates bounds the committed states kept for RecreateTrie, the oldest ones are dropped first.
+	// Every kept state is a copy of all the accounts, 0 keeps none.
+	MaxCommittedStates  int
+	CommittedStates     map[string]AccountMap
+	committedRootHashes []string
 }
 
 // NewMockAccountsAdapter instantiates a new MockAccountsAdapter.
 func NewMockAccountsAdapter(world *MockWorld) *MockAccountsAdapter {
 	return &MockAccountsAdapter{
-		World:     world,
-		Snapshots: make([]AccountMap, 0),
+		World:              world,
+		Snapshots:          make([]AccountMap, 0),
+		MaxCommittedStates: DefaultMaxCommittedStates,
+		CommittedStates:    make(map[string]AccountMap),
 	}
 }
 
@@ -71,10 +82,41 @@ func (m *MockAccountsAdapter) RemoveAccount(address []byte) error {
 	return nil
 }
 
-// Commit -
+// Commit computes the state root hash, which becomes the world state root hash,
+// and keeps a copy of the committed state for RecreateTrie, within MaxCommittedStates.
 func (m *MockAccountsAdapter) Commit() ([]byte, error) {
 	m.Snapshots = make([]AccountMap, 0)
-	return nil, nil
+
+	rootHash := m.World.AcctMap.computeStateRootHash()
+	m.keepCommittedState(string(rootHash))
+	m.World.StateRootHash = rootHash
+
+	return rootHash, nil
+}
+
+func (m *MockAccountsAdapter) keepCommittedState(rootHash string) {
+	if m.MaxCommittedStates <= 0 {
+		return
+	}
+
+	_, alreadyKept := m.CommittedStates[rootHash]
+	if alreadyKept {
+		// the same state was committed again, so it becomes the latest
+		for i, keptRootHash := range m.committedRootHashes {
+			if keptRootHash == rootHash {
+				m.committedRootHashes = append(m.committedRootHashes[:i], m.committedRootHashes[i+1:]...)
+				break
+			}
+		}
+	} else {
+		m.CommittedStates[rootHash] = m.World.AcctMap.Clone()
+	}
+	m.committedRootHashes = append(m.committedRootHashes, rootHash)
+
+	for len(m.committedRootHashes) > m.MaxCommittedStates {
+		delete(m.CommittedStates, m.committedRootHashes[0])
+		m.committedRootHashes = m.committedRootHashes[1:]
+	}
 }
 
 // JournalLen -
@@ -118,14 +160,25 @@ func (m *MockAccountsAdapter) GetCode(codeHash []byte) []byte {
 	return nil
 }
 
-// RootHash -
+// RootHash computes the root hash of the current state, including uncommitted changes.
 func (m *MockAccountsAdapter) RootHash() ([]byte, error) {
-	return nil, ErrTrieHandlingNotImplemented
+	return m.World.AcctMap.computeStateRootHash(), nil
 }
 
-// RecreateTrie -
-func (m *MockAccountsAdapter) RecreateTrie(_ []byte) error {
-	return ErrTrieHandlingNotImplemented
+// RecreateTrie restores the state committed under the given root hash, if it is still kept.
+func (m *MockAccountsAdapter) RecreateTrie(rootHash []byte) error {
+	committedState, found := m.CommittedStates[string(rootHash)]
+	if !found {
+		if !bytes.Equal(rootHash, EmptyTrieRootHash) {
+			return fmt.Errorf("%w: %s", ErrRootHashNotFound, hex.EncodeToString(rootHash))
+		}
+		committedState = NewAccountMap()
+	}
+
+	m.World.AcctMap = committedState.Clone()
+	m.World.StateRootHash = rootHash
+	m.Snapshots = make([]AccountMap, 0)
+	return nil
 }
 
 // SnapshotState -
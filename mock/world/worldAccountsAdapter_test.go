@@ -0,0 +1,98 @@
+package worldmock
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = []byte("alice___________________________")
+
+func newTestAccountsAdapter() (*MockWorld, *MockAccountsAdapter) {
+	world := NewMockWorld()
+	world.AcctMap.CreateAccount(testAddress, world)
+	return world, world.AccountsAdapter.(*MockAccountsAdapter)
+}
+
+func setTestBalance(world *MockWorld, balance int64) {
+	world.AcctMap.GetAccount(testAddress).Balance = big.NewInt(balance)
+}
+
+func TestMockAccountsAdapter_CommitAndRecreateTrie(t *testing.T) {
+	world, adapter := newTestAccountsAdapter()
+
+	setTestBalance(world, 1)
+	rootHash1, err := adapter.Commit()
+	require.Nil(t, err)
+	require.Equal(t, rootHash1, world.GetStateRootHash())
+
+	setTestBalance(world, 2)
+	uncommittedRootHash, _ := adapter.RootHash()
+	require.NotEqual(t, rootHash1, uncommittedRootHash)
+	require.Equal(t, rootHash1, world.GetStateRootHash())
+
+	rootHash2, _ := adapter.Commit()
+	require.Equal(t, uncommittedRootHash, rootHash2)
+
+	err = adapter.RecreateTrie(rootHash1)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1), world.AcctMap.GetAccount(testAddress).Balance)
+	require.Equal(t, rootHash1, world.GetStateRootHash())
+
+	// the restored state is a copy, changing it does not change the committed state
+	setTestBalance(world, 3)
+	err = adapter.RecreateTrie(rootHash2)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(2), world.AcctMap.GetAccount(testAddress).Balance)
+	err = adapter.RecreateTrie(rootHash1)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1), world.AcctMap.GetAccount(testAddress).Balance)
+
+	err = adapter.RecreateTrie(EmptyTrieRootHash)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(world.AcctMap))
+
+	err = adapter.RecreateTrie([]byte("unknown"))
+	require.True(t, errors.Is(err, ErrRootHashNotFound))
+}
+
+func TestMockAccountsAdapter_CommittedStatesAreBounded(t *testing.T) {
+	world, adapter := newTestAccountsAdapter()
+	adapter.MaxCommittedStates = 3
+
+	var rootHashes [][]byte
+	for balance := int64(1); balance <= 5; balance++ {
+		setTestBalance(world, balance)
+		rootHash, _ := adapter.Commit()
+		rootHashes = append(rootHashes, rootHash)
+	}
+	require.Equal(t, 3, len(adapter.CommittedStates))
+
+	// the oldest states were dropped
+	require.True(t, errors.Is(adapter.RecreateTrie(rootHashes[0]), ErrRootHashNotFound))
+	require.True(t, errors.Is(adapter.RecreateTrie(rootHashes[1]), ErrRootHashNotFound))
+	require.Nil(t, adapter.RecreateTrie(rootHashes[2]))
+	require.Equal(t, big.NewInt(3), world.AcctMap.GetAccount(testAddress).Balance)
+
+	// committing a kept state again makes it the latest, instead of keeping it twice
+	_, _ = adapter.Commit()
+	setTestBalance(world, 6)
+	_, _ = adapter.Commit()
+	require.Equal(t, 3, len(adapter.CommittedStates))
+	require.Nil(t, adapter.RecreateTrie(rootHashes[2]))
+	require.True(t, errors.Is(adapter.RecreateTrie(rootHashes[3]), ErrRootHashNotFound))
+	require.Nil(t, adapter.RecreateTrie(rootHashes[4]))
+}
+
+func TestMockAccountsAdapter_NoCommittedStates(t *testing.T) {
+	world, adapter := newTestAccountsAdapter()
+	adapter.MaxCommittedStates = 0
+
+	setTestBalance(world, 1)
+	rootHash, _ := adapter.Commit()
+	require.Equal(t, 0, len(adapter.CommittedStates))
+	require.Equal(t, rootHash, world.GetStateRootHash())
+	require.True(t, errors.Is(adapter.RecreateTrie(rootHash), ErrRootHashNotFound))
+}
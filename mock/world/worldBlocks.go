@@ -0,0 +1,106 @@
+package worldmock
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-v1_3-go/crypto/hashing"
+)
+
+// maxBlockhashes is the number of block hashes kept by the mock world,
+// counting back from the current block.
+const maxBlockhashes = 256
+
+// StartBlock makes the given block the current one and records its hash,
+// which is generated from the block info and the hash of the block before it.
+// Blockhashes stays indexed by the distance from the current nonce, as
+// expected by GetBlockhash; nonces skipped between blocks have no hash.
+func (b *MockWorld) StartBlock(blockInfo *BlockInfo) error {
+	if b.PreviousBlockInfo != nil && blockInfo.BlockNonce <= b.PreviousBlockInfo.BlockNonce {
+		return fmt.Errorf("%w: new block nonce %d, previous block nonce %d",
+			ErrBlockNonceNotIncreasing,
+			blockInfo.BlockNonce,
+			b.PreviousBlockInfo.BlockNonce)
+	}
+
+	oldNonce := b.CurrentNonce()
+	newNonce := blockInfo.BlockNonce
+	getOldBlockhash := func(nonce uint64) []byte {
+		if nonce > oldNonce || oldNonce-nonce >= uint64(len(b.Blockhashes)) {
+			return nil
+		}
+		return b.Blockhashes[oldNonce-nonce]
+	}
+
+	numBlockhashes := uint64(1)
+	if newNonce+uint64(len(b.Blockhashes)) > oldNonce+numBlockhashes {
+		numBlockhashes = newNonce + uint64(len(b.Blockhashes)) - oldNonce
+	}
+	if numBlockhashes > maxBlockhashes {
+		numBlockhashes = maxBlockhashes
+	}
+
+	var previousBlockhash []byte
+	if newNonce > 0 {
+		previousBlockhash = getOldBlockhash(newNonce - 1)
+	}
+
+	blockhashes := make([][]byte, numBlockhashes)
+	blockhashes[0] = GenerateBlockhash(blockInfo, previousBlockhash)
+	for offset := uint64(1); offset < numBlockhashes && offset <= newNonce; offset++ {
+		blockhashes[offset] = getOldBlockhash(newNonce - offset)
+	}
+
+	b.Blockhashes = blockhashes
+	b.CurrentBlockInfo = blockInfo
+	return nil
+}
+
+// EndBlock commits the current block, which becomes the previous block.
+func (b *MockWorld) EndBlock() {
+	if b.CurrentBlockInfo == nil {
+		return
+	}
+
+	committedBlockInfo := *b.CurrentBlockInfo
+	b.PreviousBlockInfo = &committedBlockInfo
+}
+
+// GenerateBlockhash deterministically derives a block hash from the block
+// info and the hash of the previous block, chaining the mocked blocks.
+func GenerateBlockhash(blockInfo *BlockInfo, previousBlockhash []byte) []byte {
+	data := make([]byte, 0, 128)
+	data = binary.BigEndian.AppendUint64(data, blockInfo.BlockNonce)
+	data = binary.BigEndian.AppendUint64(data, blockInfo.BlockRound)
+	data = binary.BigEndian.AppendUint32(data, blockInfo.BlockEpoch)
+	data = binary.BigEndian.AppendUint64(data, blockInfo.BlockTimestamp)
+	if blockInfo.RandomSeed != nil {
+		data = append(data, blockInfo.RandomSeed[:]...)
+	}
+	data = append(data, previousBlockhash...)
+
+	return blockHash(data)
+}
+
+// GenerateRandomSeed deterministically derives the random seed of a block
+// from the random seed of the block before it.
+func GenerateRandomSeed(previousRandomSeed *[48]byte, nonce uint64) *[48]byte {
+	data := binary.BigEndian.AppendUint64(nil, nonce)
+	if previousRandomSeed != nil {
+		data = append(data, previousRandomSeed[:]...)
+	}
+
+	var randomSeed [48]byte
+	firstHash := blockHash(data)
+	copy(randomSeed[:], firstHash)
+	copy(randomSeed[len(firstHash):], blockHash(firstHash))
+	return &randomSeed
+}
+
+func blockHash(data []byte) []byte {
+	hash, err := hashing.NewHasher().Keccak256(data)
+	if err != nil {
+		return nil
+	}
+	return hash
+}
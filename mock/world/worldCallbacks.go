@@ -19,8 +19,9 @@ var ErrBuiltinFuncWrapperNotInitialized = errors.New("builtin function not found
 var zero = big.NewInt(0)
 
 // NewAddress provides the address for a new account.
-// It looks up the explicit new address mocks, if none found generates one using a fake but realistic algorithm.
-func (b *MockWorld) NewAddress(creatorAddress []byte, creatorNonce uint64, _ []byte) ([]byte, error) {
+// It looks up the explicit new address mocks, if none found generates one using a fake but realistic algorithm,
+// or derives it exactly like the protocol does, if ProtocolNewAddresses is set.
+func (b *MockWorld) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
 	// custom error
 	if b.Err != nil {
 		return nil, b.Err
@@ -35,6 +36,15 @@ func (b *MockWorld) NewAddress(creatorAddress []byte, creatorNonce uint64, _ []b
 		}
 	}
 
+	if b.ProtocolNewAddresses {
+		result, err := GenerateProtocolAddress(creatorAddress, creatorNonce, vmType)
+		if err != nil {
+			return nil, err
+		}
+		b.LastCreatedContractAddress = result
+		return result, nil
+	}
+
 	// If a mock address wasn't registered for the specified creatorAddress, generate one automatically.
 	// This is not the real algorithm but it's simple and close enough.
 	result := GenerateMockAddress(creatorAddress, creatorNonce)
@@ -114,7 +124,8 @@ func (b *MockWorld) LastEpoch() uint32 {
 	return b.PreviousBlockInfo.BlockEpoch
 }
 
-// GetStateRootHash returns the state root hash from the last committed block
+// GetStateRootHash returns the state root hash from the last committed block,
+// which is the root hash computed by the last AccountsAdapter.Commit
 func (b *MockWorld) GetStateRootHash() []byte {
 	return b.StateRootHash
 }
@@ -278,14 +289,14 @@ func (b *MockWorld) ClearCompiledCodes() {
 	b.CompiledCode = make(map[string][]byte)
 }
 
-// IsPaused -
-func (b *MockWorld) IsPaused(_ []byte) bool {
-	return false
+// IsPaused returns true if the token was paused, see SetTokenPaused.
+func (b *MockWorld) IsPaused(tokenID []byte) bool {
+	return b.GetTokenGlobalMetadata(tokenID).Paused
 }
 
-// IsLimitedTransfer -
-func (b *MockWorld) IsLimitedTransfer(_ []byte) bool {
-	return false
+// IsLimitedTransfer returns true if the token has limited transfer, see SetTokenLimitedTransfer.
+func (b *MockWorld) IsLimitedTransfer(tokenID []byte) bool {
+	return b.GetTokenGlobalMetadata(tokenID).LimitedTransfer
 }
 
 // IsInterfaceNil returns true if underlying implementation is nil
@@ -33,6 +33,7 @@ type MockWorld struct {
 	CurrentBlockInfo           *BlockInfo
 	Blockhashes                [][]byte
 	NewAddressMocks            []*NewAddressMock
+	ProtocolNewAddresses       bool
 	StateRootHash              []byte
 	Err                        error
 	LastCreatedContractAddress []byte
@@ -0,0 +1,63 @@
+package worldmock
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// EnableEpochsHandler activates flags according to the epoch of the current
+// block of the mock world. Flags without an activation epoch are active from
+// genesis, so by default all flags are enabled.
+type EnableEpochsHandler struct {
+	world               *MockWorld
+	mutActivationEpochs sync.RWMutex
+	activationEpochs    map[core.EnableEpochFlag]uint32
+}
+
+// NewEnableEpochsHandler creates a new EnableEpochsHandler, with all flags enabled from genesis.
+func NewEnableEpochsHandler(world *MockWorld) *EnableEpochsHandler {
+	return &EnableEpochsHandler{
+		world:            world,
+		activationEpochs: make(map[core.EnableEpochFlag]uint32),
+	}
+}
+
+// SetActivationEpochs replaces all the activation epochs.
+func (handler *EnableEpochsHandler) SetActivationEpochs(activationEpochs map[core.EnableEpochFlag]uint32) {
+	handler.mutActivationEpochs.Lock()
+	defer handler.mutActivationEpochs.Unlock()
+
+	handler.activationEpochs = make(map[core.EnableEpochFlag]uint32, len(activationEpochs))
+	for flag, epoch := range activationEpochs {
+		handler.activationEpochs[flag] = epoch
+	}
+}
+
+// IsFlagDefined returns true, all flags are known to the mock.
+func (handler *EnableEpochsHandler) IsFlagDefined(_ core.EnableEpochFlag) bool {
+	return true
+}
+
+// IsFlagEnabled returns true if the flag is active in the epoch of the current block.
+func (handler *EnableEpochsHandler) IsFlagEnabled(flag core.EnableEpochFlag) bool {
+	return handler.IsFlagEnabledInEpoch(flag, handler.world.CurrentEpoch())
+}
+
+// IsFlagEnabledInEpoch returns true if the flag is active in the given epoch.
+func (handler *EnableEpochsHandler) IsFlagEnabledInEpoch(flag core.EnableEpochFlag, epoch uint32) bool {
+	return epoch >= handler.GetActivationEpoch(flag)
+}
+
+// GetActivationEpoch returns the activation epoch of the flag, 0 if not set.
+func (handler *EnableEpochsHandler) GetActivationEpoch(flag core.EnableEpochFlag) uint32 {
+	handler.mutActivationEpochs.RLock()
+	defer handler.mutActivationEpochs.RUnlock()
+
+	return handler.activationEpochs[flag]
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (handler *EnableEpochsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
@@ -8,3 +8,9 @@ var ErrInsufficientFunds = errors.New("insufficient funds")
 
 // ErrNilWorldMock signals that the WorldMock is nil but shouldn't be.
 var ErrNilWorldMock = errors.New("nil worldmock")
+
+// ErrInvalidVMTypeLength signals that the VM type given for a new address has an incorrect length.
+var ErrInvalidVMTypeLength = errors.New("invalid VM type length")
+
+// ErrBlockNonceNotIncreasing signals that a new block does not come after the last committed block.
+var ErrBlockNonceNotIncreasing = errors.New("block nonce must be greater than the nonce of the previous block")
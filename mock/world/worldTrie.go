@@ -0,0 +1,175 @@
+package worldmock
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/crypto/hashing"
+)
+
+const (
+	trieLeafNode      = byte(0)
+	trieExtensionNode = byte(1)
+	trieBranchNode    = byte(2)
+	trieBranchWidth   = 16
+)
+
+// EmptyTrieRootHash is the root hash of a trie without any entries.
+var EmptyTrieRootHash = trieHash([]byte{})
+
+type trieEntry struct {
+	path  []byte
+	value []byte
+}
+
+// computeTrieRootHash computes the root hash of the Patricia-Merkle trie holding
+// the given key-value pairs. Keys are split into nibbles; entries with empty values
+// are considered missing, like deleted storage keys. The result only depends on the
+// contents of the map, never on insertion order.
+func computeTrieRootHash(data map[string][]byte) []byte {
+	entries := make([]trieEntry, 0, len(data))
+	for key, value := range data {
+		if len(value) == 0 {
+			continue
+		}
+		entries = append(entries, trieEntry{
+			path:  keyToNibbles([]byte(key)),
+			value: value,
+		})
+	}
+	if len(entries) == 0 {
+		return EmptyTrieRootHash
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return string(entries[i].path) < string(entries[j].path)
+	})
+
+	return hashTrieNode(entries, 0)
+}
+
+// hashTrieNode hashes the node holding the given sorted entries, which share the first depth nibbles.
+func hashTrieNode(entries []trieEntry, depth int) []byte {
+	if len(entries) == 1 {
+		return hashTrieLeaf(entries[0].path[depth:], entries[0].value)
+	}
+
+	prefixLength := commonPrefixLength(entries, depth)
+	if prefixLength > 0 {
+		path := entries[0].path[depth : depth+prefixLength]
+		child := hashTrieNode(entries, depth+prefixLength)
+		return hashTrieExtension(path, child)
+	}
+
+	var value []byte
+	if len(entries[0].path) == depth {
+		// sorting puts the entry ending at this node first
+		value = entries[0].value
+		entries = entries[1:]
+	}
+
+	children := make([][]byte, trieBranchWidth)
+	for start := 0; start < len(entries); {
+		nibble := entries[start].path[depth]
+		end := start + 1
+		for end < len(entries) && entries[end].path[depth] == nibble {
+			end++
+		}
+		children[nibble] = hashTrieNode(entries[start:end], depth+1)
+		start = end
+	}
+
+	return hashTrieBranch(children, value)
+}
+
+func hashTrieLeaf(path []byte, value []byte) []byte {
+	encoded := []byte{trieLeafNode}
+	encoded = appendTrieBytes(encoded, path)
+	encoded = appendTrieBytes(encoded, value)
+	return trieHash(encoded)
+}
+
+func hashTrieExtension(path []byte, child []byte) []byte {
+	encoded := []byte{trieExtensionNode}
+	encoded = appendTrieBytes(encoded, path)
+	encoded = appendTrieBytes(encoded, child)
+	return trieHash(encoded)
+}
+
+func hashTrieBranch(children [][]byte, value []byte) []byte {
+	encoded := []byte{trieBranchNode}
+	for _, child := range children {
+		encoded = appendTrieBytes(encoded, child)
+	}
+	encoded = appendTrieBytes(encoded, value)
+	return trieHash(encoded)
+}
+
+func commonPrefixLength(entries []trieEntry, depth int) int {
+	first := entries[0].path
+	last := entries[len(entries)-1].path
+
+	// the entries are sorted, so the first and last entries share the shortest prefix
+	length := 0
+	for depth+length < len(first) && depth+length < len(last) && first[depth+length] == last[depth+length] {
+		length++
+	}
+
+	return length
+}
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+func appendTrieBytes(encoded []byte, data []byte) []byte {
+	encoded = binary.AppendUvarint(encoded, uint64(len(data)))
+	return append(encoded, data...)
+}
+
+func trieHash(data []byte) []byte {
+	hash, err := hashing.NewHasher().Keccak256(data)
+	if err != nil {
+		logger.GetOrCreate("worldTrie").Trace("trieHash", "error", err)
+	}
+	return hash
+}
+
+// trieValue serializes the account fields committed to by the accounts trie.
+// The storage is committed to through RootHash, which must be up to date.
+func (a *Account) trieValue() []byte {
+	encoded := binary.AppendUvarint(nil, a.Nonce)
+	encoded = appendTrieBytes(encoded, bigIntBytes(a.Balance))
+	encoded = appendTrieBytes(encoded, a.CodeHash)
+	encoded = appendTrieBytes(encoded, a.RootHash)
+	encoded = appendTrieBytes(encoded, a.CodeMetadata)
+	encoded = appendTrieBytes(encoded, a.OwnerAddress)
+	encoded = appendTrieBytes(encoded, a.Username)
+	encoded = appendTrieBytes(encoded, bigIntBytes(a.DeveloperReward))
+	return encoded
+}
+
+func bigIntBytes(value *big.Int) []byte {
+	if value == nil {
+		return nil
+	}
+	return value.Bytes()
+}
+
+// computeStateRootHash updates the storage root hash of every account and
+// returns the root hash of the accounts trie.
+func (am AccountMap) computeStateRootHash() []byte {
+	accounts := make(map[string][]byte, len(am))
+	for address, account := range am {
+		account.RootHash = computeTrieRootHash(account.Storage)
+		accounts[address] = account.trieValue()
+	}
+
+	return computeTrieRootHash(accounts)
+}
@@ -0,0 +1,91 @@
+package worldmock
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the expected root hashes were computed independently, from the node encoding documented in worldTrie.go
+func TestComputeTrieRootHash_KnownValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     map[string][]byte
+		rootHash string
+	}{
+		{
+			name:     "empty",
+			data:     map[string][]byte{},
+			rootHash: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+		{
+			name:     "leaf",
+			data:     map[string][]byte{"key": []byte("value")},
+			rootHash: "c77050bd509b7da420032894a4163d147aa9907cf36bddd11180cdf685bd7138",
+		},
+		{
+			name:     "extension",
+			data:     map[string][]byte{"key-a": []byte("value-a"), "key-b": []byte("value-b")},
+			rootHash: "de2a956fac6591abf84cf6619e06883410fd969487c470a4367741e4d2c737c7",
+		},
+		{
+			name:     "branch with value",
+			data:     map[string][]byte{"a": []byte("1"), "b": []byte("2"), "ab": []byte("3")},
+			rootHash: "0e6a2b9648c5f6a8f8670ff62b768d4cb4bbf36ead3ea84cf08e1178b9e7a7e8",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.rootHash, hex.EncodeToString(computeTrieRootHash(testCase.data)))
+		})
+	}
+	require.Equal(t, EmptyTrieRootHash, computeTrieRootHash(nil))
+}
+
+func TestComputeTrieRootHash_EmptyValuesAreMissing(t *testing.T) {
+	withEmptyValue := map[string][]byte{
+		"key-a": []byte("value-a"),
+		"key-b": []byte("value-b"),
+		"key-c": {},
+	}
+	withoutEmptyValue := map[string][]byte{
+		"key-a": []byte("value-a"),
+		"key-b": []byte("value-b"),
+	}
+	require.Equal(t, computeTrieRootHash(withoutEmptyValue), computeTrieRootHash(withEmptyValue))
+	require.Equal(t, EmptyTrieRootHash, computeTrieRootHash(map[string][]byte{"key": nil}))
+}
+
+func TestComputeTrieRootHash_Deterministic(t *testing.T) {
+	data := make(map[string][]byte)
+	for i := 0; i < 200; i++ {
+		data[string([]byte{byte(i), byte(i * 7), byte(i % 3)})] = []byte{byte(i), 1}
+	}
+
+	// map iteration order differs between runs, the root hash must not
+	rootHash := computeTrieRootHash(data)
+	for i := 0; i < 10; i++ {
+		require.Equal(t, rootHash, computeTrieRootHash(data))
+	}
+
+	// changing any value changes the root hash
+	data[string([]byte{5, 35, 2})] = []byte{0}
+	require.NotEqual(t, rootHash, computeTrieRootHash(data))
+}
+
+func TestComputeStateRootHash_KnownValue(t *testing.T) {
+	accounts := NewAccountMap()
+	accounts.PutAccount(&Account{
+		Address: []byte("alice___________________________"),
+		Nonce:   1,
+		Balance: big.NewInt(1000),
+		Storage: map[string][]byte{"key": []byte("value")},
+	})
+
+	rootHash := accounts.computeStateRootHash()
+	require.Equal(t, "6cd6bfcfdda1b79a750c86d03f3f1d07ea3ba343abc283b458718e6c613938fc", hex.EncodeToString(rootHash))
+	require.Equal(t, computeTrieRootHash(map[string][]byte{"key": []byte("value")}), accounts.GetAccount([]byte("alice___________________________")).RootHash)
+}
@@ -1,5 +1,20 @@
 package worldmock
 
+import (
+	"encoding/binary"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/crypto/hashing"
+)
+
+const (
+	// protocolAddressLength is the length of the addresses handled by the protocol
+	protocolAddressLength = 32
+
+	// shardIdentifierLength is the length of the address suffix which determines the shard
+	shardIdentifierLength = 2
+)
+
 // GenerateMockAddress simulates creation of a new address by the protocol.
 func GenerateMockAddress(creatorAddress []byte, creatorNonce uint64) []byte {
 	result := make([]byte, 32)
@@ -14,3 +29,34 @@ func GenerateMockAddress(creatorAddress []byte, creatorNonce uint64) []byte {
 	copy(result[30:], creatorAddress[30:])
 	return result
 }
+
+// GenerateProtocolAddress derives the address of a new contract exactly like the protocol does:
+// the Keccak256 hash of the creator address and little-endian nonce, prefixed by zeros and the
+// VM type and ending with the last bytes of the creator address, so it stays in the same shard.
+func GenerateProtocolAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	if len(creatorAddress) != protocolAddressLength {
+		return nil, ErrInvalidAddressLength
+	}
+	if len(vmType) != core.VMTypeLen {
+		return nil, ErrInvalidVMTypeLength
+	}
+
+	nonceBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nonceBytes, creatorNonce)
+
+	addressAndNonce := make([]byte, 0, len(creatorAddress)+len(nonceBytes))
+	addressAndNonce = append(addressAndNonce, creatorAddress...)
+	addressAndNonce = append(addressAndNonce, nonceBytes...)
+
+	result, err := hashing.NewHasher().Keccak256(addressAndNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, core.NumInitCharactersForScAddress-core.VMTypeLen, core.NumInitCharactersForScAddress)
+	prefix = append(prefix, vmType...)
+	copy(result[:core.NumInitCharactersForScAddress], prefix)
+	copy(result[len(result)-shardIdentifierLength:], creatorAddress[len(creatorAddress)-shardIdentifierLength:])
+
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package worldmock
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var wasmVMType = []byte{5, 0}
+
+func TestGenerateProtocolAddress_KnownAddresses(t *testing.T) {
+	// erd1j0hxzs7dcyxw08c4k2nv9tfcaxmqy8rj59meq505w92064x0h40qcxh3ap, and the addresses
+	// of its first two contracts, as computed by the MultiversX SDKs
+	creator, _ := hex.DecodeString("93ee6143cdc10ce79f15b2a6c2ad38e9b6021c72a1779051f47154fd54cfbd5e")
+
+	address, err := GenerateProtocolAddress(creator, 0, wasmVMType)
+	require.Nil(t, err)
+	require.Equal(t, "00000000000000000500bb652200ed1f994200ab6699462cab4b1af7b11ebd5e", hex.EncodeToString(address))
+
+	address, err = GenerateProtocolAddress(creator, 1, wasmVMType)
+	require.Nil(t, err)
+	require.Equal(t, "000000000000000005006e4f90488e27342f9a46e1809452c85ee7186566bd5e", hex.EncodeToString(address))
+}
+
+func TestGenerateProtocolAddress_Layout(t *testing.T) {
+	creator := []byte("creator_______________________ab")
+
+	address, err := GenerateProtocolAddress(creator, 42, []byte{7, 3})
+	require.Nil(t, err)
+	require.Equal(t, protocolAddressLength, len(address))
+	// 8 zero bytes, then the VM type
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 7, 3}, address[:10])
+	// the shard is kept from the creator
+	require.Equal(t, []byte("ab"), address[protocolAddressLength-shardIdentifierLength:])
+
+	otherNonceAddress, _ := GenerateProtocolAddress(creator, 43, []byte{7, 3})
+	require.NotEqual(t, address, otherNonceAddress)
+	require.Equal(t, address[:10], otherNonceAddress[:10])
+	require.Equal(t, address[30:], otherNonceAddress[30:])
+}
+
+func TestGenerateProtocolAddress_InvalidArguments(t *testing.T) {
+	_, err := GenerateProtocolAddress([]byte("short"), 0, wasmVMType)
+	require.Equal(t, ErrInvalidAddressLength, err)
+
+	_, err = GenerateProtocolAddress([]byte("creator_______________________ab"), 0, []byte{5})
+	require.Equal(t, ErrInvalidVMTypeLength, err)
+}
@@ -0,0 +1,85 @@
+package scenarioexec
+
+import (
+	"fmt"
+	"strings"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+)
+
+// CheckStateMismatch is a difference between the expected and the actual state of an account.
+// An empty Field means that the account itself is missing or unexpected.
+type CheckStateMismatch struct {
+	Account string
+	Field   string
+	Want    string
+	Have    string
+}
+
+// CheckStateError reports all the mismatches found when checking accounts,
+// along with account checks that match the actual state, to replace the failing ones.
+type CheckStateError struct {
+	Mismatches             []*CheckStateMismatch
+	SuggestedCheckAccounts *mj.CheckAccounts
+}
+
+// Diff lists the mismatches, grouped by account.
+func (e *CheckStateError) Diff() string {
+	var sb strings.Builder
+	lastAccount := ""
+	for i, mismatch := range e.Mismatches {
+		if len(mismatch.Field) == 0 {
+			sb.WriteString(fmt.Sprintf("\n  account \"%s\": want %s, have %s",
+				mismatch.Account, mismatch.Want, mismatch.Have))
+			lastAccount = ""
+			continue
+		}
+		if i == 0 || mismatch.Account != lastAccount {
+			sb.WriteString(fmt.Sprintf("\n  account \"%s\":", mismatch.Account))
+			lastAccount = mismatch.Account
+		}
+		sb.WriteString(fmt.Sprintf("\n    %s: want %s, have %s",
+			mismatch.Field, mismatch.Want, mismatch.Have))
+	}
+	return strings.TrimPrefix(sb.String(), "\n")
+}
+
+// SuggestedCheckState yields the JSON of a checkState step that matches the actual state.
+func (e *CheckStateError) SuggestedCheckState() string {
+	return mjwrite.StepToJSONString(&mj.CheckStateStep{
+		CheckAccounts: e.SuggestedCheckAccounts,
+		StateRootHash: mj.JSONCheckBytesUnspecified(),
+	})
+}
+
+// Error yields the diff, followed by the suggested checkState step.
+func (e *CheckStateError) Error() string {
+	plural := "es"
+	if len(e.Mismatches) == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("checkState failed with %d mismatch%s:\n%s\nsuggested checkState step:\n%s",
+		len(e.Mismatches),
+		plural,
+		e.Diff(),
+		e.SuggestedCheckState())
+}
+
+// checkStateDiff collects the mismatches of a checkState step
+type checkStateDiff struct {
+	mismatches []*CheckStateMismatch
+}
+
+func (d *checkStateDiff) add(account string, field string, want string, have string) {
+	d.mismatches = append(d.mismatches, &CheckStateMismatch{
+		Account: account,
+		Field:   field,
+		Want:    want,
+		Have:    have,
+	})
+}
+
+func quoted(value string) string {
+	return fmt.Sprintf("\"%s\"", value)
+}
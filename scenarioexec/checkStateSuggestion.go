@@ -0,0 +1,253 @@
+package scenarioexec
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	worldmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
+	er "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/reconstructor"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+)
+
+// suggestCheckAccounts yields account checks that match the actual state.
+// Expectations that hold are kept as they are, including "*" and predicates, failing ones get the actual values.
+// Missing accounts are left out, unexpected ones are added.
+func (ae *VMTestExecutor) suggestCheckAccounts(checkAccounts *mj.CheckAccounts) (*mj.CheckAccounts, error) {
+	suggested := &mj.CheckAccounts{
+		MoreAccountsAllowed: checkAccounts.MoreAccountsAllowed,
+	}
+
+	for _, expectedAcct := range checkAccounts.Accounts {
+		matchingAcct, isMatch := ae.World.AcctMap[string(expectedAcct.Address.Value)]
+		if !isMatch {
+			continue
+		}
+		suggestedAcct, err := ae.suggestCheckAccount(expectedAcct, matchingAcct)
+		if err != nil {
+			return nil, err
+		}
+		suggested.Accounts = append(suggested.Accounts, suggestedAcct)
+	}
+
+	if !checkAccounts.MoreAccountsAllowed {
+		for _, unexpectedAcct := range ae.unexpectedAccounts(checkAccounts) {
+			// the code of new contracts is left unchecked, rather than written in hex
+			newAcct := &mj.CheckAccount{
+				Address: mj.NewJSONBytesFromString(
+					unexpectedAcct.Address,
+					ae.exprReconstructor.ReconstructExpression(unexpectedAcct.Address, er.AddressHint)),
+				Nonce:         checkUint64Value(unexpectedAcct.Nonce),
+				Balance:       checkBigIntValue(unexpectedAcct.Balance),
+				Username:      mj.JSONCheckBytesUnspecified(),
+				Code:          mj.JSONCheckBytesStar(),
+				Owner:         mj.JSONCheckBytesUnspecified(),
+				AsyncCallData: mj.JSONCheckBytesUnspecified(),
+			}
+			suggestedAcct, err := ae.suggestCheckAccount(newAcct, unexpectedAcct)
+			if err != nil {
+				return nil, err
+			}
+			suggested.Accounts = append(suggested.Accounts, suggestedAcct)
+		}
+	}
+
+	return suggested, nil
+}
+
+func (ae *VMTestExecutor) suggestCheckAccount(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) (*mj.CheckAccount, error) {
+	suggestedAcct := *expectedAcct
+
+	if !suggestedAcct.Nonce.Check(matchingAcct.Nonce) {
+		suggestedAcct.Nonce = checkUint64Value(matchingAcct.Nonce)
+	}
+	if !suggestedAcct.Balance.Check(matchingAcct.Balance) {
+		suggestedAcct.Balance = checkBigIntValue(matchingAcct.Balance)
+	}
+	if !suggestedAcct.Username.Check(matchingAcct.Username) {
+		suggestedAcct.Username = ae.checkBytesValue(matchingAcct.Username, er.StrHint)
+	}
+	if !suggestedAcct.Code.Check(matchingAcct.Code) {
+		suggestedAcct.Code = ae.checkBytesValue(matchingAcct.Code, er.CodeHint)
+	}
+	if !suggestedAcct.AsyncCallData.IsUnspecified() &&
+		!suggestedAcct.AsyncCallData.Check([]byte(matchingAcct.AsyncCallData)) {
+		suggestedAcct.AsyncCallData = ae.checkBytesValue([]byte(matchingAcct.AsyncCallData), er.StrHint)
+	}
+
+	if !suggestedAcct.IgnoreStorage {
+		suggestedAcct.CheckStorage = ae.suggestStorage(expectedAcct, matchingAcct)
+	}
+
+	if !suggestedAcct.IgnoreESDT {
+		var err error
+		suggestedAcct.CheckESDTData, err = ae.suggestESDT(expectedAcct, matchingAcct)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &suggestedAcct, nil
+}
+
+func (ae *VMTestExecutor) suggestStorage(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) []*mj.CheckStorageKeyValuePair {
+	var suggested []*mj.CheckStorageKeyValuePair
+	expected := expectedStorage(expectedAcct)
+	for _, key := range storageCheckKeys(expectedAcct, matchingAcct) {
+		have := matchingAcct.StorageValue(key)
+		stkvp, specified := expected[key]
+		switch {
+		case specified && stkvp.CheckValue.Check(have):
+			suggested = append(suggested, stkvp)
+		case len(have) == 0:
+			// an empty value is the same as a missing key
+		case specified || !expectedAcct.MoreStorageAllowed:
+			suggested = append(suggested, &mj.CheckStorageKeyValuePair{
+				Key: mj.NewJSONBytesFromString(
+					[]byte(key),
+					ae.exprReconstructor.ReconstructExpression([]byte(key), er.NoHint)),
+				CheckValue: ae.checkBytesValue(have, er.NoHint),
+			})
+		}
+	}
+	return suggested
+}
+
+func (ae *VMTestExecutor) suggestESDT(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) ([]*mj.CheckESDTData, error) {
+	accountTokens, err := matchingAcct.GetFullMockESDTData()
+	if err != nil {
+		return nil, err
+	}
+
+	var suggested []*mj.CheckESDTData
+	expectedTokens := getExpectedTokens(expectedAcct)
+	for _, tokenName := range tokenCheckNames(expectedAcct, accountTokens) {
+		expectedToken := expectedTokens[tokenName]
+		if expectedToken == nil {
+			expectedToken = &mj.CheckESDTData{
+				TokenIdentifier: mj.NewJSONBytesFromString(
+					[]byte(tokenName),
+					ae.exprReconstructor.ReconstructExpression([]byte(tokenName), er.StrHint)),
+			}
+		}
+		accountToken := accountTokens[tokenName]
+		if accountToken == nil {
+			accountToken = &worldmock.MockESDTData{
+				TokenIdentifier: []byte(tokenName),
+			}
+		}
+
+		suggestedToken := ae.suggestToken(expectedToken, accountToken)
+		if len(suggestedToken.Instances) > 0 || len(suggestedToken.Roles) > 0 || len(suggestedToken.LastNonce.Original) > 0 {
+			suggested = append(suggested, suggestedToken)
+		}
+	}
+
+	return suggested, nil
+}
+
+func (ae *VMTestExecutor) suggestToken(expectedToken *mj.CheckESDTData, accountToken *worldmock.MockESDTData) *mj.CheckESDTData {
+	suggestedToken := *expectedToken
+
+	accountInstances := make(map[uint64]*esdt.ESDigitalToken)
+	for _, accountInstance := range accountToken.Instances {
+		accountInstances[accountInstance.TokenMetaData.Nonce] = accountInstance
+	}
+
+	// expected instances first, in the order of the scenario, then the other instances, by nonce
+	suggestedToken.Instances = nil
+	expectedNonces := make(map[uint64]bool)
+	for _, expectedInstance := range expectedToken.Instances {
+		nonce := expectedInstance.Nonce.Value
+		expectedNonces[nonce] = true
+		accountInstance := accountInstances[nonce]
+		if accountInstance == nil {
+			// a missing instance has balance 0, in which case there is nothing to check
+			if expectedInstance.Balance.Check(big.NewInt(0)) {
+				suggestedToken.Instances = append(suggestedToken.Instances, expectedInstance)
+			}
+			continue
+		}
+		suggestedToken.Instances = append(suggestedToken.Instances, ae.suggestTokenInstance(expectedInstance, accountInstance))
+	}
+	for _, nonce := range tokenInstanceNonces(&mj.CheckESDTData{}, accountToken) {
+		if expectedNonces[nonce] {
+			continue
+		}
+		instance := mj.NewCheckESDTInstance()
+		instance.Balance = checkBigIntValue(accountInstances[nonce].Value)
+		if nonce > 0 {
+			instance.Nonce = checkUint64Value(nonce)
+		}
+		suggestedToken.Instances = append(suggestedToken.Instances, instance)
+	}
+
+	if !suggestedToken.LastNonce.Check(accountToken.LastNonce) {
+		suggestedToken.LastNonce = checkUint64Value(accountToken.LastNonce)
+	}
+	if len(suggestedToken.LastNonce.Original) > 0 {
+		// the compact form, which leaves out the nonce, cannot hold the last nonce
+		for _, instance := range suggestedToken.Instances {
+			if len(instance.Nonce.Original) == 0 {
+				instance.Nonce = checkUint64Value(instance.Nonce.Value)
+			}
+		}
+	}
+
+	suggestedToken.Roles = nil
+	for _, role := range accountToken.Roles {
+		suggestedToken.Roles = append(suggestedToken.Roles, string(role))
+	}
+
+	return &suggestedToken
+}
+
+func (ae *VMTestExecutor) suggestTokenInstance(expectedInstance *mj.CheckESDTInstance, accountInstance *esdt.ESDigitalToken) *mj.CheckESDTInstance {
+	suggestedInstance := *expectedInstance
+	metaData := accountInstance.TokenMetaData
+
+	if !suggestedInstance.Balance.Check(accountInstance.Value) {
+		suggestedInstance.Balance = checkBigIntValue(accountInstance.Value)
+	}
+	if !suggestedInstance.Creator.IsUnspecified() && !suggestedInstance.Creator.Check(metaData.Creator) {
+		suggestedInstance.Creator = ae.checkBytesValue(metaData.Creator, er.AddressHint)
+	}
+	if !suggestedInstance.Royalties.IsUnspecified() && !suggestedInstance.Royalties.Check(uint64(metaData.Royalties)) {
+		suggestedInstance.Royalties = checkUint64Value(uint64(metaData.Royalties))
+	}
+	if !suggestedInstance.Hash.IsUnspecified() && !suggestedInstance.Hash.Check(metaData.Hash) {
+		suggestedInstance.Hash = ae.checkBytesValue(metaData.Hash, er.NoHint)
+	}
+	uri := instanceUri(accountInstance)
+	if !suggestedInstance.Uri.IsUnspecified() && !suggestedInstance.Uri.Check(uri) {
+		suggestedInstance.Uri = ae.checkBytesValue(uri, er.StrHint)
+	}
+	if !suggestedInstance.Attributes.IsUnspecified() && !suggestedInstance.Attributes.Check(metaData.Attributes) {
+		suggestedInstance.Attributes = ae.checkBytesValue(metaData.Attributes, er.StrHint)
+	}
+
+	return &suggestedInstance
+}
+
+func (ae *VMTestExecutor) checkBytesValue(value []byte, hint er.ExprReconstructorHint) mj.JSONCheckBytes {
+	if hint == er.CodeHint {
+		// the code is written in full, not shortened like in error messages
+		return mj.JSONCheckBytesReconstructed(value, "0x"+hex.EncodeToString(value))
+	}
+	return mj.JSONCheckBytesReconstructed(value, ae.exprReconstructor.ReconstructExpression(value, hint))
+}
+
+func checkUint64Value(value uint64) mj.JSONCheckUint64 {
+	return mj.JSONCheckUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
+
+func checkBigIntValue(value *big.Int) mj.JSONCheckBigInt {
+	return mj.JSONCheckBigInt{
+		Value:    big.NewInt(0).Set(value),
+		Original: value.String(),
+	}
+}
@@ -0,0 +1,79 @@
+package scenarioexec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/hostCore"
+)
+
+// ErrUnknownFlag signals that an activation epoch was given for a flag the VM doesn't use
+var ErrUnknownFlag = errors.New("unknown VM flag")
+
+// SetEnableEpochs sets the activation epoch of the VM flags, flags not
+// mentioned are active from genesis. The enableEpochs declared in scenarios
+// are ignored afterwards, so this also works as an override from the CLI.
+func (ae *VMTestExecutor) SetEnableEpochs(activationEpochs map[core.EnableEpochFlag]uint32) error {
+	for flag := range activationEpochs {
+		if !isVMFlag(flag) {
+			return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+		}
+	}
+
+	ae.activationEpochs = activationEpochs
+	ae.scenEnableEpochsLoaded = true
+	ae.applyActivationEpochs()
+	return nil
+}
+
+// DisableFlags keeps the given VM flags inactive, whatever their activation epochs,
+// from the scenarios or from SetEnableEpochs. The other flags keep their activation epochs.
+func (ae *VMTestExecutor) DisableFlags(flags []core.EnableEpochFlag) error {
+	for _, flag := range flags {
+		if !isVMFlag(flag) {
+			return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+		}
+	}
+
+	ae.disabledFlags = flags
+	ae.applyActivationEpochs()
+	return nil
+}
+
+func (ae *VMTestExecutor) applyActivationEpochs() {
+	activationEpochs := make(map[core.EnableEpochFlag]uint32, len(ae.activationEpochs)+len(ae.disabledFlags))
+	for flag, epoch := range ae.activationEpochs {
+		activationEpochs[flag] = epoch
+	}
+	for _, flag := range ae.disabledFlags {
+		activationEpochs[flag] = math.MaxUint32
+	}
+	ae.enableEpochsHandler.SetActivationEpochs(activationEpochs)
+}
+
+// setScenarioEnableEpochs only changes the activation epochs once, like the gas schedule,
+// this prevents subsequent enableEpochs declarations in externalSteps to overwrite
+func (ae *VMTestExecutor) setScenarioEnableEpochs(flagActivations []*mj.FlagActivation) error {
+	if ae.scenEnableEpochsLoaded {
+		return nil
+	}
+
+	activationEpochs := make(map[core.EnableEpochFlag]uint32, len(flagActivations))
+	for _, flagActivation := range flagActivations {
+		activationEpochs[core.EnableEpochFlag(flagActivation.Flag)] = uint32(flagActivation.Epoch.Value)
+	}
+
+	return ae.SetEnableEpochs(activationEpochs)
+}
+
+func isVMFlag(flag core.EnableEpochFlag) bool {
+	for _, vmFlag := range hostCore.AllFlags() {
+		if flag == vmFlag {
+			return true
+		}
+	}
+	return false
+}
@@ -7,7 +7,6 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmi "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/config"
-	"github.com/multiversx/mx-chain-vm-v1_3-go/mock"
 	worldhook "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
 	gasSchedules "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec/gasSchedules"
 	mc "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/controller"
@@ -23,14 +22,27 @@ var log = logger.GetOrCreate("vm/scenarios")
 // TestVMType is the VM type argument we use in tests.
 var TestVMType = []byte{0, 0}
 
+// DefaultBlockGasLimit is the block gas limit the VM starts with, block steps can set their own.
+const DefaultBlockGasLimit = uint64(10000000)
+
+// VMFactory creates the VM of a VMTestExecutor, on top of its mock world.
+type VMFactory func(world *worldhook.MockWorld, hostParameters *vmhost.VMHostParameters) (vmi.VMExecutionHandler, error)
+
 // VMTestExecutor parses, interprets and executes both .test.json tests and .scen.json scenarios with VM.
 type VMTestExecutor struct {
-	World                 *worldhook.MockWorld
-	vm                    vmi.VMExecutionHandler
-	checkGas              bool
-	scenGasScheduleLoaded bool
-	fileResolver          fr.FileResolver
-	exprReconstructor     er.ExprReconstructor
+	World                  *worldhook.MockWorld
+	vm                     vmi.VMExecutionHandler
+	currentBlockGas        *blockGas
+	enableEpochsHandler    *worldhook.EnableEpochsHandler
+	activationEpochs       map[core.EnableEpochFlag]uint32
+	disabledFlags          []core.EnableEpochFlag
+	checkGas               bool
+	scenGasScheduleLoaded  bool
+	scenEnableEpochsLoaded bool
+	fileResolver           fr.FileResolver
+	exprReconstructor      er.ExprReconstructor
+	txOutputObserver       func(step *mj.TxStep, output *vmi.VMOutput)
+	goldenMode             bool
 }
 
 var _ mc.TestExecutor = (*VMTestExecutor)(nil)
@@ -38,6 +50,12 @@ var _ mc.ScenarioExecutor = (*VMTestExecutor)(nil)
 
 // NewVMTestExecutor prepares a new VMTestExecutor instance.
 func NewVMTestExecutor() (*VMTestExecutor, error) {
+	return NewVMTestExecutorWithVMFactory(newInProcessVM)
+}
+
+// NewVMTestExecutorWithVMFactory prepares a new VMTestExecutor instance,
+// whose VM is not the in-process host, but the one created by the given factory.
+func NewVMTestExecutorWithVMFactory(vmFactory VMFactory) (*VMTestExecutor, error) {
 	world := worldhook.NewMockWorld()
 
 	gasScheduleMap := config.MakeGasMapForTests()
@@ -46,33 +64,53 @@ func NewVMTestExecutor() (*VMTestExecutor, error) {
 		return nil, err
 	}
 
-	blockGasLimit := uint64(10000000)
-	vm, err := hostCore.NewVMHost(world, &vmhost.VMHostParameters{
+	// all flags are enabled from genesis, unless configured otherwise
+	enableEpochsHandler := worldhook.NewEnableEpochsHandler(world)
+
+	vm, err := vmFactory(world, &vmhost.VMHostParameters{
 		VMType:               TestVMType,
-		BlockGasLimit:        blockGasLimit,
+		BlockGasLimit:        DefaultBlockGasLimit,
 		GasSchedule:          gasScheduleMap,
 		BuiltInFuncContainer: world.BuiltinFuncs.Container,
 		ProtectedKeyPrefix:   []byte(core.ProtectedKeyPrefix),
-		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
-			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == hostCore.SCDeployFlag || flag == hostCore.AheadOfTimeGasUsageFlag || flag == hostCore.RepairCallbackFlag || flag == hostCore.BuiltInFunctionsFlag
-			},
-		},
+		EnableEpochsHandler:  enableEpochsHandler,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &VMTestExecutor{
-		World:                 world,
-		vm:                    vm,
-		checkGas:              true,
-		scenGasScheduleLoaded: false,
-		fileResolver:          nil,
-		exprReconstructor:     er.ExprReconstructor{},
+		World:                  world,
+		vm:                     vm,
+		enableEpochsHandler:    enableEpochsHandler,
+		checkGas:               true,
+		scenGasScheduleLoaded:  false,
+		scenEnableEpochsLoaded: false,
+		fileResolver:           nil,
+		exprReconstructor:      er.ExprReconstructor{},
 	}, nil
 }
 
+func newInProcessVM(world *worldhook.MockWorld, hostParameters *vmhost.VMHostParameters) (vmi.VMExecutionHandler, error) {
+	return hostCore.NewVMHost(world, hostParameters)
+}
+
+// SetTxOutputObserver sets a function to be called with the output of each executed transaction.
+func (ae *VMTestExecutor) SetTxOutputObserver(observer func(step *mj.TxStep, output *vmi.VMOutput)) {
+	ae.txOutputObserver = observer
+}
+
+// SetGoldenMode sets whether failing checkState steps get updated to the actual state, instead of failing.
+// The scenario runner then saves the updated scenario files.
+func (ae *VMTestExecutor) SetGoldenMode(goldenMode bool) {
+	ae.goldenMode = goldenMode
+}
+
+// IsGoldenMode yields true if failing checkState steps get updated to the actual state, instead of failing.
+func (ae *VMTestExecutor) IsGoldenMode() bool {
+	return ae.goldenMode
+}
+
 // GetVM yields a reference to the VMExecutionHandler used.
 func (ae *VMTestExecutor) GetVM() vmi.VMExecutionHandler {
 	return ae.vm
@@ -15,9 +15,7 @@ func (ae *VMTestExecutor) Reset() {
 
 // ExecuteScenario executes an individual test.
 func (ae *VMTestExecutor) ExecuteScenario(scenario *mj.Scenario, fileResolver fr.FileResolver) error {
-	ae.fileResolver = fileResolver
-	ae.checkGas = scenario.CheckGas
-	err := ae.SetScenariosGasSchedule(scenario.GasSchedule)
+	err := ae.PrepareScenario(scenario, fileResolver)
 	if err != nil {
 		return err
 	}
@@ -35,6 +33,18 @@ func (ae *VMTestExecutor) ExecuteScenario(scenario *mj.Scenario, fileResolver fr
 	return nil
 }
 
+// PrepareScenario applies the scenario configuration (gas, enabled epochs), without executing any step.
+// Callers that execute the steps one by one, with ExecuteStep, need to call it first.
+func (ae *VMTestExecutor) PrepareScenario(scenario *mj.Scenario, fileResolver fr.FileResolver) error {
+	ae.fileResolver = fileResolver
+	ae.checkGas = scenario.CheckGas
+	err := ae.SetScenariosGasSchedule(scenario.GasSchedule)
+	if err != nil {
+		return err
+	}
+	return ae.setScenarioEnableEpochs(scenario.EnableEpochs)
+}
+
 // ExecuteStep executes an individual step from a scenario.
 func (ae *VMTestExecutor) ExecuteStep(generalStep mj.Step) error {
 	err := error(nil)
@@ -50,6 +60,8 @@ func (ae *VMTestExecutor) ExecuteStep(generalStep mj.Step) error {
 		_, err = ae.ExecuteTxStep(step)
 	case *mj.DumpStateStep:
 		err = ae.DumpWorld()
+	case *mj.BlockStep:
+		err = ae.ExecuteBlockStep(step)
 	}
 
 	return err
@@ -63,11 +75,14 @@ func (ae *VMTestExecutor) ExecuteExternalStep(step *mj.ExternalStepsStep) error
 	}
 
 	fileResolverBackup := ae.fileResolver
-	clonedFileResolver := ae.fileResolver.Clone()
-	externalStepsRunner := mc.NewScenarioRunner(ae, clonedFileResolver)
+	contents, externalFileResolver, err := ae.fileResolver.ResolveExternalSteps(step.Path, step.ParametersMap())
+	if err != nil {
+		return err
+	}
+	externalStepsRunner := mc.NewScenarioRunner(ae, externalFileResolver)
 
 	extAbsPth := ae.fileResolver.ResolveAbsolutePath(step.Path)
-	err := externalStepsRunner.RunSingleJSONScenario(extAbsPth)
+	err = externalStepsRunner.RunJSONScenarioContents(extAbsPth, contents)
 	if err != nil {
 		return err
 	}
@@ -95,6 +110,7 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 		}
 
 		ae.World.AcctMap.PutAccount(worldAccount)
+		convertTokenGlobalSettings(scenAccount, ae.World)
 	}
 
 	// replace block info
@@ -110,6 +126,13 @@ func (ae *VMTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 	addressMocksToAdd := convertNewAddressMocks(step.NewAddressMocks)
 	ae.World.NewAddressMocks = append(ae.World.NewAddressMocks, addressMocksToAdd...)
 
+	switch step.NewAddressDerivation {
+	case mj.NewAddressDerivationMock:
+		ae.World.ProtocolNewAddresses = false
+	case mj.NewAddressDerivationProtocol:
+		ae.World.ProtocolNewAddresses = true
+	}
+
 	return nil
 }
 
@@ -120,10 +143,18 @@ func (ae *VMTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error)
 		log.Trace("ExecuteTxStep", "comment", step.Comment)
 	}
 
+	err := ae.useBlockGas(step)
+	if err != nil {
+		return nil, err
+	}
+
 	output, err := ae.executeTx(step.TxIdent, step.Tx)
 	if err != nil {
 		return nil, err
 	}
+	if ae.txOutputObserver != nil {
+		ae.txOutputObserver(step, output)
+	}
 
 	// check results
 	if step.ExpectedResult != nil {
@@ -135,3 +166,17 @@ func (ae *VMTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error)
 
 	return output, nil
 }
+
+// ExecuteQuery executes an scQuery against the current state.
+// Unlike an scQuery step, it leaves the state unchanged, whatever the contract does.
+func (ae *VMTestExecutor) ExecuteQuery(txIdent string, tx *mj.Transaction) (*vmi.VMOutput, error) {
+	accountsBackup := ae.World.AcctMap.Clone()
+	stateRootHashBackup := ae.World.StateRootHash
+	defer func() {
+		ae.World.AcctMap = accountsBackup
+		ae.World.StateRootHash = stateRootHashBackup
+	}()
+
+	tx.Type = mj.ScQuery
+	return ae.executeTx(txIdent, tx)
+}
@@ -0,0 +1,213 @@
+package scenarioexec
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmi "github.com/multiversx/mx-chain-vm-common-go"
+	mc "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/controller"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/hostCore"
+)
+
+// TxOutputDigest holds the parts of a tx output compared by the flag matrix.
+type TxOutputDigest struct {
+	TxIdent       string
+	ReturnCode    vmi.ReturnCode
+	ReturnMessage string
+	ReturnData    [][]byte
+	GasRemaining  uint64
+}
+
+// FlagMatrixRun is the outcome of running a scenario with some flags disabled.
+type FlagMatrixRun struct {
+	DisabledFlags []core.EnableEpochFlag
+	Err           error
+	TxOutputs     []*TxOutputDigest
+}
+
+// FlagMatrixDifference describes how a run differs from the run with all flags enabled.
+type FlagMatrixDifference struct {
+	DisabledFlags []core.EnableEpochFlag
+	Details       []string
+}
+
+// FlagMatrixReport is the result of running a scenario under all flag combinations.
+type FlagMatrixReport struct {
+	ScenarioPath string
+	NumRuns      int
+	Baseline     *FlagMatrixRun
+	Differences  []*FlagMatrixDifference
+}
+
+// RunScenarioFlagMatrix runs a scenario file once for every combination of
+// enabled and disabled VM flags, each time with a fresh executor, and reports
+// the combinations whose outcome differs from the one with all flags enabled.
+// Enabled flags keep the activation epochs from the scenario, active from genesis
+// unless the scenario says otherwise, disabled flags are never active.
+func RunScenarioFlagMatrix(scenarioPath string) (*FlagMatrixReport, error) {
+	flags := hostCore.AllFlags()
+
+	baseline, err := runScenarioWithDisabledFlags(scenarioPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FlagMatrixReport{
+		ScenarioPath: scenarioPath,
+		NumRuns:      1,
+		Baseline:     baseline,
+	}
+	for combination := 1; combination < 1<<len(flags); combination++ {
+		var disabledFlags []core.EnableEpochFlag
+		for i, flag := range flags {
+			if combination&(1<<i) != 0 {
+				disabledFlags = append(disabledFlags, flag)
+			}
+		}
+
+		run, err := runScenarioWithDisabledFlags(scenarioPath, disabledFlags)
+		if err != nil {
+			return nil, err
+		}
+		report.NumRuns++
+
+		details := compareFlagMatrixRuns(baseline, run)
+		if len(details) > 0 {
+			report.Differences = append(report.Differences, &FlagMatrixDifference{
+				DisabledFlags: disabledFlags,
+				Details:       details,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+func runScenarioWithDisabledFlags(scenarioPath string, disabledFlags []core.EnableEpochFlag) (*FlagMatrixRun, error) {
+	executor, err := NewVMTestExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	err = executor.DisableFlags(disabledFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &FlagMatrixRun{
+		DisabledFlags: disabledFlags,
+	}
+	executor.txOutputObserver = func(step *mj.TxStep, output *vmi.VMOutput) {
+		run.TxOutputs = append(run.TxOutputs, &TxOutputDigest{
+			TxIdent:       step.TxIdent,
+			ReturnCode:    output.ReturnCode,
+			ReturnMessage: output.ReturnMessage,
+			ReturnData:    output.ReturnData,
+			GasRemaining:  output.GasRemaining,
+		})
+	}
+
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	run.Err = runner.RunSingleJSONScenario(scenarioPath)
+	return run, nil
+}
+
+func compareFlagMatrixRuns(baseline *FlagMatrixRun, run *FlagMatrixRun) []string {
+	var details []string
+
+	baselineErr := errorToString(baseline.Err)
+	runErr := errorToString(run.Err)
+	if baselineErr != runErr {
+		details = append(details, fmt.Sprintf("result: %s -> %s", baselineErr, runErr))
+	}
+
+	numTxs := len(baseline.TxOutputs)
+	if len(run.TxOutputs) != numTxs {
+		details = append(details, fmt.Sprintf("number of executed txs: %d -> %d", numTxs, len(run.TxOutputs)))
+		if len(run.TxOutputs) < numTxs {
+			numTxs = len(run.TxOutputs)
+		}
+	}
+
+	for i := 0; i < numTxs; i++ {
+		details = append(details, compareTxOutputDigests(baseline.TxOutputs[i], run.TxOutputs[i])...)
+	}
+
+	return details
+}
+
+func compareTxOutputDigests(expected *TxOutputDigest, actual *TxOutputDigest) []string {
+	var details []string
+	if expected.ReturnCode != actual.ReturnCode {
+		details = append(details, fmt.Sprintf("tx %s: return code: %s -> %s",
+			expected.TxIdent, expected.ReturnCode, actual.ReturnCode))
+	}
+	if expected.ReturnMessage != actual.ReturnMessage {
+		details = append(details, fmt.Sprintf("tx %s: return message: \"%s\" -> \"%s\"",
+			expected.TxIdent, expected.ReturnMessage, actual.ReturnMessage))
+	}
+	if !equalReturnData(expected.ReturnData, actual.ReturnData) {
+		details = append(details, fmt.Sprintf("tx %s: return data: %s -> %s",
+			expected.TxIdent, formatReturnData(expected.ReturnData), formatReturnData(actual.ReturnData)))
+	}
+	if expected.GasRemaining != actual.GasRemaining {
+		details = append(details, fmt.Sprintf("tx %s: gas remaining: %d -> %d",
+			expected.TxIdent, expected.GasRemaining, actual.GasRemaining))
+	}
+	return details
+}
+
+func equalReturnData(first [][]byte, second [][]byte) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i := range first {
+		if !bytes.Equal(first[i], second[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatReturnData(returnData [][]byte) string {
+	formatted := make([]string, len(returnData))
+	for i, data := range returnData {
+		formatted[i] = fmt.Sprintf("0x%x", data)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+func errorToString(err error) string {
+	if err == nil {
+		return "success"
+	}
+	return fmt.Sprintf("error \"%s\"", err.Error())
+}
+
+// String renders the report, one line per difference.
+func (report *FlagMatrixReport) String() string {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "%s: %d flag combinations, %d differ from all flags enabled (%s)\n",
+		report.ScenarioPath,
+		report.NumRuns,
+		len(report.Differences),
+		errorToString(report.Baseline.Err))
+	for _, difference := range report.Differences {
+		_, _ = fmt.Fprintf(sb, "  disabled %s:\n", formatFlags(difference.DisabledFlags))
+		for _, detail := range difference.Details {
+			_, _ = fmt.Fprintf(sb, "    %s\n", detail)
+		}
+	}
+	return sb.String()
+}
+
+func formatFlags(flags []core.EnableEpochFlag) string {
+	formatted := make([]string, len(flags))
+	for i, flag := range flags {
+		formatted[i] = string(flag)
+	}
+	return strings.Join(formatted, ", ")
+}
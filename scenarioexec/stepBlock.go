@@ -0,0 +1,127 @@
+package scenarioexec
+
+import (
+	"errors"
+	"fmt"
+
+	worldmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+)
+
+// ErrBlockGasLimitExceeded signals that the transactions of a block require more gas than the block gas limit
+var ErrBlockGasLimitExceeded = errors.New("block gas limit exceeded")
+
+// ErrNestedBlockStep signals a block step inside another block, e.g. in external steps run in a block
+var ErrNestedBlockStep = errors.New("block steps cannot be nested")
+
+// blockGas counts the gas of the transactions in the current block,
+// including those in the external steps run in the block
+type blockGas struct {
+	blockNonce  uint64
+	gasLimit    uint64
+	gasProvided uint64
+}
+
+// ExecuteBlockStep executes all the steps of a block, under the same block info.
+// At the end of the block, it becomes the previous block.
+func (ae *VMTestExecutor) ExecuteBlockStep(step *mj.BlockStep) error {
+	if len(step.Comment) > 0 {
+		log.Trace("BlockStep", "comment", step.Comment)
+	}
+	if ae.currentBlockGas != nil {
+		return ErrNestedBlockStep
+	}
+
+	blockInfo := convertBlockInfo(step.CurrentBlockInfo)
+	if blockInfo == nil {
+		blockInfo = ae.nextBlockInfo()
+	}
+	err := ae.World.StartBlock(blockInfo)
+	if err != nil {
+		return err
+	}
+	log.Trace("BlockStep", "nonce", blockInfo.BlockNonce, "round", blockInfo.BlockRound)
+
+	metering := ae.vmMetering()
+	hasBlockGasLimit := len(step.BlockGasLimit.Original) > 0
+	if metering != nil && hasBlockGasLimit {
+		previousBlockGasLimit := metering.BlockGasLimit()
+		metering.SetBlockGasLimit(step.BlockGasLimit.Value)
+		defer metering.SetBlockGasLimit(previousBlockGasLimit)
+	}
+
+	blockGasLimit := DefaultBlockGasLimit
+	switch {
+	case metering != nil:
+		blockGasLimit = metering.BlockGasLimit()
+	case hasBlockGasLimit:
+		blockGasLimit = step.BlockGasLimit.Value
+	}
+	ae.currentBlockGas = &blockGas{
+		blockNonce: blockInfo.BlockNonce,
+		gasLimit:   blockGasLimit,
+	}
+	defer func() {
+		ae.currentBlockGas = nil
+	}()
+
+	for _, generalStep := range step.Steps {
+		err = ae.ExecuteStep(generalStep)
+		if err != nil {
+			return err
+		}
+	}
+
+	ae.World.EndBlock()
+	return nil
+}
+
+// useBlockGas adds the gas limit of a transaction to the gas of the current block, if there is one.
+// The protocol only includes a tx in a block if its entire gas limit still fits.
+func (ae *VMTestExecutor) useBlockGas(step *mj.TxStep) error {
+	if ae.currentBlockGas == nil || !step.Tx.Type.HasGas() {
+		return nil
+	}
+
+	ae.currentBlockGas.gasProvided += step.Tx.GasLimit.Value
+	if ae.currentBlockGas.gasProvided > ae.currentBlockGas.gasLimit {
+		return fmt.Errorf("%w: tx %s brings the gas of block %d to %d, limit is %d",
+			ErrBlockGasLimitExceeded,
+			step.TxIdent,
+			ae.currentBlockGas.blockNonce,
+			ae.currentBlockGas.gasProvided,
+			ae.currentBlockGas.gasLimit)
+	}
+	return nil
+}
+
+// vmMetering yields the metering context of the VM, which holds the block gas limit,
+// or nil if the VM does not run in process
+func (ae *VMTestExecutor) vmMetering() vmhost.MeteringContext {
+	host, isHost := ae.vm.(vmhost.VMHost)
+	if !isHost {
+		return nil
+	}
+	return host.Metering()
+}
+
+// nextBlockInfo is used when a block doesn't specify its block info:
+// it follows the current block, with the same epoch and timestamp.
+func (ae *VMTestExecutor) nextBlockInfo() *worldmock.BlockInfo {
+	lastBlockInfo := ae.World.CurrentBlockInfo
+	if lastBlockInfo == nil {
+		lastBlockInfo = ae.World.PreviousBlockInfo
+	}
+	if lastBlockInfo == nil {
+		return &worldmock.BlockInfo{
+			RandomSeed: worldmock.GenerateRandomSeed(nil, 0),
+		}
+	}
+
+	blockInfo := *lastBlockInfo
+	blockInfo.BlockNonce++
+	blockInfo.BlockRound++
+	blockInfo.RandomSeed = worldmock.GenerateRandomSeed(lastBlockInfo.RandomSeed, blockInfo.BlockNonce)
+	return &blockInfo
+}
@@ -2,8 +2,10 @@ package scenarioexec
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -16,176 +18,236 @@ import (
 )
 
 // ExecuteCheckStateStep executes a CheckStateStep defined by the current scenario.
+// In golden mode, failing expectations are replaced with the actual state, instead of failing.
 func (ae *VMTestExecutor) ExecuteCheckStateStep(step *mj.CheckStateStep) error {
 	if len(step.Comment) > 0 {
 		log.Trace("CheckStateStep", "comment", step.Comment)
 	}
 
-	return ae.checkAccounts(step.CheckAccounts)
+	err := ae.checkAccounts(step.CheckAccounts)
+	if err != nil {
+		checkStateErr, isCheckStateErr := err.(*CheckStateError)
+		if !ae.goldenMode || !isCheckStateErr {
+			return err
+		}
+		log.Info("golden mode: updated checkState step",
+			"mismatches", len(checkStateErr.Mismatches),
+			"diff", "\n"+checkStateErr.Diff())
+		step.CheckAccounts = checkStateErr.SuggestedCheckAccounts
+	}
+
+	return ae.checkStateRootHash(step)
 }
 
-func (ae *VMTestExecutor) checkAccounts(checkAccounts *mj.CheckAccounts) error {
-	if !checkAccounts.MoreAccountsAllowed {
-		for worldAcctAddr := range ae.World.AcctMap {
-			postAcctMatch := mj.FindCheckAccount(checkAccounts.Accounts, []byte(worldAcctAddr))
-			if postAcctMatch == nil && !bytes.Equal(vmcommon.SystemAccountAddress, []byte(worldAcctAddr)) {
-				return fmt.Errorf("unexpected account address: %s",
-					ae.exprReconstructor.Reconstruct(
-						[]byte(worldAcctAddr),
-						er.AddressHint))
-			}
+func (ae *VMTestExecutor) checkStateRootHash(step *mj.CheckStateStep) error {
+	expectedRootHash := step.StateRootHash
+	if expectedRootHash.IsUnspecified() {
+		return nil
+	}
+
+	rootHash, err := ae.World.AccountsAdapter.RootHash()
+	if err != nil {
+		return err
+	}
+
+	if !expectedRootHash.Check(rootHash) {
+		if ae.goldenMode {
+			log.Info("golden mode: updated checkState state root hash")
+			step.StateRootHash = mj.JSONCheckBytesReconstructed(rootHash, "0x"+hex.EncodeToString(rootHash))
+			return nil
 		}
+		return fmt.Errorf("bad state root hash. Want: %s. Have: \"0x%s\"",
+			oj.JSONString(expectedRootHash.Original),
+			hex.EncodeToString(rootHash))
 	}
 
+	return nil
+}
+
+// checkAccounts compares all accounts to the expected ones and,
+// if anything is different, returns a CheckStateError listing all the mismatches
+func (ae *VMTestExecutor) checkAccounts(checkAccounts *mj.CheckAccounts) error {
+	diff := &checkStateDiff{}
+
 	for _, expectedAcct := range checkAccounts.Accounts {
 		matchingAcct, isMatch := ae.World.AcctMap[string(expectedAcct.Address.Value)]
 		if !isMatch {
-			return fmt.Errorf("account %s expected but not found after running test",
-				expectedAcct.Address.Original)
+			diff.add(expectedAcct.Address.Original, "", "present", "absent")
+			continue
 		}
 
-		if !bytes.Equal(matchingAcct.Address, expectedAcct.Address.Value) {
-			return fmt.Errorf("bad account address %s",
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.Address,
-					er.AddressHint))
+		ae.checkAccountFields(diff, expectedAcct, matchingAcct)
+		ae.checkAccountStorage(diff, expectedAcct, matchingAcct)
+		err := ae.checkAccountESDT(diff, expectedAcct, matchingAcct)
+		if err != nil {
+			return err
 		}
+	}
 
-		if !expectedAcct.Nonce.Check(matchingAcct.Nonce) {
-			return fmt.Errorf("bad account nonce. Account: %s. Want: \"%s\". Have: \"%d\"",
-				expectedAcct.Address.Original,
-				expectedAcct.Nonce.Original,
-				matchingAcct.Nonce)
+	if !checkAccounts.MoreAccountsAllowed {
+		for _, unexpectedAcct := range ae.unexpectedAccounts(checkAccounts) {
+			diff.add(
+				ae.exprReconstructor.Reconstruct(unexpectedAcct.Address, er.AddressHint),
+				"", "absent", "present")
 		}
+	}
 
-		if !expectedAcct.Balance.Check(matchingAcct.Balance) {
-			return fmt.Errorf("bad account balance. Account: %s. Want: \"%s\". Have: \"%s\"",
-				expectedAcct.Address.Original,
-				expectedAcct.Balance.Original,
-				ae.exprReconstructor.ReconstructFromBigInt(matchingAcct.Balance))
-		}
+	if len(diff.mismatches) == 0 {
+		return nil
+	}
 
-		if !expectedAcct.Username.Check(matchingAcct.Username) {
-			return fmt.Errorf("bad account username. Account: %s. Want: %s. Have: \"%s\"",
-				expectedAcct.Address.Original,
-				oj.JSONString(expectedAcct.Username.Original),
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.Username,
-					er.StrHint))
-		}
+	suggestedCheckAccounts, err := ae.suggestCheckAccounts(checkAccounts)
+	if err != nil {
+		return err
+	}
+	return &CheckStateError{
+		Mismatches:             diff.mismatches,
+		SuggestedCheckAccounts: suggestedCheckAccounts,
+	}
+}
 
-		if !expectedAcct.Code.Check(matchingAcct.Code) {
-			return fmt.Errorf("bad account code. Account: %s. Want: %s. Have: \"%s\"",
-				expectedAcct.Address.Original,
-				oj.JSONString(expectedAcct.Code.Original),
-				ae.exprReconstructor.Reconstruct(
-					matchingAcct.Code,
-					er.CodeHint))
+// unexpectedAccounts yields the accounts in the world that are not checked, sorted by address
+func (ae *VMTestExecutor) unexpectedAccounts(checkAccounts *mj.CheckAccounts) []*worldmock.Account {
+	var unexpectedAccts []*worldmock.Account
+	for worldAcctAddr, worldAcct := range ae.World.AcctMap {
+		if bytes.Equal(vmcommon.SystemAccountAddress, []byte(worldAcctAddr)) {
+			continue
 		}
-
-		// currently ignoring asyncCallData that is unspecified in the json
-		if !expectedAcct.AsyncCallData.IsUnspecified() &&
-			!expectedAcct.AsyncCallData.Check([]byte(matchingAcct.AsyncCallData)) {
-			return fmt.Errorf("bad async call data. Account: %s. Want: [%s]. Have: [%s]",
-				expectedAcct.Address.Original,
-				expectedAcct.AsyncCallData.Original,
-				matchingAcct.AsyncCallData)
+		if mj.FindCheckAccount(checkAccounts.Accounts, []byte(worldAcctAddr)) == nil {
+			unexpectedAccts = append(unexpectedAccts, worldAcct)
 		}
+	}
+	sort.Slice(unexpectedAccts, func(i, j int) bool {
+		return bytes.Compare(unexpectedAccts[i].Address, unexpectedAccts[j].Address) < 0
+	})
+	return unexpectedAccts
+}
 
-		err := ae.checkAccountStorage(expectedAcct, matchingAcct)
-		if err != nil {
-			return err
-		}
+func (ae *VMTestExecutor) checkAccountFields(diff *checkStateDiff, expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) {
+	accountAddress := expectedAcct.Address.Original
 
-		err = ae.checkAccountESDT(expectedAcct, matchingAcct)
-		if err != nil {
-			return err
-		}
+	if !bytes.Equal(matchingAcct.Address, expectedAcct.Address.Value) {
+		diff.add(accountAddress, "address",
+			quoted(accountAddress),
+			quoted(ae.exprReconstructor.Reconstruct(matchingAcct.Address, er.AddressHint)))
 	}
 
-	return nil
-}
+	if !expectedAcct.Nonce.Check(matchingAcct.Nonce) {
+		diff.add(accountAddress, "nonce",
+			quoted(expectedAcct.Nonce.Original)+predicateDescription(expectedAcct.Nonce.Predicate),
+			quoted(fmt.Sprintf("%d", matchingAcct.Nonce)))
+	}
 
-func (ae *VMTestExecutor) checkAccountStorage(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
-	if expectedAcct.IgnoreStorage {
-		return nil
+	if !expectedAcct.Balance.Check(matchingAcct.Balance) {
+		diff.add(accountAddress, "balance",
+			quoted(expectedAcct.Balance.Original)+predicateDescription(expectedAcct.Balance.Predicate),
+			quoted(ae.exprReconstructor.ReconstructFromBigInt(matchingAcct.Balance)))
 	}
 
-	expectedStorage := make(map[string]mj.JSONCheckBytes)
-	for _, stkvp := range expectedAcct.CheckStorage {
-		expectedStorage[string(stkvp.Key.Value)] = stkvp.CheckValue
+	if !expectedAcct.Username.Check(matchingAcct.Username) {
+		diff.add(accountAddress, "username",
+			oj.JSONString(expectedAcct.Username.Original),
+			quoted(ae.exprReconstructor.ReconstructExpression(matchingAcct.Username, er.StrHint)))
 	}
 
-	allKeys := make(map[string]bool)
-	for k := range expectedStorage {
-		allKeys[k] = true
+	if !expectedAcct.Code.Check(matchingAcct.Code) {
+		diff.add(accountAddress, "code",
+			oj.JSONString(expectedAcct.Code.Original),
+			quoted(ae.exprReconstructor.Reconstruct(matchingAcct.Code, er.CodeHint)))
 	}
-	for k := range matchingAcct.Storage {
-		allKeys[k] = true
+
+	// currently ignoring asyncCallData that is unspecified in the json
+	if !expectedAcct.AsyncCallData.IsUnspecified() &&
+		!expectedAcct.AsyncCallData.Check([]byte(matchingAcct.AsyncCallData)) {
+		diff.add(accountAddress, "asyncCallData",
+			oj.JSONString(expectedAcct.AsyncCallData.Original),
+			quoted(matchingAcct.AsyncCallData))
 	}
-	storageError := ""
-	for k := range allKeys {
-		// ignore all reserved keys
-		if strings.HasPrefix(k, core.ProtectedKeyPrefix) {
-			continue
+}
+
+// storageCheckKeys yields the expected storage keys, in the order of the scenario,
+// followed by the other keys of the account, sorted; reserved keys are left out
+func storageCheckKeys(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) []string {
+	var keys []string
+	expectedKeys := make(map[string]bool)
+	for _, stkvp := range expectedAcct.CheckStorage {
+		key := string(stkvp.Key.Value)
+		if !expectedKeys[key] && !strings.HasPrefix(key, core.ProtectedKeyPrefix) {
+			keys = append(keys, key)
 		}
+		expectedKeys[key] = true
+	}
 
-		want, specified := expectedStorage[k]
-		if !specified {
-			if expectedAcct.MoreStorageAllowed {
-				// if `"+": ""` was written in the test, any unspecified entries are allowed,
-				// which is equivalent to treating them all as "*".
-				want = mj.JSONCheckBytesStar()
-			} else {
-				// otherwise, by default, any unexpected storage key leads to a test failure
-				want = mj.JSONCheckBytesUnspecified()
-			}
+	var otherKeys []string
+	for key := range matchingAcct.Storage {
+		if !expectedKeys[key] && !strings.HasPrefix(key, core.ProtectedKeyPrefix) {
+			otherKeys = append(otherKeys, key)
+		}
+	}
+	sort.Strings(otherKeys)
+
+	return append(keys, otherKeys...)
+}
+
+func expectedStorage(expectedAcct *mj.CheckAccount) map[string]*mj.CheckStorageKeyValuePair {
+	expected := make(map[string]*mj.CheckStorageKeyValuePair)
+	for _, stkvp := range expectedAcct.CheckStorage {
+		expected[string(stkvp.Key.Value)] = stkvp
+	}
+	return expected
+}
+
+func (ae *VMTestExecutor) checkAccountStorage(diff *checkStateDiff, expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) {
+	if expectedAcct.IgnoreStorage {
+		return
+	}
+
+	expected := expectedStorage(expectedAcct)
+	for _, key := range storageCheckKeys(expectedAcct, matchingAcct) {
+		var want mj.JSONCheckBytes
+		keyExpression := ae.exprReconstructor.ReconstructExpression([]byte(key), er.NoHint)
+		stkvp, specified := expected[key]
+		if specified {
+			want = stkvp.CheckValue
+			keyExpression = stkvp.Key.Original
+		} else if expectedAcct.MoreStorageAllowed {
+			// if `"+": ""` was written in the test, any unspecified entries are allowed,
+			// which is equivalent to treating them all as "*".
+			want = mj.JSONCheckBytesStar()
+		} else {
+			// otherwise, by default, any unexpected storage key leads to a test failure
+			want = mj.JSONCheckBytesUnspecified()
 		}
-		have := matchingAcct.StorageValue(k)
+		have := matchingAcct.StorageValue(key)
 
 		if !want.Check(have) {
-			storageError += fmt.Sprintf(
-				"\n  for key %s: Want: %s. Have: \"%s\"",
-				ae.exprReconstructor.Reconstruct([]byte(k), er.NoHint),
-				oj.JSONString(want.Original),
-				ae.exprReconstructor.Reconstruct(have, er.NoHint))
+			diff.add(expectedAcct.Address.Original,
+				fmt.Sprintf("storage %s", quoted(keyExpression)),
+				oj.JSONString(want.Original)+predicateDescription(want.Predicate),
+				quoted(ae.exprReconstructor.ReconstructExpression(have, er.NoHint)))
 		}
 	}
-	if len(storageError) > 0 {
-		return fmt.Errorf("wrong account storage for account \"%s\":%s",
-			expectedAcct.Address.Original, storageError)
-	}
-	return nil
 }
 
-func (ae *VMTestExecutor) checkAccountESDT(expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
+func (ae *VMTestExecutor) checkAccountESDT(diff *checkStateDiff, expectedAcct *mj.CheckAccount, matchingAcct *worldmock.Account) error {
 	if expectedAcct.IgnoreESDT {
 		return nil
 	}
 
-	accountAddress := expectedAcct.Address.Original
-	expectedTokens := getExpectedTokens(expectedAcct)
 	accountTokens, err := matchingAcct.GetFullMockESDTData()
 	if err != nil {
 		return err
 	}
 
-	allTokenNames := make(map[string]bool)
-	for tokenName := range expectedTokens {
-		allTokenNames[tokenName] = true
-	}
-	for tokenName := range accountTokens {
-		allTokenNames[tokenName] = true
-	}
-	var errors []error
-	for tokenName := range allTokenNames {
+	expectedTokens := getExpectedTokens(expectedAcct)
+	for _, tokenName := range tokenCheckNames(expectedAcct, accountTokens) {
 		expectedToken := expectedTokens[tokenName]
 		accountToken := accountTokens[tokenName]
 		if expectedToken == nil {
 			expectedToken = &mj.CheckESDTData{
 				TokenIdentifier: mj.JSONBytesFromString{
 					Value:    []byte(tokenName),
-					Original: ae.exprReconstructor.Reconstruct([]byte(tokenName), er.StrHint),
+					Original: ae.exprReconstructor.ReconstructExpression([]byte(tokenName), er.StrHint),
 				},
 				Instances: []*mj.CheckESDTInstance{},
 				LastNonce: mj.JSONCheckUint64{Value: 0, Original: ""},
@@ -200,12 +262,7 @@ func (ae *VMTestExecutor) checkAccountESDT(expectedAcct *mj.CheckAccount, matchi
 			}
 		}
 
-		errors = append(errors, ae.checkTokenState(accountAddress, tokenName, expectedToken, accountToken)...)
-	}
-
-	errorString := makeErrorString(errors)
-	if len(errorString) > 0 {
-		return fmt.Errorf("mismatch for account \"%s\":%s", accountAddress, errorString)
+		ae.checkTokenState(diff, expectedAcct.Address.Original, expectedToken, accountToken)
 	}
 
 	return nil
@@ -221,52 +278,85 @@ func getExpectedTokens(expectedAcct *mj.CheckAccount) map[string]*mj.CheckESDTDa
 	return expectedTokens
 }
 
+// tokenCheckNames yields the expected tokens, in the order of the scenario,
+// followed by the other tokens of the account, sorted
+func tokenCheckNames(expectedAcct *mj.CheckAccount, accountTokens map[string]*worldmock.MockESDTData) []string {
+	var tokenNames []string
+	expectedTokenNames := make(map[string]bool)
+	for _, expectedTokenData := range expectedAcct.CheckESDTData {
+		tokenName := string(expectedTokenData.TokenIdentifier.Value)
+		if !expectedTokenNames[tokenName] {
+			tokenNames = append(tokenNames, tokenName)
+		}
+		expectedTokenNames[tokenName] = true
+	}
+
+	var otherTokenNames []string
+	for tokenName := range accountTokens {
+		if !expectedTokenNames[tokenName] {
+			otherTokenNames = append(otherTokenNames, tokenName)
+		}
+	}
+	sort.Strings(otherTokenNames)
+
+	return append(tokenNames, otherTokenNames...)
+}
+
 func (ae *VMTestExecutor) checkTokenState(
+	diff *checkStateDiff,
 	accountAddress string,
-	tokenName string,
 	expectedToken *mj.CheckESDTData,
-	accountToken *worldmock.MockESDTData) []error {
+	accountToken *worldmock.MockESDTData) {
 
-	var errors []error
+	tokenField := fmt.Sprintf("esdt %s", quoted(expectedToken.TokenIdentifier.Original))
 
-	errors = append(errors, ae.checkTokenInstances(accountAddress, tokenName, expectedToken, accountToken)...)
+	ae.checkTokenInstances(diff, accountAddress, tokenField, expectedToken, accountToken)
 
 	if !expectedToken.LastNonce.Check(accountToken.LastNonce) {
-		errors = append(errors, fmt.Errorf("bad account ESDT last nonce. Account: %s. Token: %s. Want: \"%s\". Have: %d",
-			accountAddress,
-			tokenName,
-			expectedToken.LastNonce.Original,
-			accountToken.LastNonce))
+		diff.add(accountAddress, tokenField+" lastNonce",
+			quoted(expectedToken.LastNonce.Original)+predicateDescription(expectedToken.LastNonce.Predicate),
+			quoted(fmt.Sprintf("%d", accountToken.LastNonce)))
 	}
 
-	errors = append(errors, checkTokenRoles(accountAddress, tokenName, expectedToken, accountToken)...)
+	checkTokenRoles(diff, accountAddress, tokenField, expectedToken, accountToken)
+}
+
+func tokenInstanceNonces(expectedToken *mj.CheckESDTData, accountToken *worldmock.MockESDTData) []uint64 {
+	allNonces := make(map[uint64]bool)
+	for _, expectedInstance := range expectedToken.Instances {
+		allNonces[expectedInstance.Nonce.Value] = true
+	}
+	for _, accountInstance := range accountToken.Instances {
+		allNonces[accountInstance.TokenMetaData.Nonce] = true
+	}
 
-	return errors
+	nonces := make([]uint64, 0, len(allNonces))
+	for nonce := range allNonces {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+	return nonces
 }
 
 func (ae *VMTestExecutor) checkTokenInstances(
+	diff *checkStateDiff,
 	accountAddress string,
-	tokenName string,
+	tokenField string,
 	expectedToken *mj.CheckESDTData,
-	accountToken *worldmock.MockESDTData) []error {
+	accountToken *worldmock.MockESDTData) {
 
-	var errors []error
-
-	allNonces := make(map[uint64]bool)
 	expectedInstances := make(map[uint64]*mj.CheckESDTInstance)
 	accountInstances := make(map[uint64]*esdt.ESDigitalToken)
 	for _, expectedInstance := range expectedToken.Instances {
-		nonce := expectedInstance.Nonce.Value
-		allNonces[nonce] = true
-		expectedInstances[nonce] = expectedInstance
+		expectedInstances[expectedInstance.Nonce.Value] = expectedInstance
 	}
 	for _, accountInstance := range accountToken.Instances {
-		nonce := accountInstance.TokenMetaData.Nonce
-		allNonces[nonce] = true
-		accountInstances[nonce] = accountInstance
+		accountInstances[accountInstance.TokenMetaData.Nonce] = accountInstance
 	}
 
-	for nonce := range allNonces {
+	for _, nonce := range tokenInstanceNonces(expectedToken, accountToken) {
 		expectedInstance := expectedInstances[nonce]
 		accountInstance := accountInstances[nonce]
 
@@ -279,132 +369,101 @@ func (ae *VMTestExecutor) checkTokenInstances(
 			accountInstance = &esdt.ESDigitalToken{
 				Value: big.NewInt(0),
 				TokenMetaData: &esdt.MetaData{
-					Name:  []byte(tokenName),
 					Nonce: nonce,
 				},
 			}
 		}
 
+		instanceField := fmt.Sprintf("%s nonce %d", tokenField, nonce)
 		if !expectedInstance.Balance.Check(accountInstance.Value) {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: Bad balance. Want: \"%s\". Have: \"%d\"",
-				tokenName,
-				nonce,
-				expectedInstance.Balance.Original,
-				accountInstance.Value))
+			diff.add(accountAddress, instanceField+" balance",
+				quoted(expectedInstance.Balance.Original)+predicateDescription(expectedInstance.Balance.Predicate),
+				quoted(ae.exprReconstructor.ReconstructFromBigInt(accountInstance.Value)))
 		}
 		if !expectedInstance.Creator.IsUnspecified() &&
 			!expectedInstance.Creator.Check(accountInstance.TokenMetaData.Creator) {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: Bad creator. Want: %s. Have: \"%s\"",
-				tokenName,
-				nonce,
+			diff.add(accountAddress, instanceField+" creator",
 				oj.JSONString(expectedInstance.Creator.Original),
-				ae.exprReconstructor.Reconstruct(
-					accountInstance.TokenMetaData.Creator,
-					er.AddressHint)))
+				quoted(ae.exprReconstructor.ReconstructExpression(accountInstance.TokenMetaData.Creator, er.AddressHint)))
 		}
 		if !expectedInstance.Royalties.IsUnspecified() &&
 			!expectedInstance.Royalties.Check(uint64(accountInstance.TokenMetaData.Royalties)) {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: Bad royalties. Want: \"%s\". Have: \"%s\"",
-				tokenName,
-				nonce,
-				expectedInstance.Royalties.Original,
-				ae.exprReconstructor.ReconstructFromUint64(
-					uint64(accountInstance.TokenMetaData.Royalties))))
+			diff.add(accountAddress, instanceField+" royalties",
+				quoted(expectedInstance.Royalties.Original)+predicateDescription(expectedInstance.Royalties.Predicate),
+				quoted(ae.exprReconstructor.ReconstructFromUint64(uint64(accountInstance.TokenMetaData.Royalties))))
 		}
 		if !expectedInstance.Hash.IsUnspecified() &&
 			!expectedInstance.Hash.Check(accountInstance.TokenMetaData.Hash) {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: Bad hash. Want: %s. Have: %s",
-				tokenName,
-				nonce,
+			diff.add(accountAddress, instanceField+" hash",
 				oj.JSONString(expectedInstance.Hash.Original),
-				ae.exprReconstructor.Reconstruct(
-					accountInstance.TokenMetaData.Hash,
-					er.NoHint)))
+				quoted(ae.exprReconstructor.ReconstructExpression(accountInstance.TokenMetaData.Hash, er.NoHint)))
 		}
 		if len(accountInstance.TokenMetaData.URIs) > 1 {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: More than one URI currently not supported",
-				tokenName,
-				nonce))
-		}
-		var actualUri []byte
-		if len(accountInstance.TokenMetaData.URIs) == 1 {
-			actualUri = accountInstance.TokenMetaData.URIs[0]
+			diff.add(accountAddress, instanceField+" uri",
+				"at most one URI",
+				fmt.Sprintf("%d URIs, currently not supported", len(accountInstance.TokenMetaData.URIs)))
 		}
+		actualUri := instanceUri(accountInstance)
 		if !expectedInstance.Uri.IsUnspecified() &&
 			!expectedInstance.Uri.Check(actualUri) {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: Bad URI. Want: %s. Have: \"%s\"",
-				tokenName,
-				nonce,
+			diff.add(accountAddress, instanceField+" uri",
 				oj.JSONString(expectedInstance.Uri.Original),
-				ae.exprReconstructor.Reconstruct(
-					actualUri,
-					er.StrHint)))
+				quoted(ae.exprReconstructor.ReconstructExpression(actualUri, er.StrHint)))
 		}
 		if !expectedInstance.Attributes.IsUnspecified() &&
 			!expectedInstance.Attributes.Check(accountInstance.TokenMetaData.Attributes) {
-			errors = append(errors, fmt.Errorf(
-				"for token: %s, nonce: %d: Bad attributes. Want: %s. Have: \"%s\"",
-				tokenName,
-				nonce,
+			diff.add(accountAddress, instanceField+" attributes",
 				oj.JSONString(expectedInstance.Attributes.Original),
-				ae.exprReconstructor.Reconstruct(
-					accountInstance.TokenMetaData.Attributes,
-					er.StrHint)))
+				quoted(ae.exprReconstructor.ReconstructExpression(accountInstance.TokenMetaData.Attributes, er.StrHint)))
 		}
-
 	}
+}
 
-	return errors
+func instanceUri(accountInstance *esdt.ESDigitalToken) []byte {
+	if len(accountInstance.TokenMetaData.URIs) == 1 {
+		return accountInstance.TokenMetaData.URIs[0]
+	}
+	return nil
 }
 
 func checkTokenRoles(
+	diff *checkStateDiff,
 	accountAddress string,
-	tokenName string,
+	tokenField string,
 	expectedToken *mj.CheckESDTData,
-	accountToken *worldmock.MockESDTData) []error {
-
-	var errors []error
+	accountToken *worldmock.MockESDTData) {
 
-	allRoles := make(map[string]bool)
+	var allRoles []string
 	expectedRoles := make(map[string]bool)
 	accountRoles := make(map[string]bool)
 
 	for _, expectedRole := range expectedToken.Roles {
-		allRoles[expectedRole] = true
+		if !expectedRoles[expectedRole] {
+			allRoles = append(allRoles, expectedRole)
+		}
 		expectedRoles[expectedRole] = true
 	}
 	for _, accountRole := range accountToken.Roles {
-		allRoles[string(accountRole)] = true
+		if !expectedRoles[string(accountRole)] && !accountRoles[string(accountRole)] {
+			allRoles = append(allRoles, string(accountRole))
+		}
 		accountRoles[string(accountRole)] = true
 	}
-	for role := range allRoles {
+	for _, role := range allRoles {
+		roleField := fmt.Sprintf("%s role %s", tokenField, role)
 		if !expectedRoles[role] {
-			errors = append(errors, fmt.Errorf("unexpected ESDT role. Account: %s. Token: %s. Role: %s",
-				accountAddress,
-				tokenName,
-				role))
+			diff.add(accountAddress, roleField, "absent", "present")
 		}
 		if !accountRoles[role] {
-			errors = append(errors, fmt.Errorf("missing ESDT role. Account: %s. Token: %s. Role: %s",
-				accountAddress,
-				tokenName,
-				role))
+			diff.add(accountAddress, roleField, "present", "absent")
 		}
 	}
-
-	return errors
 }
 
-func makeErrorString(errors []error) string {
-	errorString := ""
-	for _, err := range errors {
-		errorString += "\n  " + err.Error()
+// predicateDescription spells out check values such as ">=1000" in error messages
+func predicateDescription(predicate *mj.ValuePredicate) string {
+	if predicate == nil {
+		return ""
 	}
-	return errorString
+	return fmt.Sprintf(" (%s)", predicate.String())
 }
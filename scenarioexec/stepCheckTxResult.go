@@ -28,6 +28,12 @@ func (ae *VMTestExecutor) checkTxResults(
 	}
 
 	// check result
+	if blResult.TypedOut != nil && !checkBytesListMatches(blResult.Out, output.ReturnData) {
+		return fmt.Errorf("result mismatch. Tx %s. Want: %s. Have: %s",
+			txIndex,
+			blResult.TypedOut.String(),
+			blResult.TypedOut.DescribeResults(output.ReturnData))
+	}
 	if len(output.ReturnData) != len(blResult.Out) {
 		return fmt.Errorf("result length mismatch. Tx %s. Want: %s. Have: %s",
 			txIndex,
@@ -45,16 +51,17 @@ func (ae *VMTestExecutor) checkTxResults(
 
 	// check refund
 	if !blResult.Refund.Check(output.GasRefund) {
-		return fmt.Errorf("result gas refund mismatch. Tx %s. Want: %s. Have: 0x%x",
-			txIndex, blResult.Refund.Original, output.GasRefund)
+		return fmt.Errorf("result gas refund mismatch. Tx %s. Want: %s%s. Have: 0x%x",
+			txIndex, blResult.Refund.Original, predicateDescription(blResult.Refund.Predicate), output.GasRefund)
 	}
 
 	// check gas
 	// unlike other checks, if unspecified the remaining gas check is ignored
 	if checkGas && !blResult.Gas.IsUnspecified() && !blResult.Gas.Check(output.GasRemaining) {
-		return fmt.Errorf("result gas mismatch. Tx %s. Want: %s. Got: %d (0x%x)",
+		return fmt.Errorf("result gas mismatch. Tx %s. Want: %s%s. Got: %d (0x%x)",
 			txIndex,
 			blResult.Gas.Original,
+			predicateDescription(blResult.Gas.Predicate),
 			output.GasRemaining,
 			output.GasRemaining)
 	}
@@ -123,3 +130,15 @@ func checkBytesListPretty(jcbs []mj.JSONCheckBytes) string {
 	}
 	return str + "]"
 }
+
+func checkBytesListMatches(expected []mj.JSONCheckBytes, results [][]byte) bool {
+	if len(expected) != len(results) {
+		return false
+	}
+	for i, expectedItem := range expected {
+		if !expectedItem.Check(results[i]) {
+			return false
+		}
+	}
+	return true
+}
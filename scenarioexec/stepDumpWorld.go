@@ -149,6 +149,16 @@ func (ae *VMTestExecutor) convertMockAccountToScenarioFormat(account *worldmock.
 	}, nil
 }
 
+// ScenarioAccount yields an account of the MockWorld in scenario format, with reconstructed expressions,
+// or nil if the account does not exist.
+func (ae *VMTestExecutor) ScenarioAccount(address []byte) (*mj.Account, error) {
+	account := ae.World.AcctMap.GetAccount(address)
+	if account == nil {
+		return nil, nil
+	}
+	return ae.convertMockAccountToScenarioFormat(account)
+}
+
 // DumpWorld prints the state of the MockWorld to stdout.
 func (ae *VMTestExecutor) DumpWorld() error {
 	fmt.Print("world state dump:\n")
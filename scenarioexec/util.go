@@ -80,11 +80,32 @@ func convertAccount(testAcct *mj.Account, world *worldmock.MockWorld) (*worldmoc
 		if err != nil {
 			return nil, err
 		}
+		if isFrozen {
+			// also covers accounts not holding the token, which must not receive it
+			err = account.SetTokenFrozen(tokenName, isFrozen)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return account, nil
 }
 
+// convertTokenGlobalSettings saves the token-level flags (paused, limited transfer)
+// specified in the ESDT blocks of a "setState" account to the world.
+func convertTokenGlobalSettings(testAcct *mj.Account, world *worldmock.MockWorld) {
+	for _, scenESDTData := range testAcct.ESDTData {
+		tokenName := scenESDTData.TokenIdentifier.Value
+		if len(scenESDTData.Paused.Original) > 0 {
+			world.SetTokenPaused(tokenName, scenESDTData.Paused.Value > 0)
+		}
+		if len(scenESDTData.LimitedTransfer.Original) > 0 {
+			world.SetTokenLimitedTransfer(tokenName, scenESDTData.LimitedTransfer.Value > 0)
+		}
+	}
+}
+
 func validateSetStateAccount(scenAccount *mj.Account, converted *worldmock.Account) error {
 	err := converted.Validate()
 	if err != nil {
@@ -6,11 +6,13 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"strings"
+
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 )
 
 // RunAllJSONScenariosInDirectory walks directory, parses and prepares all json scenarios,
 // then calls scenarioExecutor for each of them.
+// The suffix matches the scenarios in all formats, e.g. ".scen.json" also matches ".scen.yaml" and ".scen.json5".
 func (r *ScenarioRunner) RunAllJSONScenariosInDirectory(
 	generalTestPath string,
 	specificTestPath string,
@@ -21,7 +23,7 @@ func (r *ScenarioRunner) RunAllJSONScenariosInDirectory(
 	var nrPassed, nrFailed, nrSkipped int
 
 	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
-		if strings.HasSuffix(testFilePath, allowedSuffix) {
+		if oj.HasSuffixInAnyFormat(testFilePath, allowedSuffix) {
 			fmt.Printf("Scenario: %s ... ", shortenTestPath(testFilePath, generalTestPath))
 			if isExcluded(excludedFilePatterns, testFilePath, generalTestPath) {
 				nrSkipped++
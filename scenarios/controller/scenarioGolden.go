@@ -0,0 +1,132 @@
+package scencontroller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+func (r *ScenarioRunner) isGoldenMode() bool {
+	goldenModeExecutor, isGoldenModeExecutor := r.Executor.(GoldenModeExecutor)
+	return isGoldenModeExecutor && goldenModeExecutor.IsGoldenMode()
+}
+
+// runGoldenScenario runs a scenario in golden mode, then writes the checkState steps updated by the executor back to the file.
+// The file is written in its canonical form, as serialized from the ordered JSON model, with the updated steps replaced.
+// Files that are not in canonical form, e.g. hand-formatted or with comments, are refused,
+// since writing them would change more than the updated steps.
+func (r *ScenarioRunner) runGoldenScenario(contextPath string, byteValue []byte, scenario *mj.Scenario) error {
+	checkStatesBefore := make(map[*mj.CheckStateStep]string)
+	collectCheckStates(scenario.Steps, checkStatesBefore)
+
+	err := r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+	if err != nil {
+		return err
+	}
+
+	format := oj.FormatFromPath(contextPath)
+	scenarioOJ, err := oj.ParseOrderedFormat(byteValue, format)
+	if err != nil {
+		return err
+	}
+	scenarioMap, isMap := scenarioOJ.(*oj.OJsonMap)
+	if !isMap {
+		return errors.New("scenario is not a map")
+	}
+	canonicalContents := oj.FormattedString(scenarioOJ, format)
+	var updatedSteps []string
+	for _, kvp := range scenarioMap.OrderedKV {
+		if kvp.Key == "steps" {
+			updatedSteps, err = updateCheckStates(kvp.Value, scenario.Steps, checkStatesBefore, "steps")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(updatedSteps) == 0 {
+		return nil
+	}
+
+	return saveGoldenScenario(contextPath, byteValue, canonicalContents, oj.FormattedString(scenarioOJ, format), updatedSteps)
+}
+
+// collectCheckStates serializes all checkState steps, including those in blocks, to tell later which of them were updated.
+// The checkState steps of external steps are not collected here,
+// every external steps file is run, and rewritten, as a golden scenario of its own.
+func collectCheckStates(steps []mj.Step, checkStates map[*mj.CheckStateStep]string) {
+	for _, generalStep := range steps {
+		switch step := generalStep.(type) {
+		case *mj.CheckStateStep:
+			checkStates[step] = mjwrite.StepToJSONString(step)
+		case *mj.BlockStep:
+			collectCheckStates(step.Steps, checkStates)
+		}
+	}
+}
+
+// updateCheckStates replaces the updated checkState steps in the steps list, as parsed from the file,
+// and returns where they are in the file, e.g. "steps[2].steps[0]" for the first step of a block.
+// The parser yields exactly one step for every item in the list.
+func updateCheckStates(stepsOJ oj.OJsonObject, steps []mj.Step, checkStatesBefore map[*mj.CheckStateStep]string, stepsPath string) ([]string, error) {
+	stepsList, isList := stepsOJ.(*oj.OJsonList)
+	if !isList || len(*stepsList) != len(steps) {
+		return nil, errors.New("steps do not match the scenario file")
+	}
+
+	var updatedSteps []string
+	for i, generalStep := range steps {
+		stepPath := fmt.Sprintf("%s[%d]", stepsPath, i)
+		switch step := generalStep.(type) {
+		case *mj.CheckStateStep:
+			if mjwrite.StepToJSONString(step) != checkStatesBefore[step] {
+				(*stepsList)[i] = mjwrite.StepToOrderedJSON(step)
+				updatedSteps = append(updatedSteps, stepPath)
+			}
+		case *mj.BlockStep:
+			blockMap, isMap := (*stepsList)[i].(*oj.OJsonMap)
+			if !isMap {
+				return nil, errors.New("block step is not a map")
+			}
+			for _, kvp := range blockMap.OrderedKV {
+				if kvp.Key == "steps" {
+					blockUpdatedSteps, err := updateCheckStates(kvp.Value, step.Steps, checkStatesBefore, stepPath+".steps")
+					if err != nil {
+						return nil, err
+					}
+					updatedSteps = append(updatedSteps, blockUpdatedSteps...)
+				}
+			}
+		}
+	}
+	return updatedSteps, nil
+}
+
+// saveGoldenScenario writes a scenario whose expectations were updated in golden mode back to its file,
+// unless what ran is not the file as it is, e.g. external steps with their placeholders replaced,
+// or the file is not in canonical form. In that case, the error lists the checkState steps that could not be updated.
+func saveGoldenScenario(contextPath string, contentsRun []byte, canonicalContents string, updatedContents string, updatedSteps []string) error {
+	fileContents, err := ioutil.ReadFile(contextPath)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(fileContents, contentsRun) {
+		return fmt.Errorf("cannot update the checkState steps %s in %s, because its placeholders were replaced by parameters",
+			strings.Join(updatedSteps, ", "), contextPath)
+	}
+	if withFinalNewline(canonicalContents) != withFinalNewline(string(fileContents)) {
+		return fmt.Errorf("cannot update the checkState steps %s in %s, because it is not in canonical form, e.g. it has comments, and rewriting it would change more than these steps",
+			strings.Join(updatedSteps, ", "), contextPath)
+	}
+
+	return ioutil.WriteFile(contextPath, []byte(withFinalNewline(updatedContents)), 0644)
+}
+
+func withFinalNewline(contents string) string {
+	return strings.TrimSuffix(contents, "\n") + "\n"
+}
@@ -7,6 +7,7 @@ import (
 
 	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 )
 
 // RunSingleJSONScenario parses and prepares test, then calls testCallback.
@@ -33,19 +34,30 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 		return err
 	}
 
+	return r.RunJSONScenarioContents(contextPath, byteValue)
+}
+
+// RunJSONScenarioContents parses and runs a scenario that was already loaded,
+// e.g. external steps with their placeholders replaced.
+// The path is used to resolve relative paths, and its extension selects the format (JSON, JSON5 or YAML).
+func (r *ScenarioRunner) RunJSONScenarioContents(contextPath string, byteValue []byte) error {
 	r.Parser.ExprInterpreter.FileResolver.SetContext(contextPath)
-	scenario, parseErr := r.Parser.ParseScenarioFile(byteValue)
+	scenario, parseErr := r.Parser.ParseScenarioFileInFormat(byteValue, oj.FormatFromPath(contextPath))
 	if parseErr != nil {
 		return parseErr
 	}
 
+	if r.isGoldenMode() {
+		return r.runGoldenScenario(contextPath, byteValue, scenario)
+	}
+
 	return r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
 }
 
 // tool to modify scenarios
 // use with extreme caution
 func saveModifiedScenario(toPath string, scenario *mj.Scenario) {
-	resultJSON := mjwrite.ScenarioToJSONString(scenario)
+	resultJSON := mjwrite.ScenarioToString(scenario, oj.FormatFromPath(toPath))
 
 	err := os.MkdirAll(filepath.Dir(toPath), os.ModePerm)
 	if err != nil {
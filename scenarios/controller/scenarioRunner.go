@@ -17,6 +17,13 @@ type ScenarioExecutor interface {
 	ExecuteScenario(*mj.Scenario, fr.FileResolver) error
 }
 
+// GoldenModeExecutor is implemented by executors that can run in golden mode,
+// where failing checkState steps get updated to the actual state, instead of failing.
+// The runner saves the scenarios updated this way.
+type GoldenModeExecutor interface {
+	IsGoldenMode() bool
+}
+
 // ScenarioRunner is a component that can run json scenarios, using a provided executor.
 type ScenarioRunner struct {
 	Executor ScenarioExecutor
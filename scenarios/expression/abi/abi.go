@@ -0,0 +1,108 @@
+package scenexpressionabi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ABI is the description of a contract's endpoints and types, as generated by the contract framework (*.abi.json).
+type ABI struct {
+	Name        string                      `json:"name"`
+	Constructor *Endpoint                   `json:"constructor"`
+	Endpoints   []*Endpoint                 `json:"endpoints"`
+	Types       map[string]*TypeDescription `json:"types"`
+}
+
+// Endpoint describes the arguments and results of a contract function.
+type Endpoint struct {
+	Name       string   `json:"name"`
+	Mutability string   `json:"mutability"`
+	Inputs     []*Param `json:"inputs"`
+	Outputs    []*Param `json:"outputs"`
+}
+
+// Param is an endpoint argument or result.
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypeDescription describes a custom type: either a struct, or an enum.
+type TypeDescription struct {
+	Type     string     `json:"type"`
+	Fields   []*Field   `json:"fields"`
+	Variants []*Variant `json:"variants"`
+}
+
+// Field is a struct field, or an enum variant field.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Variant is an enum variant. Fieldless variants only have a discriminant.
+type Variant struct {
+	Name         string   `json:"name"`
+	Discriminant int      `json:"discriminant"`
+	Fields       []*Field `json:"fields"`
+}
+
+const typeDescriptionStruct = "struct"
+const typeDescriptionEnum = "enum"
+const typeDescriptionExplicitEnum = "explicit-enum"
+
+// ParseABI loads an ABI from its JSON representation.
+func ParseABI(abiJSON []byte) (*ABI, error) {
+	abi := &ABI{}
+	err := json.Unmarshal(abiJSON, abi)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI JSON: %w", err)
+	}
+
+	for typeName, description := range abi.Types {
+		switch description.Type {
+		case typeDescriptionStruct, typeDescriptionEnum, typeDescriptionExplicitEnum:
+		default:
+			return nil, fmt.Errorf("unknown kind of type %s in ABI: %s", typeName, description.Type)
+		}
+	}
+
+	return abi, nil
+}
+
+// Endpoint finds an endpoint by name. The constructor is called "init".
+func (abi *ABI) Endpoint(name string) (*Endpoint, error) {
+	if name == "init" && abi.Constructor != nil {
+		return abi.Constructor, nil
+	}
+
+	for _, endpoint := range abi.Endpoints {
+		if endpoint.Name == name {
+			return endpoint, nil
+		}
+	}
+
+	return nil, fmt.Errorf("endpoint %s not found in ABI %s", name, abi.Name)
+}
+
+func (description *TypeDescription) isEnum() bool {
+	return description.Type == typeDescriptionEnum || description.Type == typeDescriptionExplicitEnum
+}
+
+func (description *TypeDescription) variantByName(name string) (*Variant, error) {
+	for _, variant := range description.Variants {
+		if variant.Name == name {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown enum variant %s", name)
+}
+
+func (description *TypeDescription) variantByDiscriminant(discriminant int) (*Variant, error) {
+	for _, variant := range description.Variants {
+		if variant.Discriminant == discriminant {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown enum discriminant %d", discriminant)
+}
@@ -0,0 +1,270 @@
+package scenexpressionabi
+
+import (
+	"math/big"
+	"testing"
+
+	ei "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/interpreter"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+	"github.com/stretchr/testify/require"
+)
+
+const testABIJSON = `{
+    "name": "Auction",
+    "constructor": {
+        "inputs": [{"name": "min_bid", "type": "BigUint"}],
+        "outputs": []
+    },
+    "endpoints": [
+        {
+            "name": "bid",
+            "mutability": "mutable",
+            "inputs": [
+                {"name": "offer", "type": "Offer"},
+                {"name": "memo", "type": "optional<bytes>", "multi_arg": true}
+            ],
+            "outputs": []
+        },
+        {
+            "name": "getOffers",
+            "mutability": "readonly",
+            "inputs": [],
+            "outputs": [{"type": "variadic<multi<u32,Offer>>", "multi_result": true}]
+        }
+    ],
+    "types": {
+        "Offer": {
+            "type": "struct",
+            "fields": [
+                {"name": "bidder", "type": "Address"},
+                {"name": "amount", "type": "BigUint"},
+                {"name": "tags", "type": "List<u8>"},
+                {"name": "deadline", "type": "Option<u64>"},
+                {"name": "status", "type": "Status"}
+            ]
+        },
+        "Status": {
+            "type": "enum",
+            "variants": [
+                {"name": "Open", "discriminant": 0},
+                {"name": "Closed", "discriminant": 1, "fields": [{"name": "0", "type": "i16"}]}
+            ]
+        }
+    }
+}`
+
+func loadTestABI(t *testing.T) *ABI {
+	abi, err := ParseABI([]byte(testABIJSON))
+	require.Nil(t, err)
+	return abi
+}
+
+func TestParseType(t *testing.T) {
+	parsed, err := parseType("variadic<multi<u32, List<Option<BigUint>>>>")
+	require.Nil(t, err)
+	require.Equal(t, "variadic<multi<u32,List<Option<BigUint>>>>", parsed.String())
+	require.Equal(t, multiVariadic, parsed.multiKind())
+	require.Equal(t, multiMulti, parsed.args[0].multiKind())
+	require.Equal(t, multiMulti, (&abiType{name: "MultiValue3"}).multiKind())
+
+	length, isArray := (&abiType{name: "array32"}).arrayLength()
+	require.True(t, isArray)
+	require.Equal(t, 32, length)
+
+	for _, invalid := range []string{"", "List<u8", "List<u8>>", "tuple<u8,>"} {
+		_, err = parseType(invalid)
+		require.NotNil(t, err, invalid)
+	}
+}
+
+func TestEncodeDecodeBasicTypes(t *testing.T) {
+	codec := NewCodec(nil)
+
+	checkTopAndNested := func(typeName string, value interface{}, top []byte, nested []byte) {
+		encoded, err := codec.EncodeTopLevel(typeName, value)
+		require.Nil(t, err, typeName)
+		require.Equal(t, top, encoded, typeName)
+		encoded, err = codec.EncodeNested(typeName, value)
+		require.Nil(t, err, typeName)
+		require.Equal(t, nested, encoded, typeName)
+
+		decoded, err := codec.DecodeTopLevel(typeName, top)
+		require.Nil(t, err, typeName)
+		require.Equal(t, FormatValue(value), FormatValue(decoded), typeName)
+		decoded, err = codec.DecodeNested(typeName, nested)
+		require.Nil(t, err, typeName)
+		require.Equal(t, FormatValue(value), FormatValue(decoded), typeName)
+	}
+
+	checkTopAndNested("u32", big.NewInt(5), []byte{5}, []byte{0, 0, 0, 5})
+	checkTopAndNested("u8", big.NewInt(0), []byte{}, []byte{0})
+	checkTopAndNested("i16", big.NewInt(-2), []byte{0xfe}, []byte{0xff, 0xfe})
+	checkTopAndNested("BigUint", big.NewInt(256), []byte{1, 0}, []byte{0, 0, 0, 2, 1, 0})
+	checkTopAndNested("BigInt", big.NewInt(-1), []byte{0xff}, []byte{0, 0, 0, 1, 0xff})
+	checkTopAndNested("bool", true, []byte{1}, []byte{1})
+	checkTopAndNested("bool", false, []byte{}, []byte{0})
+	checkTopAndNested("bytes", []byte("abc"), []byte("abc"), []byte{0, 0, 0, 3, 'a', 'b', 'c'})
+	checkTopAndNested("Option<u16>", nil, []byte{}, []byte{0})
+	checkTopAndNested("Option<u16>", big.NewInt(7), []byte{1, 0, 7}, []byte{1, 0, 7})
+	checkTopAndNested("List<u16>", []interface{}{big.NewInt(1), big.NewInt(2)},
+		[]byte{0, 1, 0, 2}, []byte{0, 0, 0, 2, 0, 1, 0, 2})
+	checkTopAndNested("tuple<u8,bytes>", []interface{}{big.NewInt(1), []byte("x")},
+		[]byte{1, 0, 0, 0, 1, 'x'}, []byte{1, 0, 0, 0, 1, 'x'})
+	checkTopAndNested("array2<u8>", []byte{3, 4}, []byte{3, 4}, []byte{3, 4})
+
+	_, err := codec.EncodeTopLevel("u8", big.NewInt(256))
+	require.NotNil(t, err)
+	_, err = codec.EncodeTopLevel("i8", big.NewInt(-129))
+	require.NotNil(t, err)
+	_, err = codec.EncodeTopLevel("Address", []byte{1, 2})
+	require.NotNil(t, err)
+	_, err = codec.EncodeTopLevel("Unknown", []byte{})
+	require.NotNil(t, err)
+	_, err = codec.DecodeNested("u32", []byte{0, 1})
+	require.NotNil(t, err)
+	_, err = codec.DecodeNested("u8", []byte{0, 1})
+	require.NotNil(t, err)
+}
+
+func TestEncodeDecodeCustomTypes(t *testing.T) {
+	codec := NewCodec(loadTestABI(t))
+	bidder := make([]byte, 32)
+	bidder[31] = 0xaa
+
+	offer := &StructValue{Fields: []*FieldValue{
+		{Name: "bidder", Value: bidder},
+		{Name: "amount", Value: big.NewInt(1000)},
+		{Name: "tags", Value: []interface{}{big.NewInt(1)}},
+		{Name: "deadline", Value: nil},
+		{Name: "status", Value: &EnumValue{Variant: "Closed", Fields: []*FieldValue{{Name: "0", Value: big.NewInt(-1)}}}},
+	}}
+
+	expected := append([]byte{}, bidder...)
+	expected = append(expected, 0, 0, 0, 2, 0x03, 0xe8) // amount
+	expected = append(expected, 0, 0, 0, 1, 1)          // tags
+	expected = append(expected, 0)                      // deadline
+	expected = append(expected, 1, 0xff, 0xff)          // status
+
+	encoded, err := codec.EncodeTopLevel("Offer", offer)
+	require.Nil(t, err)
+	require.Equal(t, expected, encoded)
+
+	decoded, err := codec.DecodeTopLevel("Offer", encoded)
+	require.Nil(t, err)
+	require.Equal(t, FormatValue(offer), FormatValue(decoded))
+	require.Equal(t, big.NewInt(1000), decoded.(*StructValue).Field("amount"))
+
+	// fieldless variants
+	encoded, err = codec.EncodeTopLevel("Status", "Open")
+	require.Nil(t, err)
+	require.Equal(t, []byte{}, encoded)
+	encoded, err = codec.EncodeNested("Status", "Open")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0}, encoded)
+	decoded, err = codec.DecodeTopLevel("Status", []byte{})
+	require.Nil(t, err)
+	require.Equal(t, "Open", decoded.(*EnumValue).Variant)
+
+	_, err = codec.EncodeTopLevel("Status", "Unknown")
+	require.NotNil(t, err)
+	_, err = codec.DecodeTopLevel("Status", []byte{5})
+	require.NotNil(t, err)
+}
+
+func TestEncodeArgumentsDecodeResults(t *testing.T) {
+	abi := loadTestABI(t)
+	codec := NewCodec(abi)
+
+	init, err := abi.Endpoint("init")
+	require.Nil(t, err)
+	arguments, err := codec.EncodeArguments(init.Inputs, []interface{}{big.NewInt(5)})
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{{5}}, arguments)
+
+	bid, err := abi.Endpoint("bid")
+	require.Nil(t, err)
+	offer := map[string]interface{}{
+		"bidder":   make([]byte, 32),
+		"amount":   5,
+		"tags":     []interface{}{},
+		"deadline": uint64(10),
+		"status":   "Open",
+	}
+	arguments, err = codec.EncodeArguments(bid.Inputs, []interface{}{offer})
+	require.Nil(t, err)
+	require.Len(t, arguments, 1)
+	arguments, err = codec.EncodeArguments(bid.Inputs, []interface{}{offer, "memo"})
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{arguments[0], []byte("memo")}, arguments)
+	_, err = codec.EncodeArguments(bid.Inputs, []interface{}{offer, "memo", "extra"})
+	require.NotNil(t, err)
+	_, err = codec.EncodeArguments(bid.Inputs, []interface{}{})
+	require.NotNil(t, err)
+
+	getOffers, err := abi.Endpoint("getOffers")
+	require.Nil(t, err)
+	results, err := codec.EncodeArguments(getOffers.Outputs, []interface{}{
+		[]interface{}{big.NewInt(1), offer},
+		[]interface{}{big.NewInt(2), offer},
+	})
+	require.Nil(t, err)
+	require.Len(t, results, 4)
+
+	values, err := codec.DecodeResults(getOffers.Outputs, results)
+	require.Nil(t, err)
+	require.Len(t, values, 2)
+	require.Equal(t, big.NewInt(2), values[1].([]interface{})[0])
+
+	_, err = codec.DecodeResults(getOffers.Outputs, results[:3])
+	require.NotNil(t, err)
+
+	_, err = abi.Endpoint("missing")
+	require.NotNil(t, err)
+}
+
+func TestValuesFromJSON(t *testing.T) {
+	abi := loadTestABI(t)
+	codec := NewCodec(abi)
+	interpreter := &ei.ExprInterpreter{}
+
+	bid, err := abi.Endpoint("bid")
+	require.Nil(t, err)
+	jsonValues, err := oj.ParseOrderedJSON([]byte(`[
+        {
+            "bidder": "address:bidder",
+            "amount": "1,000",
+            "tags": ["1", "0x02"],
+            "deadline": {"Some": "u64:100"},
+            "status": {"Closed": ["-5"]}
+        },
+        "str:memo"
+    ]`))
+	require.Nil(t, err)
+
+	values, err := codec.ValuesFromJSON(bid.Inputs, jsonValues, interpreter)
+	require.Nil(t, err)
+	require.Len(t, values, 2)
+	require.Equal(t,
+		`{bidder: "bidder__________________________", amount: 1000, tags: [1, 2], deadline: 100, status: Closed {0: -5}}`,
+		FormatValue(values[0]))
+
+	arguments, err := codec.EncodeArguments(bid.Inputs, values)
+	require.Nil(t, err)
+	require.Equal(t, []byte("memo"), arguments[1])
+
+	for _, invalid := range []string{
+		`[{"bidder": "address:bidder"}]`,
+		`[{"bidder": "address:bidder", "amount": "1", "tags": [], "deadline": "None", "status": "Closed"}]`,
+		`[{"bidder": "address:bidder", "amount": "1", "tags": [], "deadline": "Some", "status": "Open"}]`,
+		`[{"bidder": "address:bidder", "amount": "1", "tags": [], "deadline": "None", "status": "Open", "extra": "1"}]`,
+		`["1", "2", "3"]`,
+	} {
+		jsonValues, err = oj.ParseOrderedJSON([]byte(invalid))
+		require.Nil(t, err)
+		values, err = codec.ValuesFromJSON(bid.Inputs, jsonValues, interpreter)
+		if err == nil {
+			_, err = codec.EncodeArguments(bid.Inputs, values)
+		}
+		require.NotNil(t, err, invalid)
+	}
+}
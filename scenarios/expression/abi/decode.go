@@ -0,0 +1,348 @@
+package scenexpressionabi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+var errInputTooShort = errors.New("input too short")
+
+// DecodeTopLevel deserializes a whole argument or result.
+func (c *Codec) DecodeTopLevel(typeName string, data []byte) (interface{}, error) {
+	t, err := parseType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeTopLevel(t, data)
+}
+
+// DecodeNested deserializes a nested value, which must span all the data.
+func (c *Codec) DecodeNested(typeName string, data []byte) (interface{}, error) {
+	t, err := parseType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeNestedWhole(t, data)
+}
+
+// DecodeResults deserializes the results of an endpoint, one value per output,
+// grouping the results of multi-value outputs the same way EncodeArguments expects them.
+func (c *Codec) DecodeResults(outputs []*Param, results [][]byte) ([]interface{}, error) {
+	var values []interface{}
+	resultIndex := 0
+	for outputIndex, output := range outputs {
+		t, err := parseType(output.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		switch t.multiKind() {
+		case multiVariadic:
+			if outputIndex != len(outputs)-1 {
+				return nil, fmt.Errorf("variadic output %s must be the last one", output.Name)
+			}
+			itemType, err := t.singleArg()
+			if err != nil {
+				return nil, err
+			}
+			for resultIndex < len(results) {
+				var value interface{}
+				value, resultIndex, err = c.decodeMultiResult(itemType, results, resultIndex)
+				if err != nil {
+					return nil, fmt.Errorf("output %s: %w", output.Name, err)
+				}
+				values = append(values, value)
+			}
+		case multiOptional:
+			itemType, err := t.singleArg()
+			if err != nil {
+				return nil, err
+			}
+			if resultIndex < len(results) {
+				var value interface{}
+				value, resultIndex, err = c.decodeMultiResult(itemType, results, resultIndex)
+				if err != nil {
+					return nil, fmt.Errorf("output %s: %w", output.Name, err)
+				}
+				values = append(values, value)
+			}
+		default:
+			var value interface{}
+			value, resultIndex, err = c.decodeMultiResult(t, results, resultIndex)
+			if err != nil {
+				return nil, fmt.Errorf("output %s: %w", output.Name, err)
+			}
+			values = append(values, value)
+		}
+	}
+
+	if resultIndex < len(results) {
+		return nil, fmt.Errorf("too many results: expected %d, got %d", resultIndex, len(results))
+	}
+
+	return values, nil
+}
+
+func (c *Codec) decodeMultiResult(t *abiType, results [][]byte, resultIndex int) (interface{}, int, error) {
+	if t.multiKind() != multiMulti {
+		if resultIndex >= len(results) {
+			return nil, resultIndex, errors.New("missing result")
+		}
+		value, err := c.decodeTopLevel(t, results[resultIndex])
+		return value, resultIndex + 1, err
+	}
+
+	items := make([]interface{}, len(t.args))
+	for i, itemType := range t.args {
+		var err error
+		items[i], resultIndex, err = c.decodeMultiResult(itemType, results, resultIndex)
+		if err != nil {
+			return nil, resultIndex, err
+		}
+	}
+	return items, resultIndex, nil
+}
+
+func (c *Codec) decodeTopLevel(t *abiType, data []byte) (interface{}, error) {
+	if intType, isFixedInt := fixedIntTypes[t.name]; isFixedInt {
+		if len(data) > intType.size {
+			return nil, fmt.Errorf("%s cannot be %d bytes long", t.name, len(data))
+		}
+		return decodeBigInt(data, intType.signed), nil
+	}
+
+	switch t.name {
+	case "BigUint":
+		return decodeBigInt(data, false), nil
+	case "BigInt":
+		return decodeBigInt(data, true), nil
+	case "bool":
+		switch {
+		case len(data) == 0:
+			return false, nil
+		case len(data) == 1 && data[0] == 1:
+			return true, nil
+		default:
+			return nil, fmt.Errorf("invalid bool: 0x%x", data)
+		}
+	case "Option":
+		if len(data) == 0 {
+			return nil, nil
+		}
+		return c.decodeNestedWhole(t, data)
+	}
+
+	if _, isBytes := bytesTypes[t.name]; isBytes {
+		return data, nil
+	}
+
+	if _, isList := listTypes[t.name]; isList {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0)
+		for len(data) > 0 {
+			var item interface{}
+			item, data, err = c.decodeNested(itemType, data)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	description, isCustom := c.abi.Types[t.name]
+	if isCustom && description.isEnum() {
+		if len(data) <= 1 {
+			variant, err := description.variantByDiscriminant(int(new(big.Int).SetBytes(data).Int64()))
+			if err != nil {
+				return nil, err
+			}
+			if len(variant.Fields) == 0 {
+				return &EnumValue{Variant: variant.Name}, nil
+			}
+		}
+	}
+
+	return c.decodeNestedWhole(t, data)
+}
+
+func (c *Codec) decodeNestedWhole(t *abiType, data []byte) (interface{}, error) {
+	value, rest, err := c.decodeNested(t, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after %s", len(rest), t)
+	}
+	return value, nil
+}
+
+// decodeNested returns the decoded value and the remaining data
+func (c *Codec) decodeNested(t *abiType, data []byte) (interface{}, []byte, error) {
+	if intType, isFixedInt := fixedIntTypes[t.name]; isFixedInt {
+		if len(data) < intType.size {
+			return nil, nil, fmt.Errorf("%s: %w", t.name, errInputTooShort)
+		}
+		return decodeBigInt(data[:intType.size], intType.signed), data[intType.size:], nil
+	}
+
+	if length, isFixedBytes := fixedBytesTypes[t.name]; isFixedBytes {
+		if len(data) < length {
+			return nil, nil, fmt.Errorf("%s: %w", t.name, errInputTooShort)
+		}
+		return data[:length], data[length:], nil
+	}
+
+	switch t.name {
+	case "BigUint", "BigInt":
+		bytes, rest, err := decodeWithLength(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+		return decodeBigInt(bytes, t.name == "BigInt"), rest, nil
+	case "bool":
+		if len(data) < 1 {
+			return nil, nil, fmt.Errorf("bool: %w", errInputTooShort)
+		}
+		if data[0] > 1 {
+			return nil, nil, fmt.Errorf("invalid bool: 0x%x", data[0])
+		}
+		return data[0] == 1, data[1:], nil
+	case "Option":
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) < 1 {
+			return nil, nil, fmt.Errorf("Option: %w", errInputTooShort)
+		}
+		switch data[0] {
+		case 0:
+			return nil, data[1:], nil
+		case 1:
+			return c.decodeNested(itemType, data[1:])
+		default:
+			return nil, nil, fmt.Errorf("invalid Option discriminant: 0x%x", data[0])
+		}
+	case "tuple":
+		return c.decodeNestedItems(t.args, data)
+	}
+
+	if _, isBytes := bytesTypes[t.name]; isBytes {
+		bytes, rest, err := decodeWithLength(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+		return bytes, rest, nil
+	}
+
+	if _, isList := listTypes[t.name]; isList {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) < 4 {
+			return nil, nil, fmt.Errorf("%s: %w", t, errInputTooShort)
+		}
+		numItems := int(binary.BigEndian.Uint32(data))
+		if numItems > len(data) {
+			return nil, nil, fmt.Errorf("%s: %w", t, errInputTooShort)
+		}
+		return c.decodeNestedItems(repeatType(itemType, numItems), data[4:])
+	}
+
+	if length, isArray := t.arrayLength(); isArray {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, nil, err
+		}
+		if itemType.name == "u8" {
+			if len(data) < length {
+				return nil, nil, fmt.Errorf("%s: %w", t, errInputTooShort)
+			}
+			return data[:length], data[length:], nil
+		}
+		return c.decodeNestedItems(repeatType(itemType, length), data)
+	}
+
+	description, isCustom := c.abi.Types[t.name]
+	if !isCustom {
+		return nil, nil, fmt.Errorf("unknown type %s", t)
+	}
+
+	if description.isEnum() {
+		if len(data) < 1 {
+			return nil, nil, fmt.Errorf("%s: %w", t.name, errInputTooShort)
+		}
+		variant, err := description.variantByDiscriminant(int(data[0]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+		fields, rest, err := c.decodeFields(variant.Fields, data[1:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s::%s: %w", t.name, variant.Name, err)
+		}
+		return &EnumValue{Variant: variant.Name, Fields: fields}, rest, nil
+	}
+
+	fields, rest, err := c.decodeFields(description.Fields, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", t.name, err)
+	}
+	return &StructValue{Fields: fields}, rest, nil
+}
+
+func (c *Codec) decodeNestedItems(itemTypes []*abiType, data []byte) (interface{}, []byte, error) {
+	items := make([]interface{}, len(itemTypes))
+	for i, itemType := range itemTypes {
+		var err error
+		items[i], data, err = c.decodeNested(itemType, data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return items, data, nil
+}
+
+func (c *Codec) decodeFields(fields []*Field, data []byte) ([]*FieldValue, []byte, error) {
+	var fieldValues []*FieldValue
+	for _, field := range fields {
+		fieldType, err := parseType(field.Type)
+		if err != nil {
+			return nil, nil, err
+		}
+		var value interface{}
+		value, data, err = c.decodeNested(fieldType, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fieldValues = append(fieldValues, &FieldValue{Name: field.Name, Value: value})
+	}
+	return fieldValues, data, nil
+}
+
+func decodeWithLength(data []byte) ([]byte, []byte, error) {
+	if len(data) < 4 {
+		return nil, nil, errInputTooShort
+	}
+	length := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint64(len(data)) < uint64(length) {
+		return nil, nil, errInputTooShort
+	}
+	return data[:length], data[length:], nil
+}
+
+func decodeBigInt(data []byte, signed bool) *big.Int {
+	if signed {
+		return twos.FromBytes(data)
+	}
+	return new(big.Int).SetBytes(data)
+}
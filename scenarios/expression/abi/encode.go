@@ -0,0 +1,488 @@
+package scenexpressionabi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+// Codec encodes and decodes typed values with the contract serialization format:
+// the top-level encoding is used for each argument or result, the nested encoding for the values inside them.
+type Codec struct {
+	abi *ABI
+}
+
+// NewCodec creates a codec for the types of an ABI. Without an ABI (nil), only the built-in types are known.
+func NewCodec(abi *ABI) *Codec {
+	if abi == nil {
+		abi = &ABI{}
+	}
+	return &Codec{abi: abi}
+}
+
+// EncodeTopLevel serializes a value as a whole argument.
+func (c *Codec) EncodeTopLevel(typeName string, value interface{}) ([]byte, error) {
+	t, err := parseType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return c.encodeTopLevel(t, value)
+}
+
+// EncodeNested serializes a value as it would be inside another value.
+func (c *Codec) EncodeNested(typeName string, value interface{}) ([]byte, error) {
+	t, err := parseType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return c.encodeNested(nil, t, value)
+}
+
+// EncodeArguments serializes the arguments of an endpoint, one value per input.
+// Multi-value inputs span several arguments: variadic inputs take all remaining values,
+// optional inputs can be left out at the end, and multi<A,B,..> inputs take a list with a value for each type.
+func (c *Codec) EncodeArguments(inputs []*Param, values []interface{}) ([][]byte, error) {
+	var arguments [][]byte
+	valueIndex := 0
+	for inputIndex, input := range inputs {
+		t, err := parseType(input.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		switch t.multiKind() {
+		case multiVariadic:
+			if inputIndex != len(inputs)-1 {
+				return nil, fmt.Errorf("variadic input %s must be the last one", input.Name)
+			}
+			itemType, err := t.singleArg()
+			if err != nil {
+				return nil, err
+			}
+			for ; valueIndex < len(values); valueIndex++ {
+				arguments, err = c.encodeMultiArgument(arguments, itemType, values[valueIndex])
+				if err != nil {
+					return nil, fmt.Errorf("input %s: %w", input.Name, err)
+				}
+			}
+		case multiOptional:
+			itemType, err := t.singleArg()
+			if err != nil {
+				return nil, err
+			}
+			if valueIndex < len(values) {
+				if values[valueIndex] != nil {
+					arguments, err = c.encodeMultiArgument(arguments, itemType, values[valueIndex])
+					if err != nil {
+						return nil, fmt.Errorf("input %s: %w", input.Name, err)
+					}
+				}
+				valueIndex++
+			}
+		default:
+			if valueIndex >= len(values) {
+				return nil, fmt.Errorf("missing value for input %s", input.Name)
+			}
+			arguments, err = c.encodeMultiArgument(arguments, t, values[valueIndex])
+			if err != nil {
+				return nil, fmt.Errorf("input %s: %w", input.Name, err)
+			}
+			valueIndex++
+		}
+	}
+
+	if valueIndex < len(values) {
+		return nil, fmt.Errorf("too many values: expected %d, got %d", valueIndex, len(values))
+	}
+
+	return arguments, nil
+}
+
+func (c *Codec) encodeMultiArgument(arguments [][]byte, t *abiType, value interface{}) ([][]byte, error) {
+	if t.multiKind() != multiMulti {
+		argument, err := c.encodeTopLevel(t, value)
+		if err != nil {
+			return nil, err
+		}
+		return append(arguments, argument), nil
+	}
+
+	items, err := listValue(value, len(t.args))
+	if err != nil {
+		return nil, err
+	}
+	for i, itemType := range t.args {
+		arguments, err = c.encodeMultiArgument(arguments, itemType, items[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return arguments, nil
+}
+
+func (c *Codec) encodeTopLevel(t *abiType, value interface{}) ([]byte, error) {
+	if intType, isFixedInt := fixedIntTypes[t.name]; isFixedInt {
+		number, err := checkedFixedInt(t, intType, value)
+		if err != nil {
+			return nil, err
+		}
+		return minimalBytes(number, intType.signed), nil
+	}
+
+	switch t.name {
+	case "BigUint":
+		number, err := bigIntValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if number.Sign() < 0 {
+			return nil, fmt.Errorf("negative value for BigUint: %d", number)
+		}
+		return number.Bytes(), nil
+	case "BigInt":
+		number, err := bigIntValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return twos.ToBytes(number), nil
+	case "bool":
+		boolean, isBool := value.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		if boolean {
+			return []byte{1}, nil
+		}
+		return []byte{}, nil
+	case "Option":
+		if value == nil {
+			return []byte{}, nil
+		}
+		return c.encodeNested(nil, t, value)
+	}
+
+	if _, isBytes := bytesTypes[t.name]; isBytes {
+		return bytesValue(value)
+	}
+
+	if _, isList := listTypes[t.name]; isList {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		items, err := listValue(value, -1)
+		if err != nil {
+			return nil, err
+		}
+		var encoded []byte
+		for _, item := range items {
+			encoded, err = c.encodeNested(encoded, itemType, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return encoded, nil
+	}
+
+	description, isCustom := c.abi.Types[t.name]
+	if isCustom && description.isEnum() {
+		variant, fieldValues, err := enumValue(description, value)
+		if err != nil {
+			return nil, err
+		}
+		if len(variant.Fields) == 0 {
+			return big.NewInt(int64(variant.Discriminant)).Bytes(), nil
+		}
+		return c.encodeVariant(nil, variant, fieldValues)
+	}
+
+	return c.encodeNested(nil, t, value)
+}
+
+func (c *Codec) encodeNested(dest []byte, t *abiType, value interface{}) ([]byte, error) {
+	if intType, isFixedInt := fixedIntTypes[t.name]; isFixedInt {
+		number, err := checkedFixedInt(t, intType, value)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := twos.ToBytesOfLength(number, intType.size)
+		if err != nil {
+			return nil, err
+		}
+		return append(dest, encoded...), nil
+	}
+
+	if length, isFixedBytes := fixedBytesTypes[t.name]; isFixedBytes {
+		bytes, err := bytesValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes) != length {
+			return nil, fmt.Errorf("%s must be %d bytes long, got %d", t.name, length, len(bytes))
+		}
+		return append(dest, bytes...), nil
+	}
+
+	switch t.name {
+	case "BigUint", "BigInt":
+		encoded, err := c.encodeTopLevel(t, value)
+		if err != nil {
+			return nil, err
+		}
+		return appendWithLength(dest, encoded), nil
+	case "bool":
+		boolean, isBool := value.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		if boolean {
+			return append(dest, 1), nil
+		}
+		return append(dest, 0), nil
+	case "Option":
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return append(dest, 0), nil
+		}
+		return c.encodeNested(append(dest, 1), itemType, value)
+	case "tuple":
+		items, err := listValue(value, len(t.args))
+		if err != nil {
+			return nil, err
+		}
+		for i, itemType := range t.args {
+			dest, err = c.encodeNested(dest, itemType, items[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return dest, nil
+	}
+
+	if _, isBytes := bytesTypes[t.name]; isBytes {
+		bytes, err := bytesValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return appendWithLength(dest, bytes), nil
+	}
+
+	if _, isList := listTypes[t.name]; isList {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		items, err := listValue(value, -1)
+		if err != nil {
+			return nil, err
+		}
+		dest = binary.BigEndian.AppendUint32(dest, uint32(len(items)))
+		for _, item := range items {
+			dest, err = c.encodeNested(dest, itemType, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return dest, nil
+	}
+
+	if length, isArray := t.arrayLength(); isArray {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		if itemType.name == "u8" {
+			if bytes, isBytes := value.([]byte); isBytes {
+				if len(bytes) != length {
+					return nil, fmt.Errorf("%s must have %d items, got %d", t, length, len(bytes))
+				}
+				return append(dest, bytes...), nil
+			}
+		}
+		items, err := listValue(value, length)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			dest, err = c.encodeNested(dest, itemType, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return dest, nil
+	}
+
+	description, isCustom := c.abi.Types[t.name]
+	if !isCustom {
+		return nil, fmt.Errorf("unknown type %s", t)
+	}
+
+	if description.isEnum() {
+		variant, fieldValues, err := enumValue(description, value)
+		if err != nil {
+			return nil, err
+		}
+		return c.encodeVariant(dest, variant, fieldValues)
+	}
+
+	fieldValues, err := structFieldValues(description.Fields, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", t.name, err)
+	}
+	return c.encodeFields(dest, description.Fields, fieldValues)
+}
+
+func (c *Codec) encodeVariant(dest []byte, variant *Variant, fieldValues []interface{}) ([]byte, error) {
+	if variant.Discriminant < 0 || variant.Discriminant > 255 {
+		return nil, fmt.Errorf("discriminant of enum variant %s does not fit in a byte", variant.Name)
+	}
+	return c.encodeFields(append(dest, byte(variant.Discriminant)), variant.Fields, fieldValues)
+}
+
+func (c *Codec) encodeFields(dest []byte, fields []*Field, fieldValues []interface{}) ([]byte, error) {
+	for i, field := range fields {
+		fieldType, err := parseType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		dest, err = c.encodeNested(dest, fieldType, fieldValues[i])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return dest, nil
+}
+
+func checkedFixedInt(t *abiType, intType fixedIntType, value interface{}) (*big.Int, error) {
+	number, err := bigIntValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := uint(intType.size * 8)
+	if intType.signed {
+		limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+		if number.Cmp(new(big.Int).Neg(limit)) < 0 || number.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("value %d does not fit in %s", number, t.name)
+		}
+		return number, nil
+	}
+
+	if number.Sign() < 0 || number.BitLen() > int(bits) {
+		return nil, fmt.Errorf("value %d does not fit in %s", number, t.name)
+	}
+	return number, nil
+}
+
+func minimalBytes(number *big.Int, signed bool) []byte {
+	if signed {
+		return twos.ToBytes(number)
+	}
+	return number.Bytes()
+}
+
+func appendWithLength(dest []byte, bytes []byte) []byte {
+	dest = binary.BigEndian.AppendUint32(dest, uint32(len(bytes)))
+	return append(dest, bytes...)
+}
+
+func bigIntValue(value interface{}) (*big.Int, error) {
+	switch typed := value.(type) {
+	case *big.Int:
+		return typed, nil
+	case int:
+		return big.NewInt(int64(typed)), nil
+	case int64:
+		return big.NewInt(typed), nil
+	case uint64:
+		return new(big.Int).SetUint64(typed), nil
+	case uint32:
+		return big.NewInt(int64(typed)), nil
+	default:
+		return nil, fmt.Errorf("expected an integer, got %T", value)
+	}
+}
+
+func bytesValue(value interface{}) ([]byte, error) {
+	switch typed := value.(type) {
+	case []byte:
+		return typed, nil
+	case string:
+		return []byte(typed), nil
+	default:
+		return nil, fmt.Errorf("expected bytes, got %T", value)
+	}
+}
+
+// listValue converts a list value, checking its length if not negative
+func listValue(value interface{}, expectedLength int) ([]interface{}, error) {
+	items, isList := value.([]interface{})
+	if !isList {
+		return nil, fmt.Errorf("expected a list, got %T", value)
+	}
+	if expectedLength >= 0 && len(items) != expectedLength {
+		return nil, fmt.Errorf("expected %d items, got %d", expectedLength, len(items))
+	}
+	return items, nil
+}
+
+func structFieldValues(fields []*Field, value interface{}) ([]interface{}, error) {
+	fieldValues := make([]interface{}, len(fields))
+	switch typed := value.(type) {
+	case *StructValue:
+		if len(typed.Fields) != len(fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(fields), len(typed.Fields))
+		}
+		for i, field := range fields {
+			if typed.Fields[i].Name != field.Name {
+				return nil, fmt.Errorf("expected field %s, got %s", field.Name, typed.Fields[i].Name)
+			}
+			fieldValues[i] = typed.Fields[i].Value
+		}
+	case map[string]interface{}:
+		if len(typed) != len(fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(fields), len(typed))
+		}
+		for i, field := range fields {
+			fieldValue, found := typed[field.Name]
+			if !found {
+				return nil, fmt.Errorf("missing field %s", field.Name)
+			}
+			fieldValues[i] = fieldValue
+		}
+	default:
+		return nil, fmt.Errorf("expected a struct, got %T", value)
+	}
+	return fieldValues, nil
+}
+
+func enumValue(description *TypeDescription, value interface{}) (*Variant, []interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		variant, err := description.variantByName(typed)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(variant.Fields) > 0 {
+			return nil, nil, fmt.Errorf("enum variant %s has fields", variant.Name)
+		}
+		return variant, nil, nil
+	case *EnumValue:
+		variant, err := description.variantByName(typed.Variant)
+		if err != nil {
+			return nil, nil, err
+		}
+		fieldValues, err := structFieldValues(variant.Fields, &StructValue{Fields: typed.Fields})
+		if err != nil {
+			return nil, nil, fmt.Errorf("enum variant %s: %w", variant.Name, err)
+		}
+		return variant, fieldValues, nil
+	default:
+		return nil, nil, fmt.Errorf("expected an enum, got %T", value)
+	}
+}
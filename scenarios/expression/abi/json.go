@@ -0,0 +1,303 @@
+package scenexpressionabi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ei "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/interpreter"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+// In scenarios, typed values are written in JSON:
+//   - numbers, byte slices and addresses are scenario expressions, e.g. "1000", "str:abc", "address:owner";
+//   - bools are "true" or "false";
+//   - lists, arrays and tuples are JSON lists;
+//   - structs are JSON maps, with all the fields;
+//   - enums are the name of the variant, e.g. "Active", or, for variants with fields, a map with a single key,
+//     e.g. {"Pending": {"deadline": "100"}}, the fields being given either by name, or in order, as a list;
+//   - Option values are "None" or {"Some": value}, the same as enums;
+//   - multi-value arguments are lists, with a value for each type, variadic ones take all remaining values.
+
+const noneVariant = "None"
+const someVariant = "Some"
+
+// ValuesFromJSON converts the JSON typed values of an endpoint's inputs (or outputs) to the values accepted by EncodeArguments.
+func (c *Codec) ValuesFromJSON(params []*Param, obj oj.OJsonObject, interpreter *ei.ExprInterpreter) ([]interface{}, error) {
+	list, isList := obj.(*oj.OJsonList)
+	if !isList {
+		return nil, errors.New("typed values are not a JSON list")
+	}
+	items := list.AsList()
+
+	var values []interface{}
+	itemIndex := 0
+	for _, param := range params {
+		t, err := parseType(param.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		itemType := t
+		switch t.multiKind() {
+		case multiVariadic, multiOptional:
+			itemType, err = t.singleArg()
+			if err != nil {
+				return nil, err
+			}
+		default:
+			if itemIndex >= len(items) {
+				return nil, fmt.Errorf("missing value for %s", param.Name)
+			}
+		}
+
+		for ; itemIndex < len(items); itemIndex++ {
+			value, err := c.valueFromJSON(itemType, items[itemIndex], interpreter)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", param.Name, err)
+			}
+			values = append(values, value)
+
+			if t.multiKind() != multiVariadic {
+				itemIndex++
+				break
+			}
+		}
+	}
+
+	if itemIndex < len(items) {
+		return nil, fmt.Errorf("too many values: expected %d, got %d", itemIndex, len(items))
+	}
+
+	return values, nil
+}
+
+// ValueFromJSON converts a JSON typed value to the value accepted by EncodeTopLevel and EncodeNested.
+func (c *Codec) ValueFromJSON(typeName string, obj oj.OJsonObject, interpreter *ei.ExprInterpreter) (interface{}, error) {
+	t, err := parseType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return c.valueFromJSON(t, obj, interpreter)
+}
+
+func (c *Codec) valueFromJSON(t *abiType, obj oj.OJsonObject, interpreter *ei.ExprInterpreter) (interface{}, error) {
+	if intType, isFixedInt := fixedIntTypes[t.name]; isFixedInt {
+		return bigIntFromJSON(obj, intType.signed, interpreter)
+	}
+	if _, isFixedBytes := fixedBytesTypes[t.name]; isFixedBytes {
+		return interpreter.InterpretSubTree(obj)
+	}
+	if _, isBytes := bytesTypes[t.name]; isBytes {
+		return interpreter.InterpretSubTree(obj)
+	}
+
+	switch t.name {
+	case "BigUint":
+		return bigIntFromJSON(obj, false, interpreter)
+	case "BigInt":
+		return bigIntFromJSON(obj, true, interpreter)
+	case "bool":
+		return boolFromJSON(obj)
+	case "Option":
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		variant, fieldsObj, err := variantFromJSON(obj)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case variant == noneVariant && fieldsObj == nil:
+			return nil, nil
+		case variant == someVariant && fieldsObj != nil:
+			return c.valueFromJSON(itemType, fieldsObj, interpreter)
+		default:
+			return nil, errors.New(`Option should be "None" or {"Some": value}`)
+		}
+	}
+
+	if t.multiKind() == multiMulti || t.name == "tuple" {
+		return c.listFromJSON(t.args, obj, interpreter)
+	}
+
+	if _, isList := listTypes[t.name]; isList {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		list, isList := obj.(*oj.OJsonList)
+		if !isList {
+			return nil, fmt.Errorf("%s is not a JSON list", t)
+		}
+		return c.listFromJSON(repeatType(itemType, len(list.AsList())), obj, interpreter)
+	}
+
+	if length, isArray := t.arrayLength(); isArray {
+		itemType, err := t.singleArg()
+		if err != nil {
+			return nil, err
+		}
+		if _, isStr := obj.(*oj.OJsonString); isStr && itemType.name == "u8" {
+			return interpreter.InterpretSubTree(obj)
+		}
+		return c.listFromJSON(repeatType(itemType, length), obj, interpreter)
+	}
+
+	description, isCustom := c.abi.Types[t.name]
+	if !isCustom {
+		return nil, fmt.Errorf("unknown type %s", t)
+	}
+
+	if description.isEnum() {
+		variantName, fieldsObj, err := variantFromJSON(obj)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+		variant, err := description.variantByName(variantName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.name, err)
+		}
+		if fieldsObj == nil {
+			return &EnumValue{Variant: variant.Name}, nil
+		}
+		fields, err := c.fieldsFromJSON(variant.Fields, fieldsObj, interpreter)
+		if err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", t.name, variant.Name, err)
+		}
+		return &EnumValue{Variant: variant.Name, Fields: fields}, nil
+	}
+
+	fields, err := c.fieldsFromJSON(description.Fields, obj, interpreter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", t.name, err)
+	}
+	return &StructValue{Fields: fields}, nil
+}
+
+func (c *Codec) listFromJSON(itemTypes []*abiType, obj oj.OJsonObject, interpreter *ei.ExprInterpreter) ([]interface{}, error) {
+	list, isList := obj.(*oj.OJsonList)
+	if !isList {
+		return nil, errors.New("expected a JSON list")
+	}
+	items := list.AsList()
+	if len(items) != len(itemTypes) {
+		return nil, fmt.Errorf("expected %d items, got %d", len(itemTypes), len(items))
+	}
+
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		var err error
+		values[i], err = c.valueFromJSON(itemTypes[i], item, interpreter)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return values, nil
+}
+
+// fieldsFromJSON accepts a map of the fields by name, or a list of the fields in order
+func (c *Codec) fieldsFromJSON(fields []*Field, obj oj.OJsonObject, interpreter *ei.ExprInterpreter) ([]*FieldValue, error) {
+	fieldObjs := make([]oj.OJsonObject, len(fields))
+	switch typed := obj.(type) {
+	case *oj.OJsonList:
+		items := typed.AsList()
+		if len(items) != len(fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(fields), len(items))
+		}
+		copy(fieldObjs, items)
+	case *oj.OJsonMap:
+		for _, kvp := range typed.OrderedKV {
+			index := fieldIndex(fields, kvp.Key)
+			if index < 0 {
+				return nil, fmt.Errorf("unknown field %s", kvp.Key)
+			}
+			fieldObjs[index] = kvp.Value
+		}
+	default:
+		return nil, errors.New("fields should be a JSON map or list")
+	}
+
+	fieldValues := make([]*FieldValue, len(fields))
+	for i, field := range fields {
+		if fieldObjs[i] == nil {
+			return nil, fmt.Errorf("missing field %s", field.Name)
+		}
+		fieldType, err := parseType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.valueFromJSON(fieldType, fieldObjs[i], interpreter)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fieldValues[i] = &FieldValue{Name: field.Name, Value: value}
+	}
+	return fieldValues, nil
+}
+
+func fieldIndex(fields []*Field, name string) int {
+	for i, field := range fields {
+		if field.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// variantFromJSON reads "Variant" or {"Variant": fields}; fields are nil in the first case
+func variantFromJSON(obj oj.OJsonObject) (string, oj.OJsonObject, error) {
+	switch typed := obj.(type) {
+	case *oj.OJsonString:
+		return typed.Value, nil, nil
+	case *oj.OJsonMap:
+		if len(typed.OrderedKV) != 1 {
+			return "", nil, errors.New("enum value should be a map with a single key, the variant")
+		}
+		return typed.OrderedKV[0].Key, typed.OrderedKV[0].Value, nil
+	default:
+		return "", nil, errors.New(`enum value should be "Variant" or {"Variant": fields}`)
+	}
+}
+
+func bigIntFromJSON(obj oj.OJsonObject, signed bool, interpreter *ei.ExprInterpreter) (*big.Int, error) {
+	str, isStr := obj.(*oj.OJsonString)
+	if !isStr {
+		return nil, errors.New("number is not a JSON string")
+	}
+	bytes, err := interpreter.InterpretString(str.Value)
+	if err != nil {
+		return nil, err
+	}
+	// explicitly signed expressions are in two's complement, all others are unsigned
+	if signed && (strings.HasPrefix(str.Value, "-") || strings.HasPrefix(str.Value, "+")) {
+		return twos.FromBytes(bytes), nil
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
+
+func boolFromJSON(obj oj.OJsonObject) (bool, error) {
+	switch typed := obj.(type) {
+	case *oj.OJsonBool:
+		return bool(*typed), nil
+	case *oj.OJsonString:
+		switch typed.Value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, errors.New(`bool should be "true" or "false"`)
+}
+
+func repeatType(t *abiType, count int) []*abiType {
+	types := make([]*abiType, count)
+	for i := range types {
+		types[i] = t
+	}
+	return types
+}
@@ -0,0 +1,168 @@
+package scenexpressionabi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// abiType is a parsed type name, e.g. "List<Option<u32>>"
+type abiType struct {
+	name string
+	args []*abiType
+}
+
+type fixedIntType struct {
+	size   int
+	signed bool
+}
+
+var fixedIntTypes = map[string]fixedIntType{
+	"u8":    {size: 1},
+	"u16":   {size: 2},
+	"u32":   {size: 4},
+	"u64":   {size: 8},
+	"usize": {size: 4},
+	"i8":    {size: 1, signed: true},
+	"i16":   {size: 2, signed: true},
+	"i32":   {size: 4, signed: true},
+	"i64":   {size: 8, signed: true},
+	"isize": {size: 4, signed: true},
+}
+
+// the types that are encoded as a length-prefixed byte slice when nested
+var bytesTypes = map[string]struct{}{
+	"bytes":                     {},
+	"ManagedBuffer":             {},
+	"BoxedBytes":                {},
+	"&[u8]":                     {},
+	"utf-8 string":              {},
+	"String":                    {},
+	"&str":                      {},
+	"TokenIdentifier":           {},
+	"EgldOrEsdtTokenIdentifier": {},
+}
+
+// the types that are encoded as a byte array of known length
+var fixedBytesTypes = map[string]int{
+	"Address":        32,
+	"ManagedAddress": 32,
+	"H256":           32,
+	"CodeMetadata":   2,
+}
+
+var listTypes = map[string]struct{}{
+	"List":       {},
+	"Vec":        {},
+	"ManagedVec": {},
+}
+
+// the multi-value types only occur at the top level of endpoint inputs and outputs, spanning several arguments
+const (
+	multiVariadic = "variadic"
+	multiOptional = "optional"
+	multiMulti    = "multi"
+)
+
+var multiTypeAliases = map[string]string{
+	"variadic":          multiVariadic,
+	"MultiValueEncoded": multiVariadic,
+	"MultiValueVec":     multiVariadic,
+	"optional":          multiOptional,
+	"OptionalValue":     multiOptional,
+	"multi":             multiMulti,
+}
+
+func parseType(typeName string) (*abiType, error) {
+	typeName = strings.TrimSpace(typeName)
+	openIndex := strings.IndexByte(typeName, '<')
+	if openIndex < 0 {
+		if len(typeName) == 0 || strings.ContainsAny(typeName, ">,") {
+			return nil, fmt.Errorf("invalid type name: %s", typeName)
+		}
+		return &abiType{name: typeName}, nil
+	}
+	if !strings.HasSuffix(typeName, ">") {
+		return nil, fmt.Errorf("invalid type name: %s", typeName)
+	}
+
+	parsed := &abiType{name: strings.TrimSpace(typeName[:openIndex])}
+	argsStr := typeName[openIndex+1 : len(typeName)-1]
+	depth := 0
+	start := 0
+	for i := 0; i <= len(argsStr); i++ {
+		if i < len(argsStr) {
+			switch argsStr[i] {
+			case '<':
+				depth++
+				continue
+			case '>':
+				depth--
+				if depth < 0 {
+					return nil, fmt.Errorf("invalid type name: %s", typeName)
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+
+		arg, err := parseType(argsStr[start:i])
+		if err != nil {
+			return nil, err
+		}
+		parsed.args = append(parsed.args, arg)
+		start = i + 1
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid type name: %s", typeName)
+	}
+
+	return parsed, nil
+}
+
+func (t *abiType) String() string {
+	if len(t.args) == 0 {
+		return t.name
+	}
+	args := make([]string, len(t.args))
+	for i, arg := range t.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s<%s>", t.name, strings.Join(args, ","))
+}
+
+func (t *abiType) singleArg() (*abiType, error) {
+	if len(t.args) != 1 {
+		return nil, fmt.Errorf("type %s should have exactly 1 type argument", t)
+	}
+	return t.args[0], nil
+}
+
+// arrayLength returns the length of arrayN<T> types, e.g. 32 for "array32<u8>"
+func (t *abiType) arrayLength() (int, bool) {
+	if !strings.HasPrefix(t.name, "array") {
+		return 0, false
+	}
+	length, err := strconv.Atoi(t.name[len("array"):])
+	if err != nil || length < 0 {
+		return 0, false
+	}
+	return length, true
+}
+
+// multiKind returns the kind of multi-value type, or "" for regular types; MultiValue2<A,B>, MultiValue3<A,B,C>, etc. are all "multi"
+func (t *abiType) multiKind() string {
+	kind, isAlias := multiTypeAliases[t.name]
+	if isAlias {
+		return kind
+	}
+	if strings.HasPrefix(t.name, "MultiValue") {
+		return multiMulti
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package scenexpressionabi
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// The typed values handled by the codec are:
+//   - *big.Int for all integers, including BigUint and BigInt (encoding also accepts int, int64, uint64 and uint32);
+//   - bool;
+//   - []byte for byte slices, strings, token identifiers and addresses (encoding also accepts string);
+//   - []interface{} for lists, arrays, tuples and multi-values;
+//   - nil for an empty Option or optional, the value itself otherwise;
+//   - *StructValue for structs (encoding also accepts map[string]interface{});
+//   - *EnumValue for enums (encoding also accepts the variant name, as a string, for fieldless variants).
+
+// FieldValue is a named field of a struct or enum variant.
+type FieldValue struct {
+	Name  string
+	Value interface{}
+}
+
+// StructValue is a struct value, with its fields in ABI order.
+type StructValue struct {
+	Fields []*FieldValue
+}
+
+// EnumValue is an enum value: a variant with its fields, if any.
+type EnumValue struct {
+	Variant string
+	Fields  []*FieldValue
+}
+
+// Field finds a field of the struct by name, returning nil if missing.
+func (value *StructValue) Field(name string) interface{} {
+	return findField(value.Fields, name)
+}
+
+// Field finds a field of the enum variant by name, returning nil if missing.
+func (value *EnumValue) Field(name string) interface{} {
+	return findField(value.Fields, name)
+}
+
+func findField(fields []*FieldValue, name string) interface{} {
+	for _, field := range fields {
+		if field.Name == name {
+			return field.Value
+		}
+	}
+	return nil
+}
+
+// FormatValue renders a typed value for humans, e.g. in test error messages.
+func FormatValue(value interface{}) string {
+	var builder strings.Builder
+	formatValue(&builder, value)
+	return builder.String()
+}
+
+func formatValue(builder *strings.Builder, value interface{}) {
+	switch typed := value.(type) {
+	case nil:
+		builder.WriteString("None")
+	case *big.Int:
+		builder.WriteString(typed.String())
+	case bool:
+		builder.WriteString(fmt.Sprintf("%t", typed))
+	case []byte:
+		builder.WriteString(formatBytes(typed))
+	case []interface{}:
+		builder.WriteString("[")
+		for i, item := range typed {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			formatValue(builder, item)
+		}
+		builder.WriteString("]")
+	case *StructValue:
+		formatFields(builder, typed.Fields)
+	case *EnumValue:
+		builder.WriteString(typed.Variant)
+		if len(typed.Fields) > 0 {
+			builder.WriteString(" ")
+			formatFields(builder, typed.Fields)
+		}
+	default:
+		builder.WriteString(fmt.Sprintf("%v", typed))
+	}
+}
+
+func formatFields(builder *strings.Builder, fields []*FieldValue) {
+	builder.WriteString("{")
+	for i, field := range fields {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(field.Name)
+		builder.WriteString(": ")
+		formatValue(builder, field.Value)
+	}
+	builder.WriteString("}")
+}
+
+// byte slices are shown as strings when readable, as hex otherwise
+func formatBytes(value []byte) string {
+	if len(value) == 0 {
+		return `""`
+	}
+	for _, char := range string(value) {
+		if char == unicode.ReplacementChar || !unicode.IsPrint(char) {
+			return fmt.Sprintf("0x%x", value)
+		}
+	}
+	return fmt.Sprintf("%q", value)
+}
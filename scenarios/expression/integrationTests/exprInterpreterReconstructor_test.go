@@ -182,6 +182,52 @@ func TestSCAddress(t *testing.T) {
 	require.Equal(t, "sc:12345678901234567890120#73", er.Reconstruct(result, mer.AddressHint))
 }
 
+func TestBech32Address(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	er := mer.ExprReconstructor{}
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+
+	result, err := ei.InterpretString("bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	require.Nil(t, err)
+	require.Equal(t, alice, result)
+	require.Equal(t, "bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", er.Reconstruct(result, mer.AddressHint))
+
+	// auto-detected, without prefix
+	result, err = ei.InterpretString("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	require.Nil(t, err)
+	require.Equal(t, alice, result)
+
+	// real smart contract addresses also begin with zeros
+	result, err = ei.InterpretString("erd1qqqqqqqqqqqqqpgqfzydqmdw7m2vazsp6u5p95yxz76t2p9rd8ss0zp9ts")
+	require.Nil(t, err)
+	require.Equal(t, "bech32:erd1qqqqqqqqqqqqqpgqfzydqmdw7m2vazsp6u5p95yxz76t2p9rd8ss0zp9ts", er.Reconstruct(result, mer.AddressHint))
+
+	// mock addresses keep their form
+	result, err = ei.InterpretString("address:an_address")
+	require.Nil(t, err)
+	require.Equal(t, "address:an_address", er.Reconstruct(result, mer.AddressHint))
+}
+
+func TestBech32AddressErrors(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+
+	// bad checksum
+	_, err := ei.InterpretString("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6tq")
+	require.NotNil(t, err)
+
+	// wrong human-readable part
+	_, err = ei.InterpretString("bech32:bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	require.NotNil(t, err)
+
+	// not 32 bytes
+	_, err = ei.InterpretString("erd1xyerxdp4xcmnswfsxyeqqzq40r")
+	require.NotNil(t, err)
+
+	// mixed case
+	_, err = ei.InterpretString("erd1QYU5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	require.NotNil(t, err)
+}
+
 func TestSCAddressWithShardId(t *testing.T) {
 	ei := mei.ExprInterpreter{}
 	er := mer.ExprReconstructor{}
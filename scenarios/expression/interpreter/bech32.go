@@ -0,0 +1,143 @@
+package scenexpressioninterpreter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Bech32AddressHRP is the human-readable part of bech32 addresses, e.g. "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th".
+const Bech32AddressHRP = "erd"
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+const bech32ChecksumLength = 6
+
+var bech32Generator = []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// EncodeBech32Address converts a 32-byte address to its bech32 form.
+func EncodeBech32Address(address []byte) (string, error) {
+	if len(address) != 32 {
+		return "", fmt.Errorf("bech32 address must be 32 bytes long, got %d", len(address))
+	}
+
+	data, err := convertBits(address, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	checksum := bech32Checksum(Bech32AddressHRP, data)
+	var builder strings.Builder
+	builder.WriteString(Bech32AddressHRP)
+	builder.WriteByte('1')
+	for _, value := range append(data, checksum...) {
+		builder.WriteByte(bech32Charset[value])
+	}
+	return builder.String(), nil
+}
+
+// DecodeBech32Address converts a bech32 address back to its 32 bytes,
+// checking the checksum and the human-readable part.
+func DecodeBech32Address(str string) ([]byte, error) {
+	if strings.ToLower(str) != str && strings.ToUpper(str) != str {
+		return nil, fmt.Errorf("bech32 address has mixed case: %s", str)
+	}
+	str = strings.ToLower(str)
+
+	separatorIndex := strings.LastIndexByte(str, '1')
+	if separatorIndex < 1 || separatorIndex+1+bech32ChecksumLength > len(str) {
+		return nil, fmt.Errorf("invalid bech32 address: %s", str)
+	}
+
+	hrp := str[:separatorIndex]
+	if hrp != Bech32AddressHRP {
+		return nil, fmt.Errorf("bech32 address should start with %s1: %s", Bech32AddressHRP, str)
+	}
+
+	data := make([]byte, 0, len(str)-separatorIndex-1)
+	for _, char := range str[separatorIndex+1:] {
+		value := strings.IndexRune(bech32Charset, char)
+		if value < 0 {
+			return nil, fmt.Errorf("invalid character %q in bech32 address: %s", char, str)
+		}
+		data = append(data, byte(value))
+	}
+
+	if bech32Polymod(append(bech32ExpandHRP(hrp), data...)) != 1 {
+		return nil, fmt.Errorf("invalid bech32 address checksum: %s", str)
+	}
+
+	address, err := convertBits(data[:len(data)-bech32ChecksumLength], 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bech32 address %s: %w", str, err)
+	}
+	if len(address) != 32 {
+		return nil, fmt.Errorf("bech32 address must be 32 bytes long, got %d: %s", len(address), str)
+	}
+
+	return address, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i, generator := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				checksum ^= generator
+			}
+		}
+	}
+	return checksum
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+func bech32Checksum(hrp string, data []byte) []byte {
+	values := append(bech32ExpandHRP(hrp), data...)
+	values = append(values, make([]byte, bech32ChecksumLength)...)
+	polymod := bech32Polymod(values) ^ 1
+
+	checksum := make([]byte, bech32ChecksumLength)
+	for i := range checksum {
+		checksum[i] = byte((polymod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+// convertBits regroups a sequence of fromBits-bit values into toBits-bit values.
+func convertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	accumulator := uint32(0)
+	numBits := uint(0)
+	maxValue := uint32(1<<toBits) - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, value := range data {
+		accumulator = accumulator<<fromBits | uint32(value)
+		numBits += fromBits
+		for numBits >= toBits {
+			numBits -= toBits
+			result = append(result, byte((accumulator>>numBits)&maxValue))
+		}
+	}
+
+	if pad {
+		if numBits > 0 {
+			result = append(result, byte((accumulator<<(toBits-numBits))&maxValue))
+		}
+	} else if numBits >= fromBits || (accumulator<<(toBits-numBits))&maxValue != 0 {
+		return nil, errors.New("invalid padding")
+	}
+
+	return result, nil
+}
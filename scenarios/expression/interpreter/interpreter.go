@@ -16,6 +16,7 @@ var strPrefixes = []string{"str:", "``", "''"}
 
 const addrPrefix = "address:"
 const scAddrPrefix = "sc:"
+const bech32Prefix = "bech32:"
 
 const filePrefix = "file:"
 const keccak256Prefix = "keccak256:"
@@ -88,6 +89,7 @@ func (ei *ExprInterpreter) InterpretSubTree(obj oj.OJsonObject) ([]byte, error)
 // - "true"/"false"
 // - "address:..."
 // - "sc:..." (also an address)
+// - "bech32:erd1..." or just "erd1..." (a real address, e.g. copied from an explorer)
 // - "file:..."
 // - "keccak256:..."
 // - concatenation using |
@@ -167,6 +169,14 @@ func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
 		return scExpression(addrArgument)
 	}
 
+	// bech32 address, with or without prefix
+	if strings.HasPrefix(strRaw, bech32Prefix) {
+		return DecodeBech32Address(strRaw[len(bech32Prefix):])
+	}
+	if strings.HasPrefix(strRaw, Bech32AddressHRP+"1") {
+		return DecodeBech32Address(strRaw)
+	}
+
 	// fixed width numbers
 	parsed, result, err := ei.tryInterpretFixedWidth(strRaw)
 	if err != nil {
@@ -50,6 +50,40 @@ func (er *ExprReconstructor) Reconstruct(value []byte, hint ExprReconstructorHin
 	}
 }
 
+// ReconstructExpression yields an expression that interprets back to exactly the same bytes,
+// unlike Reconstruct, which can truncate values or add explanations.
+// It is used when writing actual values into scenarios.
+func (er *ExprReconstructor) ReconstructExpression(value []byte, hint ExprReconstructorHint) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	switch hint {
+	case NumberHint:
+		// leading zeros would get lost
+		if value[0] != 0 {
+			return big.NewInt(0).SetBytes(value).String()
+		}
+	case StrHint:
+		if canWriteAsStr(value) {
+			return fmt.Sprintf("str:%s", string(value))
+		}
+	case AddressHint:
+		if len(value) == 32 {
+			return addressPretty(value)
+		}
+	case NoHint:
+		if canWriteAsStr(value) {
+			return fmt.Sprintf("str:%s", string(value))
+		}
+		if value[0] != 0 && len(value) < maxBytesInterpretedAsNumber {
+			return big.NewInt(0).SetBytes(value).String()
+		}
+	}
+
+	return "0x" + hex.EncodeToString(value)
+}
+
 func (er *ExprReconstructor) ReconstructFromBigInt(value *big.Int) string {
 	return er.Reconstruct(value.Bytes(), NumberHint)
 }
@@ -89,28 +123,57 @@ func addressPretty(value []byte) string {
 		if value[31] == byte('_') {
 			addrStr := string(value[ei.SCAddressNumLeadingZeros:])
 			addrStr = strings.TrimRight(addrStr, "_")
-			return fmt.Sprintf("sc:%s", addrStr)
+			if isMockAddressName(addrStr, true) {
+				return fmt.Sprintf("sc:%s", addrStr)
+			}
 		} else {
 			// last byte is the shard id and is explicit
 			addrStr := string(value[ei.SCAddressNumLeadingZeros:31])
 			addrStr = strings.TrimRight(addrStr, "_")
 			shard_id := value[31]
-			return fmt.Sprintf("sc:%s#%x", addrStr, shard_id)
+			if isMockAddressName(addrStr, value[30] == byte('_')) {
+				return fmt.Sprintf("sc:%s#%x", addrStr, shard_id)
+			}
 		}
+
+		return bech32Pretty(value)
 	}
 
 	// regular addresses
 	if value[31] == byte('_') {
 		addrStr := string(value)
 		addrStr = strings.TrimRight(addrStr, "_")
-		return fmt.Sprintf("address:%s", addrStr)
+		if isMockAddressName(addrStr, true) {
+			return fmt.Sprintf("address:%s", addrStr)
+		}
 	} else {
 		// last byte is the shard id and is explicit
 		addrStr := string(value[:31])
 		addrStr = strings.TrimRight(addrStr, "_")
 		shard_id := value[31]
-		return fmt.Sprintf("address:%s#%02x", addrStr, shard_id)
+		if isMockAddressName(addrStr, value[30] == byte('_')) {
+			return fmt.Sprintf("address:%s#%02x", addrStr, shard_id)
+		}
 	}
+
+	return bech32Pretty(value)
+}
+
+// isMockAddressName tells if an address was generated from a name ("address:..." or "sc:..."), as opposed to being a real address:
+// the name is either padded with underscores, or readable
+func isMockAddressName(name string, isPadded bool) bool {
+	if strings.ContainsAny(name, "#|") {
+		return false
+	}
+	return isPadded || len(name) == 0 || canInterpretAsString([]byte(name))
+}
+
+func bech32Pretty(value []byte) string {
+	bech32Address, err := ei.EncodeBech32Address(value)
+	if err != nil {
+		return unknownByteArrayPretty(value)
+	}
+	return fmt.Sprintf("bech32:%s", bech32Address)
 }
 
 func canInterpretAsString(bytes []byte) bool {
@@ -125,6 +188,12 @@ func canInterpretAsString(bytes []byte) bool {
 	return true
 }
 
+// canWriteAsStr tells if a "str:" expression yields the value: the interpreter would split it at "|",
+// and quotes and backslashes would need escaping in the scenario file
+func canWriteAsStr(bytes []byte) bool {
+	return canInterpretAsString(bytes) && !strings.ContainsAny(string(bytes), "|\"\\")
+}
+
 func codePretty(bytes []byte) string {
 	if len(bytes) == 0 {
 		return ""
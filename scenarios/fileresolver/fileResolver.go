@@ -13,4 +13,8 @@ type FileResolver interface {
 
 	// ResolveFileValue converts a value prefixed with "file:" and replaces it with the file contents.
 	ResolveFileValue(value string) ([]byte, error)
+
+	// ResolveExternalSteps loads an external steps file, with its "{{name}}" placeholders replaced by the parameters,
+	// and yields the resolver to use inside that file. Files that include themselves, directly or not, are rejected.
+	ResolveExternalSteps(value string, parameters map[string]string) ([]byte, FileResolver, error)
 }
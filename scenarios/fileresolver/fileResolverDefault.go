@@ -1,8 +1,10 @@
 package scenfileresolver
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 )
 
 var _ FileResolver = (*DefaultFileResolver)(nil)
@@ -11,6 +13,9 @@ var _ FileResolver = (*DefaultFileResolver)(nil)
 type DefaultFileResolver struct {
 	contextPath              string
 	contractPathReplacements map[string]string
+
+	// the external steps files that include the current one, outermost first
+	includingPaths []string
 }
 
 // NewDefaultFileResolver yields a new DefaultFileResolver instance.
@@ -33,6 +38,7 @@ func (fr *DefaultFileResolver) Clone() FileResolver {
 	return &DefaultFileResolver{
 		contextPath:              fr.contextPath,
 		contractPathReplacements: fr.contractPathReplacements,
+		includingPaths:           fr.includingPaths,
 	}
 }
 
@@ -66,3 +72,41 @@ func (fr *DefaultFileResolver) ResolveFileValue(value string) ([]byte, error) {
 
 	return scCode, nil
 }
+
+// ResolveExternalSteps loads an external steps file, with its "{{name}}" placeholders replaced by the parameters,
+// and yields the resolver to use inside that file. Files that include themselves, directly or not, are rejected.
+func (fr *DefaultFileResolver) ResolveExternalSteps(value string, parameters map[string]string) ([]byte, FileResolver, error) {
+	fullPath, err := filepath.Abs(fr.ResolveAbsolutePath(value))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inclusionChain := fr.includingPaths
+	if len(fr.contextPath) > 0 {
+		contextPath, err := filepath.Abs(fr.contextPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		inclusionChain = append(append([]string{}, inclusionChain...), contextPath)
+	}
+	for _, includingPath := range inclusionChain {
+		if includingPath == fullPath {
+			return nil, nil, fmt.Errorf("cyclic external steps: %s -> %s", strings.Join(inclusionChain, " -> "), fullPath)
+		}
+	}
+
+	contents, err := ioutil.ReadFile(fullPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	contents, err = ReplacePlaceholders(contents, parameters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot resolve external steps %s: %w", value, err)
+	}
+
+	return contents, &DefaultFileResolver{
+		contextPath:              fullPath,
+		contractPathReplacements: fr.contractPathReplacements,
+		includingPaths:           inclusionChain,
+	}, nil
+}
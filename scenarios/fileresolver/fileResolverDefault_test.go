@@ -0,0 +1,83 @@
+package scenfileresolver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, dir string, name string, contents string) string {
+	path := filepath.Join(dir, name)
+	require.Nil(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	replaced, err := ReplacePlaceholders(
+		[]byte(`{"from": "address:{{owner}}", "value": "{{ amount }}", "comment": "{{comment}}"}`),
+		map[string]string{"owner": "alice", "amount": "1,000", "comment": `say "hi"`})
+	require.Nil(t, err)
+	require.Equal(t, `{"from": "address:alice", "value": "1,000", "comment": "say \"hi\""}`, string(replaced))
+
+	_, err = ReplacePlaceholders([]byte(`"{{owner}}"`), map[string]string{})
+	require.EqualError(t, err, "missing parameters: owner")
+
+	_, err = ReplacePlaceholders([]byte(`"{{owner}}"`), map[string]string{"owner": "alice", "amount": "5"})
+	require.EqualError(t, err, "unused parameters: amount")
+
+	replaced, err = ReplacePlaceholders([]byte(`"no placeholders"`), nil)
+	require.Nil(t, err)
+	require.Equal(t, `"no placeholders"`, string(replaced))
+}
+
+func TestResolveExternalSteps(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := writeTestFile(t, dir, "main.scen.json", `{}`)
+	writeTestFile(t, dir, "outer.steps.json", `{"path": "inner.steps.json", "parameters": {"who": "{{owner}}"}}`)
+	writeTestFile(t, dir, "inner.steps.json", `{"from": "address:{{who}}"}`)
+
+	resolver := NewDefaultFileResolver()
+	resolver.SetContext(mainPath)
+
+	contents, outerResolver, err := resolver.ResolveExternalSteps("outer.steps.json", map[string]string{"owner": "alice"})
+	require.Nil(t, err)
+	require.Equal(t, `{"path": "inner.steps.json", "parameters": {"who": "alice"}}`, string(contents))
+
+	contents, _, err = outerResolver.ResolveExternalSteps("inner.steps.json", map[string]string{"who": "alice"})
+	require.Nil(t, err)
+	require.Equal(t, `{"from": "address:alice"}`, string(contents))
+
+	_, _, err = resolver.ResolveExternalSteps("outer.steps.json", nil)
+	require.NotNil(t, err)
+}
+
+func TestResolveExternalStepsCycle(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := writeTestFile(t, dir, "main.scen.json", `{}`)
+	writeTestFile(t, dir, "a.steps.json", `{}`)
+	writeTestFile(t, dir, "b.steps.json", `{}`)
+
+	resolver := NewDefaultFileResolver()
+	resolver.SetContext(mainPath)
+
+	_, selfResolver, err := resolver.ResolveExternalSteps("main.scen.json", nil)
+	require.NotNil(t, err)
+	require.Nil(t, selfResolver)
+
+	_, aResolver, err := resolver.ResolveExternalSteps("a.steps.json", nil)
+	require.Nil(t, err)
+	_, bResolver, err := aResolver.ResolveExternalSteps("b.steps.json", nil)
+	require.Nil(t, err)
+
+	_, _, err = bResolver.ResolveExternalSteps("a.steps.json", nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cyclic external steps")
+
+	// the same file can be included several times, as long as it does not include itself
+	_, _, err = aResolver.ResolveExternalSteps("b.steps.json", nil)
+	require.Nil(t, err)
+	_, _, err = bResolver.Clone().ResolveExternalSteps("main.scen.json", nil)
+	require.NotNil(t, err)
+}
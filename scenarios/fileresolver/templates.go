@@ -0,0 +1,52 @@
+package scenfileresolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// ReplacePlaceholders replaces the "{{name}}" placeholders in a file with the parameter values.
+// Placeholders are expected inside JSON strings, so the values are escaped accordingly.
+// All placeholders must have a value, and all parameters must be used.
+func ReplacePlaceholders(contents []byte, parameters map[string]string) ([]byte, error) {
+	usedParameters := make(map[string]struct{})
+	var missingParameters []string
+
+	replaced := placeholderRegexp.ReplaceAllFunc(contents, func(placeholder []byte) []byte {
+		name := string(placeholderRegexp.FindSubmatch(placeholder)[1])
+		value, found := parameters[name]
+		if !found {
+			missingParameters = append(missingParameters, name)
+			return placeholder
+		}
+		usedParameters[name] = struct{}{}
+		return []byte(escapeJSONStringContents(value))
+	})
+
+	if len(missingParameters) > 0 {
+		return nil, fmt.Errorf("missing parameters: %s", strings.Join(missingParameters, ", "))
+	}
+
+	var unusedParameters []string
+	for name := range parameters {
+		if _, used := usedParameters[name]; !used {
+			unusedParameters = append(unusedParameters, name)
+		}
+	}
+	if len(unusedParameters) > 0 {
+		sort.Strings(unusedParameters)
+		return nil, fmt.Errorf("unused parameters: %s", strings.Join(unusedParameters, ", "))
+	}
+
+	return replaced, nil
+}
+
+func escapeJSONStringContents(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted[1 : len(quoted)-1])
+}
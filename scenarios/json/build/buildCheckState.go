@@ -0,0 +1,181 @@
+package scenjsonbuild
+
+import (
+	"math/big"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+)
+
+// CheckStateBuilder builds a checkState step.
+type CheckStateBuilder struct {
+	step *mj.CheckStateStep
+}
+
+// CheckState starts building a checkState step.
+// Only the accounts added are allowed to exist, unless MoreAccountsAllowed is called.
+func CheckState() *CheckStateBuilder {
+	return &CheckStateBuilder{
+		step: &mj.CheckStateStep{
+			CheckAccounts: &mj.CheckAccounts{},
+			StateRootHash: mj.JSONCheckBytesUnspecified(),
+		},
+	}
+}
+
+// Comment sets the step comment.
+func (b *CheckStateBuilder) Comment(comment string) *CheckStateBuilder {
+	b.step.Comment = comment
+	return b
+}
+
+// Account adds an account check.
+func (b *CheckStateBuilder) Account(account *CheckAccountBuilder) *CheckStateBuilder {
+	b.step.CheckAccounts.Accounts = append(b.step.CheckAccounts.Accounts, account.Build())
+	return b
+}
+
+// MoreAccountsAllowed allows accounts other than the ones checked.
+func (b *CheckStateBuilder) MoreAccountsAllowed() *CheckStateBuilder {
+	b.step.CheckAccounts.MoreAccountsAllowed = true
+	return b
+}
+
+// Build yields the step.
+func (b *CheckStateBuilder) Build() *mj.CheckStateStep {
+	return b.step
+}
+
+// CheckAccountBuilder builds an account check, for checkState steps.
+type CheckAccountBuilder struct {
+	account *mj.CheckAccount
+}
+
+// CheckAccount starts building an account check.
+// Like in scenario files, fields not set must be zero or empty, e.g. the nonce, the code, the storage.
+func CheckAccount(address Value) *CheckAccountBuilder {
+	return &CheckAccountBuilder{
+		account: &mj.CheckAccount{
+			Address:       address.bytesFromString(),
+			Nonce:         mj.JSONCheckUint64Unspecified(),
+			Balance:       mj.JSONCheckBigIntUnspecified(),
+			Username:      mj.JSONCheckBytesUnspecified(),
+			Code:          mj.JSONCheckBytesUnspecified(),
+			Owner:         mj.JSONCheckBytesUnspecified(),
+			AsyncCallData: mj.JSONCheckBytesUnspecified(),
+		},
+	}
+}
+
+// Comment sets the account comment.
+func (b *CheckAccountBuilder) Comment(comment string) *CheckAccountBuilder {
+	b.account.Comment = comment
+	return b
+}
+
+// Nonce sets the expected nonce.
+func (b *CheckAccountBuilder) Nonce(nonce uint64) *CheckAccountBuilder {
+	b.account.Nonce = checkUint64(nonce)
+	return b
+}
+
+// Balance sets the expected EGLD balance.
+func (b *CheckAccountBuilder) Balance(balance *big.Int) *CheckAccountBuilder {
+	b.account.Balance = checkBigInt(balance)
+	return b
+}
+
+// Username sets the expected username.
+func (b *CheckAccountBuilder) Username(username Value) *CheckAccountBuilder {
+	b.account.Username = username.checkBytes()
+	return b
+}
+
+// Code sets the expected contract code.
+func (b *CheckAccountBuilder) Code(code Value) *CheckAccountBuilder {
+	b.account.Code = code.checkBytes()
+	return b
+}
+
+// Owner sets the expected contract owner.
+func (b *CheckAccountBuilder) Owner(owner Value) *CheckAccountBuilder {
+	b.account.Owner = owner.checkBytes()
+	return b
+}
+
+// Storage adds an expected storage entry; Star matches any value.
+func (b *CheckAccountBuilder) Storage(key Value, value Value) *CheckAccountBuilder {
+	b.account.CheckStorage = append(b.account.CheckStorage, &mj.CheckStorageKeyValuePair{
+		Key:        key.bytesFromString(),
+		CheckValue: value.checkBytes(),
+	})
+	return b
+}
+
+// MoreStorageAllowed allows storage entries other than the ones checked.
+func (b *CheckAccountBuilder) MoreStorageAllowed() *CheckAccountBuilder {
+	b.account.MoreStorageAllowed = true
+	return b
+}
+
+// AnyStorage skips the storage check.
+func (b *CheckAccountBuilder) AnyStorage() *CheckAccountBuilder {
+	b.account.IgnoreStorage = true
+	return b
+}
+
+// ESDT adds an expected fungible token balance.
+func (b *CheckAccountBuilder) ESDT(tokenIdentifier Value, balance *big.Int) *CheckAccountBuilder {
+	instance := mj.NewCheckESDTInstance()
+	instance.Balance = checkBigInt(balance)
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.Instances = append(esdtData.Instances, instance)
+	return b
+}
+
+// NFT adds an expected balance of a non-fungible or semi-fungible token instance.
+func (b *CheckAccountBuilder) NFT(tokenIdentifier Value, nonce uint64, balance *big.Int) *CheckAccountBuilder {
+	instance := mj.NewCheckESDTInstance()
+	instance.Nonce = checkUint64(nonce)
+	instance.Balance = checkBigInt(balance)
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.Instances = append(esdtData.Instances, instance)
+	return b
+}
+
+// ESDTRoles sets the expected local roles for a token.
+func (b *CheckAccountBuilder) ESDTRoles(tokenIdentifier Value, roles ...string) *CheckAccountBuilder {
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.Roles = append(esdtData.Roles, roles...)
+	return b
+}
+
+// MoreESDTTokensAllowed allows tokens other than the ones checked.
+func (b *CheckAccountBuilder) MoreESDTTokensAllowed() *CheckAccountBuilder {
+	b.account.MoreESDTTokensAllowed = true
+	return b
+}
+
+// AnyESDT skips the ESDT check.
+func (b *CheckAccountBuilder) AnyESDT() *CheckAccountBuilder {
+	b.account.IgnoreESDT = true
+	return b
+}
+
+func (b *CheckAccountBuilder) esdtData(tokenIdentifier Value) *mj.CheckESDTData {
+	for _, esdtData := range b.account.CheckESDTData {
+		if esdtData.TokenIdentifier.Original == tokenIdentifier.Expression {
+			return esdtData
+		}
+	}
+
+	esdtData := &mj.CheckESDTData{
+		TokenIdentifier: tokenIdentifier.bytesFromString(),
+	}
+	b.account.CheckESDTData = append(b.account.CheckESDTData, esdtData)
+	return esdtData
+}
+
+// Build yields the account check.
+func (b *CheckAccountBuilder) Build() *mj.CheckAccount {
+	return b.account
+}
@@ -0,0 +1,120 @@
+package scenjsonbuild
+
+import (
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+)
+
+// StepBuilder is implemented by all step builders.
+type StepBuilder interface {
+	BuildStep() mj.Step
+}
+
+// BuildStep yields the step, as a generic scenario step.
+func (b *SetStateBuilder) BuildStep() mj.Step {
+	return b.Build()
+}
+
+// BuildStep yields the step, as a generic scenario step.
+func (b *CheckStateBuilder) BuildStep() mj.Step {
+	return b.Build()
+}
+
+// BuildStep yields the step, as a generic scenario step.
+func (b *TxBuilder) BuildStep() mj.Step {
+	return b.Build()
+}
+
+// BuildStep yields the step, as a generic scenario step.
+func (b *BlockBuilder) BuildStep() mj.Step {
+	return b.Build()
+}
+
+// ScenarioBuilder builds a whole scenario, e.g. to be serialized with scenjsonwrite.
+type ScenarioBuilder struct {
+	scenario *mj.Scenario
+}
+
+// Scenario starts building a scenario, which checks gas and uses the default gas schedule.
+func Scenario(name string) *ScenarioBuilder {
+	return &ScenarioBuilder{
+		scenario: &mj.Scenario{
+			Name:        name,
+			CheckGas:    true,
+			GasSchedule: mj.GasScheduleDefault,
+		},
+	}
+}
+
+// Comment sets the scenario comment.
+func (b *ScenarioBuilder) Comment(comment string) *ScenarioBuilder {
+	b.scenario.Comment = comment
+	return b
+}
+
+// CheckGas sets whether the gas remaining after transactions is checked.
+func (b *ScenarioBuilder) CheckGas(checkGas bool) *ScenarioBuilder {
+	b.scenario.CheckGas = checkGas
+	return b
+}
+
+// GasSchedule sets the gas schedule.
+func (b *ScenarioBuilder) GasSchedule(gasSchedule mj.GasSchedule) *ScenarioBuilder {
+	b.scenario.GasSchedule = gasSchedule
+	return b
+}
+
+// Steps adds steps to the scenario.
+func (b *ScenarioBuilder) Steps(steps ...StepBuilder) *ScenarioBuilder {
+	for _, step := range steps {
+		b.scenario.Steps = append(b.scenario.Steps, step.BuildStep())
+	}
+	return b
+}
+
+// Step adds an already built step to the scenario, e.g. one that was executed.
+func (b *ScenarioBuilder) Step(step mj.Step) *ScenarioBuilder {
+	b.scenario.Steps = append(b.scenario.Steps, step)
+	return b
+}
+
+// Build yields the scenario.
+func (b *ScenarioBuilder) Build() *mj.Scenario {
+	return b.scenario
+}
+
+// BlockBuilder builds a block step, grouping transactions executed in the same block.
+type BlockBuilder struct {
+	step *mj.BlockStep
+}
+
+// Block starts building a block step.
+func Block() *BlockBuilder {
+	return &BlockBuilder{
+		step: &mj.BlockStep{},
+	}
+}
+
+// Comment sets the step comment.
+func (b *BlockBuilder) Comment(comment string) *BlockBuilder {
+	b.step.Comment = comment
+	return b
+}
+
+// CurrentBlockInfo sets the info of the block.
+func (b *BlockBuilder) CurrentBlockInfo(blockInfo *BlockInfoBuilder) *BlockBuilder {
+	b.step.CurrentBlockInfo = blockInfo.Build()
+	return b
+}
+
+// Steps adds steps to the block.
+func (b *BlockBuilder) Steps(steps ...StepBuilder) *BlockBuilder {
+	for _, step := range steps {
+		b.step.Steps = append(b.step.Steps, step.BuildStep())
+	}
+	return b
+}
+
+// Build yields the step.
+func (b *BlockBuilder) Build() *mj.BlockStep {
+	return b.step
+}
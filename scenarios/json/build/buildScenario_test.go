@@ -0,0 +1,184 @@
+package scenjsonbuild
+
+import (
+	"math/big"
+	"testing"
+
+	am "github.com/multiversx/mx-chain-vm-v1_3-go/scenarioexec"
+	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	mjparse "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/parse"
+	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+	"github.com/stretchr/testify/require"
+)
+
+func buildExampleScenario() *mj.Scenario {
+	token := Str("TOKEN-123456")
+	nft := Str("NFT-abcdef")
+
+	return Scenario("built scenario").
+		Comment("built in code").
+		Steps(
+			SetState().
+				Account(Account(Address("owner")).
+					Nonce(1).
+					Balance(big.NewInt(1000000)).
+					ESDT(token, big.NewInt(500)).
+					NFT(nft, 2, big.NewInt(1))).
+				Account(Account(SC("adder")).
+					Storage(Str("sum"), Uint(5)).
+					Code(Bytes([]byte{0x00, 0x61, 0x73, 0x6d})).
+					Owner(Address("owner"))).
+				NewAddress(Address("owner"), 1, SC("adder-2")).
+				CurrentBlockInfo(BlockInfo().Nonce(10).Timestamp(1234)),
+			ScCall("add").
+				From(Address("owner")).
+				To(SC("adder")).
+				Function("add").
+				Arguments(Uint(7), Str("a|b"), Bytes(nil)).
+				ESDT(token, 0, big.NewInt(100)).
+				GasLimit(5000000).
+				GasPrice(0).
+				Expect(Expect().
+					Out(Uint(12), Star()).
+					Status(0).
+					AnyGas().
+					NoLogs()),
+			Block().
+				CurrentBlockInfo(BlockInfo().Nonce(11)).
+				Steps(Transfer("transfer").
+					From(Address("owner")).
+					To(SC("adder")).
+					Value(big.NewInt(10))),
+			CheckState().
+				Account(CheckAccount(Address("owner")).
+					Nonce(2).
+					Balance(big.NewInt(999990)).
+					ESDT(token, big.NewInt(400)).
+					NFT(nft, 2, big.NewInt(1))).
+				Account(CheckAccount(SC("adder")).
+					Storage(Str("sum"), Uint(12)).
+					MoreStorageAllowed().
+					AnyESDT()).
+				MoreAccountsAllowed(),
+		).
+		Build()
+}
+
+func TestBuildValues(t *testing.T) {
+	require.Equal(t, "address:owner", Address("owner").Expression)
+	require.Len(t, Address("owner").Bytes, 32)
+	require.Equal(t, []byte("abc"), Str("abc").Bytes)
+	require.Equal(t, "str:abc", Str("abc").Expression)
+	require.Equal(t, "0x617c62", Str("a|b").Expression)
+	require.Equal(t, []byte{0x01, 0x00}, Uint(256).Bytes)
+	require.Equal(t, "256", Uint(256).Expression)
+	require.Equal(t, []byte{}, Uint(0).Bytes)
+	require.Equal(t, "", Bytes(nil).Expression)
+
+	value, err := Expr("str:a|u8:1")
+	require.Nil(t, err)
+	require.Equal(t, []byte{'a', 0x01}, value.Bytes)
+
+	_, err = Expr("file:missing.wasm")
+	require.NotNil(t, err)
+}
+
+func TestBuildScenarioRoundTrip(t *testing.T) {
+	scenario := buildExampleScenario()
+	serialized := mjwrite.ScenarioToJSONString(scenario)
+
+	p := mjparse.NewParser(nil)
+	parsed, err := p.ParseScenarioFile([]byte(serialized))
+	require.Nil(t, err)
+
+	// the parsed scenario yields the same values, and serializes the same way
+	require.Equal(t, serialized, mjwrite.ScenarioToJSONString(parsed))
+	require.Len(t, parsed.Steps, len(scenario.Steps))
+
+	builtAccount := scenario.Steps[0].(*mj.SetStateStep).Accounts[1]
+	parsedAccount := parsed.Steps[0].(*mj.SetStateStep).Accounts[1]
+	require.Equal(t, builtAccount.Address.Value, parsedAccount.Address.Value)
+	require.Equal(t, builtAccount.Storage[0].Value.Value, parsedAccount.Storage[0].Value.Value)
+	require.Equal(t, builtAccount.Code.Value, parsedAccount.Code.Value)
+	require.Equal(t, builtAccount.Owner.Value, parsedAccount.Owner.Value)
+
+	builtTx := scenario.Steps[1].(*mj.TxStep)
+	parsedTx := parsed.Steps[1].(*mj.TxStep)
+	require.Equal(t, builtTx.Tx.To.Value, parsedTx.Tx.To.Value)
+	require.Equal(t, builtTx.Tx.ESDTValue.TokenIdentifier.Value, parsedTx.Tx.ESDTValue.TokenIdentifier.Value)
+	require.Equal(t, builtTx.Tx.GasLimit, parsedTx.Tx.GasLimit)
+	for i, argument := range builtTx.Tx.Arguments {
+		require.Equal(t, argument.Value, parsedTx.Tx.Arguments[i].Value)
+	}
+	require.Equal(t, builtTx.ExpectedResult.Out[0].Value, parsedTx.ExpectedResult.Out[0].Value)
+	require.True(t, parsedTx.ExpectedResult.Out[1].IsStar)
+	require.True(t, parsedTx.ExpectedResult.Gas.IsStar)
+	require.False(t, parsedTx.ExpectedResult.LogsStar)
+
+	builtCheck := scenario.Steps[3].(*mj.CheckStateStep).CheckAccounts
+	parsedCheck := parsed.Steps[3].(*mj.CheckStateStep).CheckAccounts
+	require.Equal(t, builtCheck.MoreAccountsAllowed, parsedCheck.MoreAccountsAllowed)
+	for i, esdtData := range builtCheck.Accounts[0].CheckESDTData {
+		parsedESDTData := parsedCheck.Accounts[0].CheckESDTData[i]
+		require.Equal(t, esdtData.TokenIdentifier, parsedESDTData.TokenIdentifier)
+		require.Equal(t, esdtData.Instances[0].Nonce.Value, parsedESDTData.Instances[0].Nonce.Value)
+		require.Equal(t, esdtData.Instances[0].Balance.Value, parsedESDTData.Instances[0].Balance.Value)
+	}
+	require.Equal(t, builtCheck.Accounts[1].CheckStorage, parsedCheck.Accounts[1].CheckStorage)
+	require.True(t, parsedCheck.Accounts[1].IgnoreESDT)
+}
+
+func TestBuildScenarioExecution(t *testing.T) {
+	token := Str("TOKEN-123456")
+	checkState := func(ownerBalance int64, ownerTokens int64) *CheckStateBuilder {
+		return CheckState().
+			Account(CheckAccount(Address("owner")).
+				Nonce(3).
+				Balance(big.NewInt(ownerBalance)).
+				ESDT(token, big.NewInt(ownerTokens))).
+			Account(CheckAccount(Address("receiver")).
+				Nonce(0).
+				Balance(big.NewInt(300)).
+				ESDT(token, big.NewInt(100)))
+	}
+	buildScenario := func(check *CheckStateBuilder) *mj.Scenario {
+		return Scenario("built and executed").
+			Steps(
+				SetState().
+					Account(Account(Address("owner")).
+						Balance(big.NewInt(1000)).
+						ESDT(token, big.NewInt(500))).
+					Account(Account(Address("receiver"))),
+				Transfer("egld").
+					From(Address("owner")).
+					To(Address("receiver")).
+					Value(big.NewInt(100)),
+				Transfer("esdt").
+					From(Address("owner")).
+					To(Address("receiver")).
+					ESDT(token, 0, big.NewInt(100)).
+					GasLimit(1000000).
+					GasPrice(0),
+				Block().
+					CurrentBlockInfo(BlockInfo().Nonce(2)).
+					Steps(Transfer("in-block").
+						From(Address("owner")).
+						To(Address("receiver")).
+						Value(big.NewInt(200))),
+				check,
+			).
+			Build()
+	}
+
+	executor, err := am.NewVMTestExecutor()
+	require.Nil(t, err)
+	err = executor.ExecuteScenario(buildScenario(checkState(700, 400)), fr.NewDefaultFileResolver())
+	require.Nil(t, err)
+
+	executor, err = am.NewVMTestExecutor()
+	require.Nil(t, err)
+	err = executor.ExecuteScenario(buildScenario(checkState(700, 500)), fr.NewDefaultFileResolver())
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "str:TOKEN-123456")
+}
@@ -0,0 +1,238 @@
+package scenjsonbuild
+
+import (
+	"math/big"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+)
+
+// SetStateBuilder builds a setState step.
+type SetStateBuilder struct {
+	step *mj.SetStateStep
+}
+
+// SetState starts building a setState step.
+func SetState() *SetStateBuilder {
+	return &SetStateBuilder{
+		step: &mj.SetStateStep{},
+	}
+}
+
+// Comment sets the step comment.
+func (b *SetStateBuilder) Comment(comment string) *SetStateBuilder {
+	b.step.Comment = comment
+	return b
+}
+
+// Account adds an account to the state.
+func (b *SetStateBuilder) Account(account *AccountBuilder) *SetStateBuilder {
+	b.step.Accounts = append(b.step.Accounts, account.Build())
+	return b
+}
+
+// NewAddress mocks the address of the contract deployed by the creator, with the given nonce.
+func (b *SetStateBuilder) NewAddress(creatorAddress Value, creatorNonce uint64, newAddress Value) *SetStateBuilder {
+	b.step.NewAddressMocks = append(b.step.NewAddressMocks, &mj.NewAddressMock{
+		CreatorAddress: creatorAddress.bytesFromString(),
+		CreatorNonce:   uint64Value(creatorNonce),
+		NewAddress:     newAddress.bytesFromString(),
+	})
+	return b
+}
+
+// NewAddressDerivation selects how the addresses of new contracts are generated, when not mocked.
+func (b *SetStateBuilder) NewAddressDerivation(derivation mj.NewAddressDerivation) *SetStateBuilder {
+	b.step.NewAddressDerivation = derivation
+	return b
+}
+
+// PreviousBlockInfo sets the info of the previous block.
+func (b *SetStateBuilder) PreviousBlockInfo(blockInfo *BlockInfoBuilder) *SetStateBuilder {
+	b.step.PreviousBlockInfo = blockInfo.Build()
+	return b
+}
+
+// CurrentBlockInfo sets the info of the current block.
+func (b *SetStateBuilder) CurrentBlockInfo(blockInfo *BlockInfoBuilder) *SetStateBuilder {
+	b.step.CurrentBlockInfo = blockInfo.Build()
+	return b
+}
+
+// BlockHashes sets the hashes of the previous blocks.
+func (b *SetStateBuilder) BlockHashes(blockHashes ...Value) *SetStateBuilder {
+	for _, blockHash := range blockHashes {
+		b.step.BlockHashes = append(b.step.BlockHashes, blockHash.bytesFromString())
+	}
+	return b
+}
+
+// Build yields the step.
+func (b *SetStateBuilder) Build() *mj.SetStateStep {
+	return b.step
+}
+
+// AccountBuilder builds an account, for setState steps.
+type AccountBuilder struct {
+	account *mj.Account
+}
+
+// Account starts building an account, with no nonce, balance, storage or code.
+func Account(address Value) *AccountBuilder {
+	return &AccountBuilder{
+		account: &mj.Account{
+			Address:  address.bytesFromString(),
+			Shard:    mj.JSONUint64Zero(),
+			Nonce:    uint64Value(0),
+			Balance:  bigInt(big.NewInt(0)),
+			Username: mj.NewJSONBytesFromString(nil, ""),
+			Code:     mj.NewJSONBytesFromString(nil, ""),
+			Owner:    mj.NewJSONBytesFromString(nil, ""),
+		},
+	}
+}
+
+// Comment sets the account comment.
+func (b *AccountBuilder) Comment(comment string) *AccountBuilder {
+	b.account.Comment = comment
+	return b
+}
+
+// Nonce sets the account nonce.
+func (b *AccountBuilder) Nonce(nonce uint64) *AccountBuilder {
+	b.account.Nonce = uint64Value(nonce)
+	return b
+}
+
+// Balance sets the EGLD balance.
+func (b *AccountBuilder) Balance(balance *big.Int) *AccountBuilder {
+	b.account.Balance = bigInt(balance)
+	return b
+}
+
+// Username sets the account username.
+func (b *AccountBuilder) Username(username Value) *AccountBuilder {
+	b.account.Username = username.bytesFromString()
+	return b
+}
+
+// Storage adds a storage entry.
+func (b *AccountBuilder) Storage(key Value, value Value) *AccountBuilder {
+	b.account.Storage = append(b.account.Storage, &mj.StorageKeyValuePair{
+		Key:   key.bytesFromString(),
+		Value: value.bytesFromTree(),
+	})
+	return b
+}
+
+// Code sets the contract code, see File.
+func (b *AccountBuilder) Code(code Value) *AccountBuilder {
+	b.account.Code = code.bytesFromString()
+	return b
+}
+
+// Owner sets the contract owner.
+func (b *AccountBuilder) Owner(owner Value) *AccountBuilder {
+	b.account.Owner = owner.bytesFromString()
+	return b
+}
+
+// ESDT adds a fungible token balance.
+func (b *AccountBuilder) ESDT(tokenIdentifier Value, balance *big.Int) *AccountBuilder {
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.Instances = append(esdtData.Instances, &mj.ESDTInstance{
+		Nonce:   mj.JSONUint64Zero(),
+		Balance: bigInt(balance),
+	})
+	return b
+}
+
+// NFT adds a balance of a non-fungible or semi-fungible token instance.
+func (b *AccountBuilder) NFT(tokenIdentifier Value, nonce uint64, balance *big.Int) *AccountBuilder {
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.Instances = append(esdtData.Instances, &mj.ESDTInstance{
+		Nonce:   uint64Value(nonce),
+		Balance: bigInt(balance),
+	})
+	return b
+}
+
+// ESDTRoles sets the local roles of the account for a token.
+func (b *AccountBuilder) ESDTRoles(tokenIdentifier Value, roles ...string) *AccountBuilder {
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.Roles = append(esdtData.Roles, roles...)
+	return b
+}
+
+// ESDTLastNonce sets the last nonce created by the account for a token.
+func (b *AccountBuilder) ESDTLastNonce(tokenIdentifier Value, lastNonce uint64) *AccountBuilder {
+	esdtData := b.esdtData(tokenIdentifier)
+	esdtData.LastNonce = uint64Value(lastNonce)
+	return b
+}
+
+func (b *AccountBuilder) esdtData(tokenIdentifier Value) *mj.ESDTData {
+	for _, esdtData := range b.account.ESDTData {
+		if esdtData.TokenIdentifier.Original == tokenIdentifier.Expression {
+			return esdtData
+		}
+	}
+
+	esdtData := &mj.ESDTData{
+		TokenIdentifier: tokenIdentifier.bytesFromString(),
+	}
+	b.account.ESDTData = append(b.account.ESDTData, esdtData)
+	return esdtData
+}
+
+// Build yields the account.
+func (b *AccountBuilder) Build() *mj.Account {
+	return b.account
+}
+
+// BlockInfoBuilder builds block info, for setState and block steps.
+type BlockInfoBuilder struct {
+	blockInfo *mj.BlockInfo
+}
+
+// BlockInfo starts building block info. Fields not set keep their current values.
+func BlockInfo() *BlockInfoBuilder {
+	return &BlockInfoBuilder{
+		blockInfo: &mj.BlockInfo{},
+	}
+}
+
+// Timestamp sets the block timestamp.
+func (b *BlockInfoBuilder) Timestamp(timestamp uint64) *BlockInfoBuilder {
+	b.blockInfo.BlockTimestamp = uint64Value(timestamp)
+	return b
+}
+
+// Nonce sets the block nonce.
+func (b *BlockInfoBuilder) Nonce(nonce uint64) *BlockInfoBuilder {
+	b.blockInfo.BlockNonce = uint64Value(nonce)
+	return b
+}
+
+// Round sets the block round.
+func (b *BlockInfoBuilder) Round(round uint64) *BlockInfoBuilder {
+	b.blockInfo.BlockRound = uint64Value(round)
+	return b
+}
+
+// Epoch sets the block epoch.
+func (b *BlockInfoBuilder) Epoch(epoch uint64) *BlockInfoBuilder {
+	b.blockInfo.BlockEpoch = uint64Value(epoch)
+	return b
+}
+
+// RandomSeed sets the block random seed, which must be 48 bytes long.
+func (b *BlockInfoBuilder) RandomSeed(randomSeed Value) *BlockInfoBuilder {
+	seed := randomSeed.bytesFromTree()
+	b.blockInfo.BlockRandomSeed = &seed
+	return b
+}
+
+// Build yields the block info.
+func (b *BlockInfoBuilder) Build() *mj.BlockInfo {
+	return b.blockInfo
+}
@@ -0,0 +1,213 @@
+package scenjsonbuild
+
+import (
+	"math/big"
+
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+)
+
+// TxBuilder builds a transaction step: scCall, scDeploy, scQuery, transfer or validatorReward.
+type TxBuilder struct {
+	step *mj.TxStep
+}
+
+func newTx(txType mj.TransactionType, txID string) *TxBuilder {
+	return &TxBuilder{
+		step: &mj.TxStep{
+			TxIdent: txID,
+			Tx: &mj.Transaction{
+				Type:     txType,
+				Nonce:    mj.JSONUint64Zero(),
+				Value:    bigInt(big.NewInt(0)),
+				GasLimit: uint64Value(0),
+				GasPrice: uint64Value(0),
+			},
+		},
+	}
+}
+
+// ScCall starts building a smart contract call.
+func ScCall(txID string) *TxBuilder {
+	return newTx(mj.ScCall, txID)
+}
+
+// ScDeploy starts building a contract deploy.
+func ScDeploy(txID string) *TxBuilder {
+	return newTx(mj.ScDeploy, txID)
+}
+
+// ScQuery starts building an off-chain call, without a sender or gas.
+func ScQuery(txID string) *TxBuilder {
+	return newTx(mj.ScQuery, txID)
+}
+
+// Transfer starts building a transfer, which does not call a contract.
+func Transfer(txID string) *TxBuilder {
+	return newTx(mj.Transfer, txID)
+}
+
+// ValidatorReward starts building a validator reward, sent by the protocol.
+func ValidatorReward(txID string) *TxBuilder {
+	return newTx(mj.ValidatorReward, txID)
+}
+
+// Comment sets the step comment.
+func (b *TxBuilder) Comment(comment string) *TxBuilder {
+	b.step.Comment = comment
+	return b
+}
+
+// From sets the sender.
+func (b *TxBuilder) From(from Value) *TxBuilder {
+	b.step.Tx.From = from.bytesFromString()
+	return b
+}
+
+// To sets the receiver.
+func (b *TxBuilder) To(to Value) *TxBuilder {
+	b.step.Tx.To = to.bytesFromString()
+	return b
+}
+
+// Value sets the EGLD value transferred.
+func (b *TxBuilder) Value(value *big.Int) *TxBuilder {
+	b.step.Tx.Value = bigInt(value)
+	return b
+}
+
+// ESDT sets the ESDT tokens transferred; the nonce is 0 for fungible tokens.
+func (b *TxBuilder) ESDT(tokenIdentifier Value, nonce uint64, value *big.Int) *TxBuilder {
+	b.step.Tx.ESDTValue = &mj.ESDTTxData{
+		TokenIdentifier: tokenIdentifier.bytesFromString(),
+		Nonce:           uint64Value(nonce),
+		Value:           bigInt(value),
+	}
+	return b
+}
+
+// Function sets the function called.
+func (b *TxBuilder) Function(function string) *TxBuilder {
+	b.step.Tx.Function = function
+	return b
+}
+
+// Code sets the code deployed, see File.
+func (b *TxBuilder) Code(code Value) *TxBuilder {
+	b.step.Tx.Code = code.bytesFromString()
+	return b
+}
+
+// Arguments adds call or deploy arguments.
+func (b *TxBuilder) Arguments(arguments ...Value) *TxBuilder {
+	for _, argument := range arguments {
+		b.step.Tx.Arguments = append(b.step.Tx.Arguments, argument.bytesFromTree())
+	}
+	return b
+}
+
+// GasLimit sets the gas limit.
+func (b *TxBuilder) GasLimit(gasLimit uint64) *TxBuilder {
+	b.step.Tx.GasLimit = uint64Value(gasLimit)
+	return b
+}
+
+// GasPrice sets the gas price.
+func (b *TxBuilder) GasPrice(gasPrice uint64) *TxBuilder {
+	b.step.Tx.GasPrice = uint64Value(gasPrice)
+	return b
+}
+
+// Expect sets the expected result. Without one, the result is not checked.
+func (b *TxBuilder) Expect(result *ResultBuilder) *TxBuilder {
+	b.step.ExpectedResult = result.Build()
+	return b
+}
+
+// Build yields the step.
+func (b *TxBuilder) Build() *mj.TxStep {
+	return b.step
+}
+
+// ResultBuilder builds the expected result of a transaction.
+type ResultBuilder struct {
+	result *mj.TransactionResult
+}
+
+// Expect starts building an expected result which, like in scenario files,
+// expects success, no outputs, no message and no refund, but does not check the gas or the logs.
+func Expect() *ResultBuilder {
+	return &ResultBuilder{
+		result: &mj.TransactionResult{
+			Status:          mj.JSONCheckBigIntUnspecified(),
+			Message:         mj.JSONCheckBytesUnspecified(),
+			Gas:             mj.JSONCheckUint64Unspecified(),
+			Refund:          mj.JSONCheckBigIntUnspecified(),
+			LogsStar:        true,
+			LogsUnspecified: true,
+		},
+	}
+}
+
+// Out adds expected outputs; Star matches any output.
+func (b *ResultBuilder) Out(out ...Value) *ResultBuilder {
+	for _, value := range out {
+		b.result.Out = append(b.result.Out, value.checkBytes())
+	}
+	return b
+}
+
+// Status sets the expected return code.
+func (b *ResultBuilder) Status(status int64) *ResultBuilder {
+	b.result.Status = checkBigInt(big.NewInt(status))
+	return b
+}
+
+// Message sets the expected return message.
+func (b *ResultBuilder) Message(message Value) *ResultBuilder {
+	b.result.Message = message.checkBytes()
+	return b
+}
+
+// Gas sets the expected remaining gas.
+func (b *ResultBuilder) Gas(gas uint64) *ResultBuilder {
+	b.result.Gas = checkUint64(gas)
+	return b
+}
+
+// AnyGas explicitly allows any remaining gas.
+func (b *ResultBuilder) AnyGas() *ResultBuilder {
+	b.result.Gas = mj.JSONCheckUint64{IsStar: true, Original: "*"}
+	return b
+}
+
+// Refund sets the expected gas refund.
+func (b *ResultBuilder) Refund(refund *big.Int) *ResultBuilder {
+	b.result.Refund = checkBigInt(refund)
+	return b
+}
+
+// AnyRefund explicitly allows any gas refund.
+func (b *ResultBuilder) AnyRefund() *ResultBuilder {
+	b.result.Refund = mj.JSONCheckBigInt{Value: big.NewInt(0), IsStar: true, Original: "*"}
+	return b
+}
+
+// AnyLogs explicitly allows any logs.
+func (b *ResultBuilder) AnyLogs() *ResultBuilder {
+	b.result.LogsUnspecified = false
+	b.result.LogsStar = true
+	return b
+}
+
+// NoLogs expects no logs.
+func (b *ResultBuilder) NoLogs() *ResultBuilder {
+	b.result.LogsUnspecified = false
+	b.result.LogsStar = false
+	b.result.Logs = []*mj.LogEntry{}
+	return b
+}
+
+// Build yields the expected result.
+func (b *ResultBuilder) Build() *mj.TransactionResult {
+	return b.result
+}
@@ -0,0 +1,143 @@
+package scenjsonbuild
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	ei "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/interpreter"
+	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+// Value is a scenario value: the bytes the executor works with,
+// along with the expression that yields them, which is what gets serialized.
+type Value struct {
+	Bytes      []byte
+	Expression string
+}
+
+// Expr interprets a scenario expression, e.g. "address:owner", "str:abc" or "1,000".
+// Expressions loading files ("file:...") need a file resolver, see File.
+func Expr(expression string) (Value, error) {
+	interpreter := ei.ExprInterpreter{}
+	value, err := interpreter.InterpretString(expression)
+	if err != nil {
+		return Value{}, fmt.Errorf("invalid expression %s: %w", expression, err)
+	}
+	return Value{Bytes: value, Expression: expression}, nil
+}
+
+// MustExpr is like Expr, but panics if the expression is invalid.
+// It is meant for expressions written in code, that are known to be valid.
+func MustExpr(expression string) Value {
+	value, err := Expr(expression)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// File loads a file, typically contract code, relative to the context of the file resolver.
+func File(fileResolver fr.FileResolver, path string) (Value, error) {
+	interpreter := ei.ExprInterpreter{FileResolver: fileResolver}
+	expression := "file:" + path
+	value, err := interpreter.InterpretString(expression)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{Bytes: value, Expression: expression}, nil
+}
+
+// Address yields a mock user address, "address:<name>".
+func Address(name string) Value {
+	return MustExpr("address:" + name)
+}
+
+// SC yields a mock smart contract address, "sc:<name>".
+func SC(name string) Value {
+	return MustExpr("sc:" + name)
+}
+
+// Str yields the bytes of a string, written as "str:<value>" if that reads back the same, and in hex otherwise.
+func Str(value string) Value {
+	for _, c := range []byte(value) {
+		if c < 0x20 || c >= 0x7f || c == '|' || c == '"' || c == '\\' {
+			return Bytes([]byte(value))
+		}
+	}
+	return Value{Bytes: []byte(value), Expression: "str:" + value}
+}
+
+// Bytes yields raw bytes, written in hex.
+func Bytes(value []byte) Value {
+	if len(value) == 0 {
+		return Value{Bytes: []byte{}, Expression: ""}
+	}
+	return Value{Bytes: value, Expression: "0x" + hex.EncodeToString(value)}
+}
+
+// BigUint yields the minimal big endian encoding of an unsigned number, written in decimal.
+func BigUint(value *big.Int) Value {
+	return Value{Bytes: value.Bytes(), Expression: value.String()}
+}
+
+// Uint yields the minimal big endian encoding of an unsigned number, written in decimal.
+func Uint(value uint64) Value {
+	return BigUint(big.NewInt(0).SetUint64(value))
+}
+
+// Star matches any value. It is only meant for expectations.
+func Star() Value {
+	return Value{Bytes: []byte{}, Expression: "*"}
+}
+
+func (value Value) bytesFromString() mj.JSONBytesFromString {
+	return mj.NewJSONBytesFromString(value.Bytes, value.Expression)
+}
+
+func (value Value) bytesFromTree() mj.JSONBytesFromTree {
+	return mj.JSONBytesFromTree{
+		Value:    value.Bytes,
+		Original: &oj.OJsonString{Value: value.Expression},
+	}
+}
+
+func (value Value) checkBytes() mj.JSONCheckBytes {
+	if value.Expression == "*" {
+		return mj.JSONCheckBytesStar()
+	}
+	return mj.JSONCheckBytes{
+		Value:    value.Bytes,
+		Original: &oj.OJsonString{Value: value.Expression},
+	}
+}
+
+func bigInt(value *big.Int) mj.JSONBigInt {
+	return mj.JSONBigInt{
+		Value:    big.NewInt(0).Set(value),
+		Original: value.String(),
+	}
+}
+
+func uint64Value(value uint64) mj.JSONUint64 {
+	return mj.JSONUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
+
+func checkBigInt(value *big.Int) mj.JSONCheckBigInt {
+	return mj.JSONCheckBigInt{
+		Value:    big.NewInt(0).Set(value),
+		Original: value.String(),
+	}
+}
+
+func checkUint64(value uint64) mj.JSONCheckUint64 {
+	return mj.JSONCheckUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
@@ -1,12 +1,15 @@
 package scenjsontest
 
 import (
+	"encoding/hex"
 	"io/ioutil"
 	"testing"
 
 	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	mjparse "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/parse"
 	mjwrite "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/write"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,3 +32,307 @@ func TestWriteScenario(t *testing.T) {
 
 	require.Equal(t, contents, []byte(serialized))
 }
+
+func TestWriteScenarioBech32Addresses(t *testing.T) {
+	contents := `{
+    "name": "bech32 addresses",
+    "gasSchedule": "default",
+    "steps": [
+        {
+            "step": "setState",
+            "accounts": {
+                "bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th": {
+                    "nonce": "0",
+                    "balance": "100",
+                    "storage": {},
+                    "code": ""
+                },
+                "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx": {
+                    "nonce": "0",
+                    "balance": "0",
+                    "storage": {},
+                    "code": ""
+                }
+            }
+        },
+        {
+            "step": "transfer",
+            "txId": "1",
+            "tx": {
+                "from": "bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th",
+                "to": "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx",
+                "value": "10",
+                "gasLimit": "",
+                "gasPrice": ""
+            }
+        }
+    ]
+}
+`
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+	scenario, parseErr := p.ParseScenarioFile([]byte(contents))
+	require.Nil(t, parseErr)
+
+	serialized := mjwrite.ScenarioToJSONString(scenario)
+	require.Equal(t, contents, serialized)
+}
+
+func TestWriteAccountsWithoutOriginalAddress(t *testing.T) {
+	alice, _ := hex.DecodeString("0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1")
+	accounts := []*mj.Account{
+		{Address: mj.JSONBytesFromString{Value: alice}},
+		{Address: mj.JSONBytesFromString{Value: []byte("an_address______________________")}},
+	}
+
+	accountsOJ := mjwrite.AccountsToOJ(accounts).(*oj.OJsonMap)
+	keys := make([]string, 0)
+	for _, kvp := range accountsOJ.OrderedKV {
+		keys = append(keys, kvp.Key)
+	}
+	require.Equal(t, []string{
+		"bech32:erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th",
+		"address:an_address",
+	}, keys)
+}
+
+func TestWriteScenarioCheckPredicates(t *testing.T) {
+	contents := `{
+    "name": "check predicates",
+    "gasSchedule": "default",
+    "steps": [
+        {
+            "step": "checkState",
+            "accounts": {
+                "address:owner": {
+                    "nonce": "<5",
+                    "balance": "1000..2000",
+                    "esdt": {
+                        "str:TOKEN-123456": "1000~0.5%"
+                    },
+                    "storage": {
+                        "str:counter": ">=0x10"
+                    },
+                    "code": ""
+                }
+            }
+        }
+    ]
+}
+`
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+	scenario, parseErr := p.ParseScenarioFile([]byte(contents))
+	require.Nil(t, parseErr)
+
+	serialized := mjwrite.ScenarioToJSONString(scenario)
+	require.Equal(t, contents, serialized)
+}
+
+func TestWriteScenarioTypedValues(t *testing.T) {
+	contents := `{
+    "name": "typed values",
+    "gasSchedule": "default",
+    "abi": {
+        "sc:example": "example.abi.json"
+    },
+    "steps": [
+        {
+            "step": "scCall",
+            "txId": "1",
+            "tx": {
+                "from": "address:owner",
+                "to": "sc:example",
+                "value": "0",
+                "function": "setConfig",
+                "typedArguments": [
+                    {
+                        "limit": "1000",
+                        "mode": {
+                            "Capped": [
+                                "5"
+                            ]
+                        }
+                    },
+                    "address:owner"
+                ],
+                "gasLimit": "5,000,000",
+                "gasPrice": "0"
+            }
+        },
+        {
+            "step": "scQuery",
+            "txId": "2",
+            "tx": {
+                "to": "sc:example",
+                "function": "getConfig",
+                "typedArguments": []
+            },
+            "expect": {
+                "typedOut": [
+                    {
+                        "Some": {
+                            "limit": "1000",
+                            "mode": "Off"
+                        }
+                    }
+                ]
+            }
+        }
+    ]
+}
+`
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+	scenario, parseErr := p.ParseScenarioFile([]byte(contents))
+	require.Nil(t, parseErr)
+
+	callTx := scenario.Steps[0].(*mj.TxStep).Tx
+	require.Len(t, callTx.Arguments, 2)
+	require.Equal(t, []byte{0, 0, 0, 2, 0x03, 0xe8, 1, 0, 0, 0, 5}, callTx.Arguments[0].Value)
+	require.Equal(t, []byte("owner___________________________"), callTx.Arguments[1].Value)
+
+	queryResult := scenario.Steps[1].(*mj.TxStep).ExpectedResult
+	require.Len(t, queryResult.Out, 1)
+	require.Equal(t, []byte{1, 0, 0, 0, 2, 0x03, 0xe8, 0}, queryResult.Out[0].Value)
+	require.Equal(t, "[{limit: 1000, mode: Off}]", queryResult.TypedOut.String())
+	require.Equal(t, "[None]", queryResult.TypedOut.DescribeResults([][]byte{{}}))
+
+	serialized := mjwrite.ScenarioToJSONString(scenario)
+	require.Equal(t, contents, serialized)
+
+	_, parseErr = p.ParseScenarioFile([]byte(`{
+    "steps": [
+        {
+            "step": "scQuery",
+            "tx": {
+                "to": "sc:example",
+                "function": "getConfig",
+                "typedArguments": []
+            }
+        }
+    ]
+}`))
+	require.NotNil(t, parseErr)
+}
+
+func TestWriteScenarioExternalStepsParameters(t *testing.T) {
+	contents := `{
+    "name": "external steps parameters",
+    "gasSchedule": "default",
+    "steps": [
+        {
+            "step": "externalSteps",
+            "path": "setup.steps.json",
+            "parameters": {
+                "owner": "alice",
+                "amount": "1,000"
+            }
+        }
+    ]
+}
+`
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+	scenario, parseErr := p.ParseScenarioFile([]byte(contents))
+	require.Nil(t, parseErr)
+
+	step := scenario.Steps[0].(*mj.ExternalStepsStep)
+	require.Equal(t, map[string]string{"owner": "alice", "amount": "1,000"}, step.ParametersMap())
+
+	serialized := mjwrite.ScenarioToJSONString(scenario)
+	require.Equal(t, contents, serialized)
+}
+
+func TestWriteScenarioInAllFormats(t *testing.T) {
+	contents, err := loadExampleFile("example.scen.json")
+	require.Nil(t, err)
+
+	p := mjparse.NewParser(
+		fr.NewDefaultFileResolver().ReplacePath(
+			"smart-contract.wasm",
+			"exampleFile.txt"))
+	scenario, parseErr := p.ParseScenarioFile(contents)
+	require.Nil(t, parseErr)
+
+	for _, format := range []oj.Format{oj.FormatJSON5, oj.FormatYAML} {
+		serialized := mjwrite.ScenarioToString(scenario, format)
+		require.Equal(t, format, oj.FormatFromPath("example.scen"+format.Extension()))
+
+		reparsed, parseErr := p.ParseScenarioFileInFormat([]byte(serialized), format)
+		require.Nil(t, parseErr, serialized)
+		require.Equal(t, string(contents), mjwrite.ScenarioToJSONString(reparsed))
+		require.Equal(t, serialized, mjwrite.ScenarioToString(reparsed, format))
+	}
+}
+
+func TestParseScenarioJSON5AndYAML(t *testing.T) {
+	expected := `{
+    "name": "other formats",
+    "gasSchedule": "v3",
+    "steps": [
+        {
+            "step": "setState",
+            "comment": "it's \"quoted\"",
+            "accounts": {
+                "address:owner": {
+                    "nonce": "1",
+                    "balance": "1,000",
+                    "storage": {},
+                    "code": ""
+                }
+            }
+        }
+    ]
+}
+`
+
+	json5 := `// a comment
+{
+    name: 'other formats',
+    gasSchedule: "v3", /* another comment */
+    steps: [
+        {
+            step: "setState",
+            comment: 'it\'s "quoted"',
+            accounts: {
+                "address:owner": {
+                    nonce: 1,
+                    balance: "1,000",
+                    storage: {},
+                    code: "",
+                },
+            },
+        },
+    ],
+}
+`
+
+	yaml := `# a comment
+name: other formats
+gasSchedule: v3
+steps:
+  - step: setState
+    comment: it's "quoted"
+    accounts:
+      address:owner:
+        nonce: 1
+        balance: 1,000
+        storage: {}
+        code:
+`
+
+	p := mjparse.NewParser(fr.NewDefaultFileResolver())
+	for format, contents := range map[oj.Format]string{oj.FormatJSON5: json5, oj.FormatYAML: yaml} {
+		scenario, parseErr := p.ParseScenarioFileInFormat([]byte(contents), format)
+		require.Nil(t, parseErr, contents)
+		require.Equal(t, expected, mjwrite.ScenarioToJSONString(scenario))
+	}
+
+	_, parseErr := p.ParseScenarioFileInFormat([]byte(`{name: "unterminated /* comment}`), oj.FormatJSON5)
+	require.NotNil(t, parseErr)
+	_, parseErr = p.ParseScenarioFileInFormat([]byte("name: [unbalanced"), oj.FormatYAML)
+	require.NotNil(t, parseErr)
+	_, parseErr = p.ParseScenarioFileInFormat([]byte(json5), oj.FormatJSON)
+	require.NotNil(t, parseErr)
+}
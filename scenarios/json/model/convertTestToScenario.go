@@ -40,6 +40,7 @@ func ConvertTestToScenario(top []*Test) (*Scenario, error) {
 
 	scenario.Steps = append(scenario.Steps, &CheckStateStep{
 		CheckAccounts: test.PostState,
+		StateRootHash: JSONCheckBytesUnspecified(),
 	})
 
 	return scenario, nil
@@ -25,6 +25,8 @@ type ESDTData struct {
 	LastNonce       JSONUint64
 	Roles           []string
 	Frozen          JSONUint64
+	Paused          JSONUint64
+	LimitedTransfer JSONUint64
 }
 
 // CheckESDTInstance checks an instance of an NFT/SFT, with its own nonce
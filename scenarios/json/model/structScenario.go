@@ -2,11 +2,19 @@ package scenjsonmodel
 
 // Scenario is a json object representing a test scenario with steps.
 type Scenario struct {
-	Name        string
-	Comment     string
-	CheckGas    bool
-	GasSchedule GasSchedule
-	Steps       []Step
+	Name         string
+	Comment      string
+	CheckGas     bool
+	GasSchedule  GasSchedule
+	EnableEpochs []*FlagActivation
+	ContractABIs []*ContractABI
+	Steps        []Step
+}
+
+// FlagActivation sets the epoch from which a VM flag is active
+type FlagActivation struct {
+	Flag  string
+	Epoch JSONUint64
 }
 
 // Step is the basic block of a scenario.
@@ -30,26 +38,59 @@ type BlockInfo struct {
 	BlockRandomSeed *JSONBytesFromTree
 }
 
-// ExternalStepsStep allows including steps from another file
+// NewAddressDerivation selects how the addresses of new contracts are generated, when not mocked
+type NewAddressDerivation string
+
+const (
+	// NewAddressDerivationUnspecified keeps the current derivation
+	NewAddressDerivationUnspecified NewAddressDerivation = ""
+
+	// NewAddressDerivationMock generates simple, easy to read addresses; this is the default
+	NewAddressDerivationMock NewAddressDerivation = "mock"
+
+	// NewAddressDerivationProtocol derives addresses exactly like the protocol does
+	NewAddressDerivationProtocol NewAddressDerivation = "protocol"
+)
+
+// ExternalStepsStep allows including steps from another file,
+// optionally replacing "{{name}}" placeholders in that file with parameters
 type ExternalStepsStep struct {
-	Comment string
-	Path    string
+	Comment    string
+	Path       string
+	Parameters []*ExternalStepsParameter
+}
+
+// ExternalStepsParameter is the value of a placeholder in an external steps file
+type ExternalStepsParameter struct {
+	Name  string
+	Value string
+}
+
+// ParametersMap yields the parameters of the external steps, by name
+func (step *ExternalStepsStep) ParametersMap() map[string]string {
+	parameters := make(map[string]string, len(step.Parameters))
+	for _, parameter := range step.Parameters {
+		parameters[parameter.Name] = parameter.Value
+	}
+	return parameters
 }
 
 // SetStateStep is a step where data is saved to the blockchain mock.
 type SetStateStep struct {
-	Comment           string
-	Accounts          []*Account
-	PreviousBlockInfo *BlockInfo
-	CurrentBlockInfo  *BlockInfo
-	BlockHashes       []JSONBytesFromString
-	NewAddressMocks   []*NewAddressMock
+	Comment              string
+	Accounts             []*Account
+	PreviousBlockInfo    *BlockInfo
+	CurrentBlockInfo     *BlockInfo
+	BlockHashes          []JSONBytesFromString
+	NewAddressMocks      []*NewAddressMock
+	NewAddressDerivation NewAddressDerivation
 }
 
 // CheckStateStep is a step where the state of the blockchain mock is verified.
 type CheckStateStep struct {
 	Comment       string
 	CheckAccounts *CheckAccounts
+	StateRootHash JSONCheckBytes
 }
 
 // DumpStateStep is a step that simply prints the entire state to console. Useful for debugging.
@@ -57,6 +98,16 @@ type DumpStateStep struct {
 	Comment string
 }
 
+// BlockStep groups several steps, executed in the same block.
+// The transactions in a block share the block info and the block gas limit.
+// Without a BlockGasLimit, the block gas limit of the VM applies.
+type BlockStep struct {
+	Comment          string
+	CurrentBlockInfo *BlockInfo
+	BlockGasLimit    JSONUint64
+	Steps            []Step
+}
+
 // TxStep is a step where a transaction is executed.
 type TxStep struct {
 	TxIdent        string
@@ -69,6 +120,7 @@ var _ Step = (*ExternalStepsStep)(nil)
 var _ Step = (*SetStateStep)(nil)
 var _ Step = (*CheckStateStep)(nil)
 var _ Step = (*DumpStateStep)(nil)
+var _ Step = (*BlockStep)(nil)
 var _ Step = (*TxStep)(nil)
 
 // StepNameExternalSteps is a json step type name.
@@ -103,6 +155,14 @@ func (*DumpStateStep) StepTypeName() string {
 	return StepNameDumpState
 }
 
+// StepNameBlock is a json step type name.
+const StepNameBlock = "block"
+
+// StepTypeName type as string
+func (*BlockStep) StepTypeName() string {
+	return StepNameBlock
+}
+
 // StepNameScCall is a json step type name.
 const StepNameScCall = "scCall"
 
@@ -70,6 +70,9 @@ type Transaction struct {
 	Arguments []JSONBytesFromTree
 	GasPrice  JSONUint64
 	GasLimit  JSONUint64
+
+	// TypedArguments are set when the arguments are given as typed values, Arguments then hold their encoding.
+	TypedArguments *TypedValues
 }
 
 // TransactionResult is a json object representing an expected transaction result.
@@ -83,6 +86,9 @@ type TransactionResult struct {
 	LogsUnspecified bool
 	LogHash         string
 	Logs            []*LogEntry
+
+	// TypedOut is set when the results are given as typed values, Out then holds their encoding.
+	TypedOut *TypedValues
 }
 
 // LogEntry is a json object representing an expected transaction result log entry.
@@ -0,0 +1,48 @@
+package scenjsonmodel
+
+import (
+	"fmt"
+	"strings"
+
+	eabi "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/abi"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+// ContractABI associates a contract with the ABI used for its typed arguments and results.
+type ContractABI struct {
+	Address JSONBytesFromString
+	Path    string
+	ABI     *eabi.ABI
+}
+
+// TypedValues holds transaction arguments or expected results given as typed values,
+// which are encoded according to the contract ABI.
+type TypedValues struct {
+	Original oj.OJsonObject
+	Codec    *eabi.Codec
+	Params   []*eabi.Param
+	Values   []interface{}
+}
+
+// String renders the typed values, e.g. for error messages.
+func (tv *TypedValues) String() string {
+	return formatTypedValues(tv.Values)
+}
+
+// DescribeResults decodes raw results as the same types, for comparison with the typed values.
+// Results that cannot be decoded are rendered raw.
+func (tv *TypedValues) DescribeResults(results [][]byte) string {
+	values, err := tv.Codec.DecodeResults(tv.Params, results)
+	if err != nil {
+		return fmt.Sprintf("%s (cannot decode: %s)", ResultAsString(results), err.Error())
+	}
+	return formatTypedValues(values)
+}
+
+func formatTypedValues(values []interface{}) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = eabi.FormatValue(value)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
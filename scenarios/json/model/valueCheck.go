@@ -8,11 +8,12 @@ import (
 )
 
 // JSONCheckBytes holds a byte slice condition.
-// Values are checked for equality.
+// Values are checked for equality, or against a predicate (comparing bytes as unsigned numbers).
 // "*" allows all values.
 type JSONCheckBytes struct {
 	Value       []byte
 	IsStar      bool
+	Predicate   *ValuePredicate
 	Original    oj.OJsonObject
 	Unspecified bool
 }
@@ -66,15 +67,19 @@ func (jcbytes JSONCheckBytes) Check(other []byte) bool {
 	if jcbytes.IsStar {
 		return true
 	}
+	if jcbytes.Predicate != nil {
+		return jcbytes.Predicate.Check(big.NewInt(0).SetBytes(other))
+	}
 	return bytes.Equal(jcbytes.Value, other)
 }
 
 // JSONCheckBigInt holds a big int condition.
-// Values are checked for equality.
+// Values are checked for equality, or against a predicate.
 // "*" allows all values.
 type JSONCheckBigInt struct {
 	Value       *big.Int
 	IsStar      bool
+	Predicate   *ValuePredicate
 	Original    string
 	Unspecified bool
 }
@@ -100,15 +105,19 @@ func (jcbi JSONCheckBigInt) Check(other *big.Int) bool {
 	if jcbi.IsStar {
 		return true
 	}
+	if jcbi.Predicate != nil {
+		return jcbi.Predicate.Check(other)
+	}
 	return jcbi.Value.Cmp(other) == 0
 }
 
 // JSONCheckUint64 holds a uint64 condition.
-// Values are checked for equality.
+// Values are checked for equality, or against a predicate.
 // "*" allows all values.
 type JSONCheckUint64 struct {
 	Value       uint64
 	IsStar      bool
+	Predicate   *ValuePredicate
 	Original    string
 	Unspecified bool
 }
@@ -134,6 +143,9 @@ func (jcu JSONCheckUint64) Check(other uint64) bool {
 	if jcu.IsStar {
 		return true
 	}
+	if jcu.Predicate != nil {
+		return jcu.Predicate.Check(big.NewInt(0).SetUint64(other))
+	}
 	return jcu.Value == other
 }
 
@@ -143,5 +155,15 @@ func (jcu JSONCheckUint64) CheckBool(other bool) bool {
 	if jcu.IsStar {
 		return true
 	}
+	if jcu.Predicate != nil {
+		return jcu.Check(boolToUint64(other))
+	}
 	return jcu.Value > 0 == other
 }
+
+func boolToUint64(value bool) uint64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package scenjsonmodel
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// PredicateOperator is the kind of condition expressed by a ValuePredicate.
+type PredicateOperator int
+
+const (
+	// PredicateGreaterOrEqual is written ">=X".
+	PredicateGreaterOrEqual PredicateOperator = iota
+
+	// PredicateGreater is written ">X".
+	PredicateGreater
+
+	// PredicateLessOrEqual is written "<=X".
+	PredicateLessOrEqual
+
+	// PredicateLess is written "<X".
+	PredicateLess
+
+	// PredicateRange is written "X..Y", both bounds included.
+	PredicateRange
+
+	// PredicateTolerance is written "X~P%", allowing values that differ from X by at most P percent of X.
+	PredicateTolerance
+)
+
+// ValuePredicate is a check value condition other than equality, for numeric values.
+type ValuePredicate struct {
+	Operator PredicateOperator
+
+	// Operand is the compared value, the lower bound of a range, or the expected value with a tolerance.
+	Operand *big.Int
+
+	// UpperBound is the upper bound of a range.
+	UpperBound *big.Int
+
+	// TolerancePercent is the allowed deviation, in percents, e.g. 0.5 for "~0.5%".
+	TolerancePercent *big.Rat
+}
+
+// Check returns true if the predicate holds for a value.
+func (vp *ValuePredicate) Check(other *big.Int) bool {
+	if other == nil {
+		other = big.NewInt(0)
+	}
+
+	switch vp.Operator {
+	case PredicateGreaterOrEqual:
+		return other.Cmp(vp.Operand) >= 0
+	case PredicateGreater:
+		return other.Cmp(vp.Operand) > 0
+	case PredicateLessOrEqual:
+		return other.Cmp(vp.Operand) <= 0
+	case PredicateLess:
+		return other.Cmp(vp.Operand) < 0
+	case PredicateRange:
+		return other.Cmp(vp.Operand) >= 0 && other.Cmp(vp.UpperBound) <= 0
+	case PredicateTolerance:
+		// |other - operand| * 100 <= |operand| * percent
+		deviation := new(big.Rat).SetInt(new(big.Int).Abs(new(big.Int).Sub(other, vp.Operand)))
+		deviation.Mul(deviation, big.NewRat(100, 1))
+		allowed := new(big.Rat).SetInt(new(big.Int).Abs(vp.Operand))
+		allowed.Mul(allowed, vp.TolerancePercent)
+		return deviation.Cmp(allowed) <= 0
+	default:
+		return false
+	}
+}
+
+// String describes the predicate in words, for error messages.
+func (vp *ValuePredicate) String() string {
+	switch vp.Operator {
+	case PredicateGreaterOrEqual:
+		return fmt.Sprintf("at least %d", vp.Operand)
+	case PredicateGreater:
+		return fmt.Sprintf("greater than %d", vp.Operand)
+	case PredicateLessOrEqual:
+		return fmt.Sprintf("at most %d", vp.Operand)
+	case PredicateLess:
+		return fmt.Sprintf("less than %d", vp.Operand)
+	case PredicateRange:
+		return fmt.Sprintf("between %d and %d", vp.Operand, vp.UpperBound)
+	case PredicateTolerance:
+		percent := strings.TrimRight(strings.TrimRight(vp.TolerancePercent.FloatString(4), "0"), ".")
+		return fmt.Sprintf("within %s%% of %d", percent, vp.Operand)
+	default:
+		return "unknown predicate"
+	}
+}
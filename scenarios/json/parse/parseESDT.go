@@ -80,6 +80,16 @@ func (p *Parser) processESDTDataMap(tokenName mj.JSONBytesFromString, esdtDataMa
 				if err != nil {
 					return nil, fmt.Errorf("invalid ESDT frozen flag: %w", err)
 				}
+			case "paused":
+				esdtData.Paused, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ESDT paused flag: %w", err)
+				}
+			case "limitedTransfer":
+				esdtData.LimitedTransfer, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ESDT limitedTransfer flag: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("unknown ESDT data field: %s", kvp.Key)
 			}
@@ -106,9 +106,9 @@ func (p *Parser) tryProcessCheckESDTInstanceField(kvp *oj.OJsonKeyValuePair, tar
 	var err error
 	switch kvp.Key {
 	case "nonce":
-		targetInstance.Nonce, err = p.processCheckUint64(kvp.Value)
+		targetInstance.Nonce, err = p.processCheckESDTInstanceNonce(kvp.Value)
 		if err != nil {
-			return false, fmt.Errorf("invalid account nonce: %w", err)
+			return false, fmt.Errorf("invalid ESDT instance nonce: %w", err)
 		}
 	case "balance":
 		targetInstance.Balance, err = p.processCheckBigInt(kvp.Value, bigIntUnsignedBytes)
@@ -149,6 +149,30 @@ func (p *Parser) tryProcessCheckESDTInstanceField(kvp *oj.OJsonKeyValuePair, tar
 	return true, nil
 }
 
+// processCheckESDTInstanceNonce only accepts exact nonces: the nonce identifies the instance to check,
+// so it cannot be "*" or a predicate
+func (p *Parser) processCheckESDTInstanceNonce(obj oj.OJsonObject) (mj.JSONCheckUint64, error) {
+	if IsStar(obj) {
+		return mj.JSONCheckUint64{}, errors.New("the nonce identifies the instance, it cannot be *")
+	}
+	predicate, err := p.parseCheckPredicate(obj, bigIntUnsignedBytes)
+	if err != nil {
+		return mj.JSONCheckUint64{}, err
+	}
+	if predicate != nil {
+		return mj.JSONCheckUint64{}, errors.New("the nonce identifies the instance, it cannot be a predicate")
+	}
+
+	nonce, err := p.processUint64(obj)
+	if err != nil {
+		return mj.JSONCheckUint64{}, err
+	}
+	return mj.JSONCheckUint64{
+		Value:    nonce.Value,
+		Original: nonce.Original,
+	}, nil
+}
+
 func (p *Parser) processCheckESDTInstances(esdtInstancesRaw oj.OJsonObject) ([]*mj.CheckESDTInstance, error) {
 	var instancesResult []*mj.CheckESDTInstance
 	esdtInstancesList, isList := esdtInstancesRaw.(*oj.OJsonList)
@@ -55,3 +55,18 @@ func (p *Parser) processNewAddressMocks(namsRaw oj.OJsonObject) ([]*mj.NewAddres
 
 	return namEntries, nil
 }
+
+func (p *Parser) parseNewAddressDerivation(obj oj.OJsonObject) (mj.NewAddressDerivation, error) {
+	str, err := p.parseString(obj)
+	if err != nil {
+		return mj.NewAddressDerivationUnspecified, err
+	}
+
+	derivation := mj.NewAddressDerivation(str)
+	switch derivation {
+	case mj.NewAddressDerivationMock, mj.NewAddressDerivationProtocol:
+		return derivation, nil
+	default:
+		return mj.NewAddressDerivationUnspecified, fmt.Errorf("unknown new address derivation: %s", str)
+	}
+}
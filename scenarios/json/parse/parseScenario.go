@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
@@ -10,7 +11,12 @@ import (
 
 // ParseScenarioFile converts a scenario json string to scenario object representation
 func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
-	jobj, err := oj.ParseOrderedJSON(jsonString)
+	return p.ParseScenarioFileInFormat(jsonString, oj.FormatJSON)
+}
+
+// ParseScenarioFileInFormat converts a scenario written in any of the supported formats (JSON, JSON5, YAML) to the object model.
+func (p *Parser) ParseScenarioFileInFormat(contents []byte, format oj.Format) (*mj.Scenario, error) {
+	jobj, err := oj.ParseOrderedFormat(contents, format)
 	if err != nil {
 		return nil, err
 	}
@@ -25,6 +31,17 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
 		GasSchedule: mj.GasScheduleDefault,
 	}
 
+	// the ABIs are needed by the steps, wherever they are declared
+	for _, kvp := range topMap.OrderedKV {
+		if kvp.Key == "abi" {
+			scenario.ContractABIs, err = p.processContractABIs(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad scenario abi: %w", err)
+			}
+		}
+	}
+	p.setContractABIs(scenario.ContractABIs)
+
 	for _, kvp := range topMap.OrderedKV {
 		switch kvp.Key {
 		case "name":
@@ -48,6 +65,12 @@ func (p *Parser) ParseScenarioFile(jsonString []byte) (*mj.Scenario, error) {
 			if err != nil {
 				return nil, fmt.Errorf("bad scenario gasSchedule: %w", err)
 			}
+		case "enableEpochs":
+			scenario.EnableEpochs, err = p.processEnableEpochs(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad scenario enableEpochs: %w", err)
+			}
+		case "abi":
 		case "steps":
 			scenario.Steps, err = p.processScenarioStepList(kvp.Value)
 			if err != nil {
@@ -81,6 +104,47 @@ func (p *Parser) parseGasSchedule(value oj.OJsonObject) (mj.GasSchedule, error)
 	}
 }
 
+func (p *Parser) processEnableEpochs(value oj.OJsonObject) ([]*mj.FlagActivation, error) {
+	enableEpochsMap, isMap := value.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("enableEpochs not a JSON map")
+	}
+	var flagActivations []*mj.FlagActivation
+	for _, kvp := range enableEpochsMap.OrderedKV {
+		epoch, err := p.processUint64(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid activation epoch for flag %s: %w", kvp.Key, err)
+		}
+		if epoch.Value > math.MaxUint32 {
+			return nil, fmt.Errorf("activation epoch for flag %s does not fit in 32 bits", kvp.Key)
+		}
+		flagActivations = append(flagActivations, &mj.FlagActivation{
+			Flag:  kvp.Key,
+			Epoch: epoch,
+		})
+	}
+	return flagActivations, nil
+}
+
+func (p *Parser) processExternalStepsParameters(value oj.OJsonObject) ([]*mj.ExternalStepsParameter, error) {
+	parametersMap, isMap := value.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("parameters not a JSON map")
+	}
+	var parameters []*mj.ExternalStepsParameter
+	for _, kvp := range parametersMap.OrderedKV {
+		parameterValue, err := p.parseString(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value of parameter %s is not a string: %w", kvp.Key, err)
+		}
+		parameters = append(parameters, &mj.ExternalStepsParameter{
+			Name:  kvp.Key,
+			Value: parameterValue,
+		})
+	}
+	return parameters, nil
+}
+
 func (p *Parser) processScenarioStepList(obj interface{}) ([]mj.Step, error) {
 	listRaw, listOk := obj.(*oj.OJsonList)
 	if !listOk {
@@ -97,6 +161,23 @@ func (p *Parser) processScenarioStepList(obj interface{}) ([]mj.Step, error) {
 	return stepList, nil
 }
 
+// only transactions, state checks and external steps are allowed inside a block,
+// everything else would change the block itself
+func (p *Parser) processBlockStepList(obj interface{}) ([]mj.Step, error) {
+	stepList, err := p.processScenarioStepList(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range stepList {
+		switch step.(type) {
+		case *mj.TxStep, *mj.CheckStateStep, *mj.DumpStateStep, *mj.ExternalStepsStep:
+		default:
+			return nil, fmt.Errorf("step not allowed inside a block: %s", step.StepTypeName())
+		}
+	}
+	return stepList, nil
+}
+
 // ParseScenarioStep parses a single scenario step, instead of an entire file.
 // Handy for tests, where step snippets can be embedded in code.
 func (p *Parser) ParseScenarioStep(jsonSnippet string) (mj.Step, error) {
@@ -143,6 +224,11 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 				if err != nil {
 					return nil, fmt.Errorf("bad externalSteps path: %w", err)
 				}
+			case "parameters":
+				step.Parameters, err = p.processExternalStepsParameters(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad externalSteps parameters: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("invalid externalSteps field: %s", kvp.Key)
 			}
@@ -168,6 +254,11 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 				if err != nil {
 					return nil, fmt.Errorf("error parsing new addresses: %w", err)
 				}
+			case "newAddressDerivation":
+				step.NewAddressDerivation, err = p.parseNewAddressDerivation(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing new address derivation: %w", err)
+				}
 			case "previousBlockInfo":
 				step.PreviousBlockInfo, err = p.processBlockInfo(kvp.Value)
 				if err != nil {
@@ -189,7 +280,9 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 		}
 		return step, nil
 	case mj.StepNameCheckState:
-		step := &mj.CheckStateStep{}
+		step := &mj.CheckStateStep{
+			StateRootHash: mj.JSONCheckBytesUnspecified(),
+		}
 		for _, kvp := range stepMap.OrderedKV {
 			switch kvp.Key {
 			case "step":
@@ -203,6 +296,11 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 				if err != nil {
 					return nil, fmt.Errorf("cannot parse check state step: %w", err)
 				}
+			case "stateRootHash":
+				step.StateRootHash, err = p.parseCheckBytes(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid check state root hash: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("invalid check state field: %s", kvp.Key)
 			}
@@ -223,6 +321,36 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 			}
 		}
 		return step, nil
+	case mj.StepNameBlock:
+		step := &mj.BlockStep{}
+		for _, kvp := range stepMap.OrderedKV {
+			switch kvp.Key {
+			case "step":
+			case "comment":
+				step.Comment, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad block step comment: %w", err)
+				}
+			case "currentBlockInfo":
+				step.CurrentBlockInfo, err = p.processBlockInfo(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing block currentBlockInfo: %w", err)
+				}
+			case "blockGasLimit":
+				step.BlockGasLimit, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing block gas limit: %w", err)
+				}
+			case "steps":
+				step.Steps, err = p.processBlockStepList(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("error processing block steps: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("invalid block field: %s", kvp.Key)
+			}
+		}
+		return step, nil
 	case mj.StepNameScCall:
 		return p.parseTxStep(mj.ScCall, stepMap)
 	case mj.StepNameScDeploy:
@@ -271,5 +399,13 @@ func (p *Parser) parseTxStep(txType mj.TransactionType, stepMap *oj.OJsonMap) (*
 			return nil, fmt.Errorf("invalid tx step field: %s", kvp.Key)
 		}
 	}
+
+	if step.ExpectedResult != nil && step.ExpectedResult.TypedOut != nil {
+		err = p.processTypedOut(step.Tx, step.ExpectedResult)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx expected typedOut: %w", err)
+		}
+	}
+
 	return step, nil
 }
@@ -3,6 +3,7 @@ package scenjsonparse
 import (
 	"testing"
 
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,87 @@ func TestParseScenario(t *testing.T) {
 	require.NotNil(t, step)
 	require.Equal(t, "scCall", step.StepTypeName())
 }
+
+func TestParseBlockStep(t *testing.T) {
+	snippet := `
+	{
+		"step": "block",
+		"currentBlockInfo": {
+			"blockNonce": "5"
+		},
+		"blockGasLimit": "2,000,000",
+		"steps": [
+			{
+				"step": "transfer",
+				"txId": "1",
+				"tx": {
+					"from": "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b000000000000000000000000",
+					"to": "0x1000000000000000000000000000000000000000000000000000000000000000",
+					"value": "0x00",
+					"gasLimit": "0x100000",
+					"gasPrice": "0x01"
+				}
+			}
+		]
+	}`
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(snippet)
+	require.Nil(t, parseErr)
+	require.Equal(t, "block", step.StepTypeName())
+	require.Len(t, step.(*mj.BlockStep).Steps, 1)
+	require.Equal(t, uint64(2000000), step.(*mj.BlockStep).BlockGasLimit.Value)
+	require.Equal(t, "2,000,000", step.(*mj.BlockStep).BlockGasLimit.Original)
+
+	nestedSnippet := `
+	{
+		"step": "block",
+		"steps": [
+			{
+				"step": "setState"
+			}
+		]
+	}`
+
+	_, parseErr = p.ParseScenarioStep(nestedSnippet)
+	require.EqualError(t, parseErr, "error processing block steps: step not allowed inside a block: setState")
+}
+
+func TestParseCheckStateESDTInstanceNonce(t *testing.T) {
+	checkStateWithInstanceNonce := func(nonce string) string {
+		return `
+	{
+		"step": "checkState",
+		"accounts": {
+			"address:owner": {
+				"esdt": {
+					"str:NFT-123456": {
+						"instances": [
+							{
+								"nonce": "` + nonce + `",
+								"balance": "1"
+							}
+						]
+					}
+				}
+			}
+		}
+	}`
+	}
+
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(checkStateWithInstanceNonce("2"))
+	require.Nil(t, parseErr)
+	checkState := step.(*mj.CheckStateStep)
+	instance := checkState.CheckAccounts.Accounts[0].CheckESDTData[0].Instances[0]
+	require.Equal(t, uint64(2), instance.Nonce.Value)
+
+	// the nonce identifies the instance, so it must be exact
+	_, parseErr = p.ParseScenarioStep(checkStateWithInstanceNonce("*"))
+	require.NotNil(t, parseErr)
+	require.Contains(t, parseErr.Error(), "invalid ESDT instance nonce: the nonce identifies the instance, it cannot be *")
+
+	_, parseErr = p.ParseScenarioStep(checkStateWithInstanceNonce(">=1"))
+	require.NotNil(t, parseErr)
+	require.Contains(t, parseErr.Error(), "invalid ESDT instance nonce: the nonce identifies the instance, it cannot be a predicate")
+}
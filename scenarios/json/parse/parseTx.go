@@ -21,6 +21,7 @@ func (p *Parser) processTx(txType mj.TransactionType, blrRaw oj.OJsonObject) (*m
 	}
 
 	var err error
+	var typedArgumentsRaw oj.OJsonObject
 	for _, kvp := range bltMap.OrderedKV {
 
 		switch kvp.Key {
@@ -90,6 +91,8 @@ func (p *Parser) processTx(txType mj.TransactionType, blrRaw oj.OJsonObject) (*m
 			if txType == mj.Transfer && len(blt.Arguments) > 0 {
 				return nil, errors.New("function arguments not allowed for transfer transactions")
 			}
+		case "typedArguments":
+			typedArgumentsRaw = kvp.Value
 		case "contractCode":
 			blt.Code, err = p.processStringAsByteArray(kvp.Value)
 			if err != nil {
@@ -119,5 +122,13 @@ func (p *Parser) processTx(txType mj.TransactionType, blrRaw oj.OJsonObject) (*m
 		}
 	}
 
+	// typed arguments are encoded once the receiver and the function are known
+	if typedArgumentsRaw != nil {
+		err = p.processTypedArguments(&blt, typedArgumentsRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction typedArguments: %w", err)
+		}
+	}
+
 	return &blt, nil
 }
@@ -30,6 +30,9 @@ func (p *Parser) processTxExpectedResult(blrRaw oj.OJsonObject) (*mj.Transaction
 			if err != nil {
 				return nil, fmt.Errorf("invalid block result out: %w", err)
 			}
+		case "typedOut":
+			// encoded by the tx step, once the transaction is known
+			blr.TypedOut = &mj.TypedValues{Original: kvp.Value}
 		case "status":
 			blr.Status, err = p.processCheckBigInt(kvp.Value, bigIntSignedBytes)
 			if err != nil {
@@ -0,0 +1,138 @@
+package scenjsonparse
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	eabi "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/abi"
+	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
+)
+
+// processContractABIs loads the ABIs declared as "abi": {"<contract address>": "<path to .abi.json>"}
+func (p *Parser) processContractABIs(obj oj.OJsonObject) ([]*mj.ContractABI, error) {
+	abiMap, isMap := obj.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("abi not a JSON map")
+	}
+
+	var contractABIs []*mj.ContractABI
+	for _, kvp := range abiMap.OrderedKV {
+		address, err := p.parseAccountAddress(kvp.Key)
+		if err != nil {
+			return nil, fmt.Errorf("bad contract address %s: %w", kvp.Key, err)
+		}
+		path, err := p.parseString(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("bad ABI path for contract %s: %w", kvp.Key, err)
+		}
+		if p.ExprInterpreter.FileResolver == nil {
+			return nil, errors.New("parser FileResolver not provided")
+		}
+		abiJSON, err := p.ExprInterpreter.FileResolver.ResolveFileValue(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load ABI of contract %s: %w", kvp.Key, err)
+		}
+		abi, err := eabi.ParseABI(abiJSON)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load ABI of contract %s: %w", kvp.Key, err)
+		}
+
+		contractABIs = append(contractABIs, &mj.ContractABI{
+			Address: address,
+			Path:    path,
+			ABI:     abi,
+		})
+	}
+
+	return contractABIs, nil
+}
+
+func (p *Parser) setContractABIs(contractABIs []*mj.ContractABI) {
+	p.contractABIs = make(map[string]*eabi.ABI)
+	for _, contractABI := range contractABIs {
+		p.contractABIs[string(contractABI.Address.Value)] = contractABI.ABI
+	}
+}
+
+func (p *Parser) findEndpoint(tx *mj.Transaction) (*eabi.ABI, *eabi.Endpoint, error) {
+	if !tx.Type.HasFunction() {
+		return nil, nil, errors.New("typed values are only allowed for scCall and scQuery transactions")
+	}
+	abi, found := p.contractABIs[string(tx.To.Value)]
+	if !found {
+		return nil, nil, fmt.Errorf("no ABI declared for contract %s", tx.To.Original)
+	}
+	endpoint, err := abi.Endpoint(tx.Function)
+	if err != nil {
+		return nil, nil, err
+	}
+	return abi, endpoint, nil
+}
+
+// processTypedArguments encodes the typed arguments of a transaction, once the receiver and function are known
+func (p *Parser) processTypedArguments(tx *mj.Transaction, obj oj.OJsonObject) error {
+	if len(tx.Arguments) > 0 {
+		return errors.New("transaction cannot have both arguments and typedArguments")
+	}
+	abi, endpoint, err := p.findEndpoint(tx)
+	if err != nil {
+		return err
+	}
+
+	tx.TypedArguments, err = p.processTypedValues(abi, endpoint.Inputs, obj)
+	if err != nil {
+		return err
+	}
+	encoded, err := tx.TypedArguments.Codec.EncodeArguments(endpoint.Inputs, tx.TypedArguments.Values)
+	if err != nil {
+		return err
+	}
+	for _, argument := range encoded {
+		tx.Arguments = append(tx.Arguments, mj.JSONBytesFromTree{
+			Value:    argument,
+			Original: &oj.OJsonString{Value: "0x" + hex.EncodeToString(argument)},
+		})
+	}
+	return nil
+}
+
+// processTypedOut encodes the typed results expected from a transaction
+func (p *Parser) processTypedOut(tx *mj.Transaction, result *mj.TransactionResult) error {
+	if len(result.Out) > 0 {
+		return errors.New("expected result cannot have both out and typedOut")
+	}
+	abi, endpoint, err := p.findEndpoint(tx)
+	if err != nil {
+		return err
+	}
+
+	result.TypedOut, err = p.processTypedValues(abi, endpoint.Outputs, result.TypedOut.Original)
+	if err != nil {
+		return err
+	}
+	encoded, err := result.TypedOut.Codec.EncodeArguments(endpoint.Outputs, result.TypedOut.Values)
+	if err != nil {
+		return err
+	}
+	result.Out = make([]mj.JSONCheckBytes, 0, len(encoded))
+	for _, value := range encoded {
+		result.Out = append(result.Out, mj.JSONCheckBytesReconstructed(value, "0x"+hex.EncodeToString(value)))
+	}
+	return nil
+}
+
+func (p *Parser) processTypedValues(abi *eabi.ABI, params []*eabi.Param, obj oj.OJsonObject) (*mj.TypedValues, error) {
+	codec := eabi.NewCodec(abi)
+	values, err := codec.ValuesFromJSON(params, obj, &p.ExprInterpreter)
+	if err != nil {
+		return nil, err
+	}
+	return &mj.TypedValues{
+		Original: obj,
+		Codec:    codec,
+		Params:   params,
+		Values:   values,
+	}, nil
+}
@@ -2,7 +2,9 @@ package scenjsonparse
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 
 	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
@@ -25,6 +27,18 @@ func (p *Parser) processCheckBigInt(obj oj.OJsonObject, format bigIntParseFormat
 			Original: "*"}, nil
 	}
 
+	predicate, err := p.parseCheckPredicate(obj, format)
+	if err != nil {
+		return mj.JSONCheckBigInt{}, err
+	}
+	if predicate != nil {
+		return mj.JSONCheckBigInt{
+			Value:     nil,
+			Predicate: predicate,
+			Original:  obj.(*oj.OJsonString).Value,
+		}, nil
+	}
+
 	jbi, err := p.processBigInt(obj, format)
 	if err != nil {
 		return mj.JSONCheckBigInt{}, err
@@ -73,6 +87,18 @@ func (p *Parser) processCheckUint64(obj oj.OJsonObject) (mj.JSONCheckUint64, err
 			Original: "*"}, nil
 	}
 
+	predicate, err := p.parseCheckPredicate(obj, bigIntUnsignedBytes)
+	if err != nil {
+		return mj.JSONCheckUint64{}, err
+	}
+	if predicate != nil {
+		return mj.JSONCheckUint64{
+			Value:     0,
+			Predicate: predicate,
+			Original:  obj.(*oj.OJsonString).Value,
+		}, nil
+	}
+
 	ju, err := p.processUint64(obj)
 	if err != nil {
 		return mj.JSONCheckUint64{}, err
@@ -105,6 +131,18 @@ func (p *Parser) parseCheckBytes(obj oj.OJsonObject) (mj.JSONCheckBytes, error)
 		return mj.JSONCheckBytesStar(), nil
 	}
 
+	predicate, err := p.parseCheckPredicate(obj, bigIntUnsignedBytes)
+	if err != nil {
+		return mj.JSONCheckBytes{}, err
+	}
+	if predicate != nil {
+		return mj.JSONCheckBytes{
+			Value:     []byte{},
+			Predicate: predicate,
+			Original:  obj,
+		}, nil
+	}
+
 	jb, err := p.processSubTreeAsByteArray(obj)
 	if err != nil {
 		return mj.JSONCheckBytes{}, err
@@ -116,6 +154,93 @@ func (p *Parser) parseCheckBytes(obj oj.OJsonObject) (mj.JSONCheckBytes, error)
 	}, nil
 }
 
+// comparison operators, longest first
+var predicateComparisons = []struct {
+	prefix   string
+	operator mj.PredicateOperator
+}{
+	{">=", mj.PredicateGreaterOrEqual},
+	{"<=", mj.PredicateLessOrEqual},
+	{">", mj.PredicateGreater},
+	{"<", mj.PredicateLess},
+}
+
+// parseCheckPredicate recognizes the check values that are not equality assertions:
+// ">=X", ">X", "<=X", "<X", ranges "X..Y" and tolerances "X~P%".
+// Returns nil if the value is not a predicate.
+func (p *Parser) parseCheckPredicate(obj oj.OJsonObject, format bigIntParseFormat) (*mj.ValuePredicate, error) {
+	strVal, err := p.parseString(obj)
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, comparison := range predicateComparisons {
+		if strings.HasPrefix(strVal, comparison.prefix) {
+			operand, err := p.parseBigInt(strings.TrimSpace(strVal[len(comparison.prefix):]), format)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse check value %s: %w", strVal, err)
+			}
+			return &mj.ValuePredicate{
+				Operator: comparison.operator,
+				Operand:  operand,
+			}, nil
+		}
+	}
+
+	// ranges and tolerances only apply to numbers, "str:a..b" is just a string
+	if !startsWithNumber(strVal) {
+		return nil, nil
+	}
+
+	if separatorIndex := strings.Index(strVal, ".."); separatorIndex > 0 {
+		lowerBound, err := p.parseBigInt(strVal[:separatorIndex], format)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse lower bound of range %s: %w", strVal, err)
+		}
+		upperBound, err := p.parseBigInt(strVal[separatorIndex+2:], format)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse upper bound of range %s: %w", strVal, err)
+		}
+		if lowerBound.Cmp(upperBound) > 0 {
+			return nil, fmt.Errorf("empty range %s", strVal)
+		}
+		return &mj.ValuePredicate{
+			Operator:   mj.PredicateRange,
+			Operand:    lowerBound,
+			UpperBound: upperBound,
+		}, nil
+	}
+
+	if separatorIndex := strings.Index(strVal, "~"); separatorIndex > 0 {
+		operand, err := p.parseBigInt(strVal[:separatorIndex], format)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse value with tolerance %s: %w", strVal, err)
+		}
+		percentStr := strVal[separatorIndex+1:]
+		if !strings.HasSuffix(percentStr, "%") {
+			return nil, fmt.Errorf("tolerance should be a percentage, e.g. 1000~1%%: %s", strVal)
+		}
+		percent, ok := new(big.Rat).SetString(percentStr[:len(percentStr)-1])
+		if !ok || percent.Sign() < 0 {
+			return nil, fmt.Errorf("bad tolerance percentage %s", strVal)
+		}
+		return &mj.ValuePredicate{
+			Operator:         mj.PredicateTolerance,
+			Operand:          operand,
+			TolerancePercent: percent,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func startsWithNumber(str string) bool {
+	if len(str) > 0 && (str[0] == '-' || str[0] == '+') {
+		str = str[1:]
+	}
+	return len(str) > 0 && str[0] >= '0' && str[0] <= '9'
+}
+
 func (p *Parser) processStringAsByteArray(obj oj.OJsonObject) (mj.JSONBytesFromString, error) {
 	strVal, err := p.parseString(obj)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,3 +38,56 @@ func TestBigInt(t *testing.T) {
 	require.Nil(t, err)
 	require.True(t, big.NewInt(0).Cmp(result) == 0)
 }
+
+func TestCheckPredicate(t *testing.T) {
+	p := Parser{}
+	checkBigInt, err := p.processCheckBigInt(&oj.OJsonString{Value: ">=1000"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.Equal(t, ">=1000", checkBigInt.Original)
+	require.True(t, checkBigInt.Check(big.NewInt(1000)))
+	require.False(t, checkBigInt.Check(big.NewInt(999)))
+	require.Equal(t, "at least 1000", checkBigInt.Predicate.String())
+
+	checkUint64, err := p.processCheckUint64(&oj.OJsonString{Value: "<5"})
+	require.Nil(t, err)
+	require.True(t, checkUint64.Check(4))
+	require.False(t, checkUint64.Check(5))
+
+	checkBigInt, err = p.processCheckBigInt(&oj.OJsonString{Value: "1000..2000"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.True(t, checkBigInt.Check(big.NewInt(1000)))
+	require.True(t, checkBigInt.Check(big.NewInt(2000)))
+	require.False(t, checkBigInt.Check(big.NewInt(2001)))
+	require.Equal(t, "between 1000 and 2000", checkBigInt.Predicate.String())
+
+	checkBigInt, err = p.processCheckBigInt(&oj.OJsonString{Value: "1000~1%"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.True(t, checkBigInt.Check(big.NewInt(990)))
+	require.True(t, checkBigInt.Check(big.NewInt(1010)))
+	require.False(t, checkBigInt.Check(big.NewInt(1011)))
+	require.Equal(t, "within 1% of 1000", checkBigInt.Predicate.String())
+
+	checkBytes, err := p.parseCheckBytes(&oj.OJsonString{Value: ">0x0100"})
+	require.Nil(t, err)
+	require.True(t, checkBytes.Check([]byte{1, 1}))
+	require.False(t, checkBytes.Check([]byte{1}))
+	require.Equal(t, ">0x0100", checkBytes.Original.(*oj.OJsonString).Value)
+
+	// not predicates
+	checkBytes, err = p.parseCheckBytes(&oj.OJsonString{Value: "str:1..2"})
+	require.Nil(t, err)
+	require.Nil(t, checkBytes.Predicate)
+	require.True(t, checkBytes.Check([]byte("1..2")))
+
+	checkBigInt, err = p.processCheckBigInt(&oj.OJsonString{Value: "1000"}, bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.Nil(t, checkBigInt.Predicate)
+
+	// errors
+	_, err = p.processCheckBigInt(&oj.OJsonString{Value: "2000..1000"}, bigIntUnsignedBytes)
+	require.NotNil(t, err)
+	_, err = p.processCheckBigInt(&oj.OJsonString{Value: "1000~1"}, bigIntUnsignedBytes)
+	require.NotNil(t, err)
+	_, err = p.processCheckBigInt(&oj.OJsonString{Value: "1000~x%"}, bigIntUnsignedBytes)
+	require.NotNil(t, err)
+}
@@ -1,6 +1,7 @@
 package scenjsonparse
 
 import (
+	eabi "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/abi"
 	ei "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/interpreter"
 	fr "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/fileresolver"
 )
@@ -8,6 +9,9 @@ import (
 // Parser performs parsing of both json tests (older) and scenarios (new).
 type Parser struct {
 	ExprInterpreter ei.ExprInterpreter
+
+	// the ABIs declared by the scenario being parsed, by contract address
+	contractABIs map[string]*eabi.ABI
 }
 
 // NewParser provides a new Parser instance.
@@ -28,13 +28,13 @@ func AccountsToOJ(accounts []*mj.Account) oj.OJsonObject {
 		acctOJ.Put("storage", storageOJ)
 		acctOJ.Put("code", bytesFromStringToOJ(account.Code))
 		if len(account.Owner.Value) > 0 {
-			acctOJ.Put("owner", bytesFromStringToOJ(account.Owner))
+			acctOJ.Put("owner", addressToOJ(account.Owner))
 		}
 		if len(account.AsyncCallData) > 0 {
 			acctOJ.Put("asyncCallData", stringToOJ(account.AsyncCallData))
 		}
 
-		acctsOJ.Put(bytesFromStringToString(account.Address), acctOJ)
+		acctsOJ.Put(addressToString(account.Address), acctOJ)
 	}
 
 	return acctsOJ
@@ -86,7 +86,7 @@ func checkAccountsToOJ(checkAccounts *mj.CheckAccounts) oj.OJsonObject {
 			acctOJ.Put("asyncCallData", checkBytesToOJ(checkAccount.AsyncCallData))
 		}
 
-		acctsOJ.Put(bytesFromStringToString(checkAccount.Address), acctOJ)
+		acctsOJ.Put(addressToString(checkAccount.Address), acctOJ)
 	}
 
 	if checkAccounts.MoreAccountsAllowed {
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"math/big"
 
+	er "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/expression/reconstructor"
 	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 )
@@ -20,12 +21,16 @@ func blockHashesToOJ(blockHashes []mj.JSONBytesFromString) oj.OJsonObject {
 func resultToOJ(res *mj.TransactionResult) oj.OJsonObject {
 	resultOJ := oj.NewMap()
 
-	var outList []oj.OJsonObject
-	for _, out := range res.Out {
-		outList = append(outList, checkBytesToOJ(out))
+	if res.TypedOut != nil {
+		resultOJ.Put("typedOut", res.TypedOut.Original)
+	} else {
+		var outList []oj.OJsonObject
+		for _, out := range res.Out {
+			outList = append(outList, checkBytesToOJ(out))
+		}
+		outOJ := oj.OJsonList(outList)
+		resultOJ.Put("out", &outOJ)
 	}
-	outOJ := oj.OJsonList(outList)
-	resultOJ.Put("out", &outOJ)
 
 	if !res.Status.IsUnspecified() {
 		resultOJ.Put("status", checkBigIntToOJ(res.Status))
@@ -129,6 +134,20 @@ func bytesFromStringToOJ(bytes mj.JSONBytesFromString) oj.OJsonObject {
 	return &oj.OJsonString{Value: bytesFromStringToString(bytes)}
 }
 
+// addressToString keeps the original form of an address (e.g. "address:...", "bech32:erd1..."),
+// and for addresses built in code, without one, reconstructs a mock-style or bech32 form
+func addressToString(address mj.JSONBytesFromString) string {
+	if len(address.Original) == 0 && len(address.Value) > 0 {
+		reconstructor := er.ExprReconstructor{}
+		address.Original = reconstructor.Reconstruct(address.Value, er.AddressHint)
+	}
+	return address.Original
+}
+
+func addressToOJ(address mj.JSONBytesFromString) oj.OJsonObject {
+	return &oj.OJsonString{Value: addressToString(address)}
+}
+
 func bytesFromTreeToOJ(bytes mj.JSONBytesFromTree) oj.OJsonObject {
 	if bytes.OriginalEmpty() {
 		bytes.Original = &oj.OJsonString{Value: hex.EncodeToString(bytes.Value)}
@@ -64,6 +64,12 @@ func esdtItemToOJ(esdtItem *mj.ESDTData) oj.OJsonObject {
 	if len(esdtItem.Frozen.Original) > 0 {
 		esdtItemOJ.Put("frozen", uint64ToOJ(esdtItem.Frozen))
 	}
+	if len(esdtItem.Paused.Original) > 0 {
+		esdtItemOJ.Put("paused", uint64ToOJ(esdtItem.Paused))
+	}
+	if len(esdtItem.LimitedTransfer.Original) > 0 {
+		esdtItemOJ.Put("limitedTransfer", uint64ToOJ(esdtItem.LimitedTransfer))
+	}
 
 	return esdtItemOJ
 }
@@ -105,5 +111,11 @@ func isCompactESDT(esdtItem *mj.ESDTData) bool {
 	if len(esdtItem.Frozen.Original) > 0 {
 		return false
 	}
+	if len(esdtItem.Paused.Original) > 0 {
+		return false
+	}
+	if len(esdtItem.LimitedTransfer.Original) > 0 {
+		return false
+	}
 	return true
 }
@@ -1,14 +1,21 @@
 package scenjsonwrite
 
 import (
+	"strings"
+
 	mj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/json/model"
 	oj "github.com/multiversx/mx-chain-vm-v1_3-go/scenarios/orderedjson"
 )
 
 // ScenarioToJSONString converts a scenario object to its JSON representation.
 func ScenarioToJSONString(scenario *mj.Scenario) string {
+	return ScenarioToString(scenario, oj.FormatJSON)
+}
+
+// ScenarioToString converts a scenario object to its representation in any of the supported formats (JSON, JSON5, YAML).
+func ScenarioToString(scenario *mj.Scenario, format oj.Format) string {
 	jobj := ScenarioToOrderedJSON(scenario)
-	return oj.JSONString(jobj) + "\n"
+	return strings.TrimSuffix(oj.FormattedString(jobj, format), "\n") + "\n"
 }
 
 // ScenarioToOrderedJSON converts a scenario object to an ordered JSON object.
@@ -30,74 +37,130 @@ func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 
 	scenarioOJ.Put("gasSchedule", gasScheduleToOJ(scenario.GasSchedule))
 
-	var stepOJList []oj.OJsonObject
-
-	for _, generalStep := range scenario.Steps {
-		stepOJ := oj.NewMap()
-		stepOJ.Put("step", stringToOJ(generalStep.StepTypeName()))
-		switch step := generalStep.(type) {
-		case *mj.ExternalStepsStep:
-			if len(step.Comment) > 0 {
-				stepOJ.Put("comment", stringToOJ(step.Comment))
-			}
-			stepOJ.Put("path", stringToOJ(step.Path))
-		case *mj.SetStateStep:
-			if len(step.Comment) > 0 {
-				stepOJ.Put("comment", stringToOJ(step.Comment))
-			}
-			if len(step.Accounts) > 0 {
-				stepOJ.Put("accounts", AccountsToOJ(step.Accounts))
-			}
-			if len(step.NewAddressMocks) > 0 {
-				stepOJ.Put("newAddresses", newAddressMocksToOJ(step.NewAddressMocks))
-			}
-			if step.PreviousBlockInfo != nil {
-				stepOJ.Put("previousBlockInfo", blockInfoToOJ(step.PreviousBlockInfo))
-			}
-			if step.CurrentBlockInfo != nil {
-				stepOJ.Put("currentBlockInfo", blockInfoToOJ(step.CurrentBlockInfo))
-			}
-			if len(step.BlockHashes) > 0 {
-				stepOJ.Put("blockHashes", blockHashesToOJ(step.BlockHashes))
-			}
-		case *mj.CheckStateStep:
-			if len(step.Comment) > 0 {
-				stepOJ.Put("comment", stringToOJ(step.Comment))
-			}
-			stepOJ.Put("accounts", checkAccountsToOJ(step.CheckAccounts))
-		case *mj.DumpStateStep:
-			if len(step.Comment) > 0 {
-				stepOJ.Put("comment", stringToOJ(step.Comment))
-			}
-		case *mj.TxStep:
-			if len(step.TxIdent) > 0 {
-				stepOJ.Put("txId", stringToOJ(step.TxIdent))
-			}
-			if len(step.Comment) > 0 {
-				stepOJ.Put("comment", stringToOJ(step.Comment))
-			}
-			stepOJ.Put("tx", transactionToScenarioOJ(step.Tx))
-			if step.Tx.Type.IsSmartContractTx() && step.ExpectedResult != nil {
-				stepOJ.Put("expect", resultToOJ(step.ExpectedResult))
-			}
+	if len(scenario.EnableEpochs) > 0 {
+		enableEpochsOJ := oj.NewMap()
+		for _, flagActivation := range scenario.EnableEpochs {
+			enableEpochsOJ.Put(flagActivation.Flag, uint64ToOJ(flagActivation.Epoch))
 		}
+		scenarioOJ.Put("enableEpochs", enableEpochsOJ)
+	}
 
-		stepOJList = append(stepOJList, stepOJ)
+	if len(scenario.ContractABIs) > 0 {
+		abiOJ := oj.NewMap()
+		for _, contractABI := range scenario.ContractABIs {
+			abiOJ.Put(addressToString(contractABI.Address), stringToOJ(contractABI.Path))
+		}
+		scenarioOJ.Put("abi", abiOJ)
 	}
 
-	stepsOJ := oj.OJsonList(stepOJList)
-	scenarioOJ.Put("steps", &stepsOJ)
+	scenarioOJ.Put("steps", stepsToOJ(scenario.Steps))
 
 	return scenarioOJ
 }
 
+// StepToJSONString converts a single step to its JSON representation, e.g. to suggest it in an error message.
+func StepToJSONString(step mj.Step) string {
+	return oj.JSONString(StepToOrderedJSON(step))
+}
+
+// StepToOrderedJSON converts a single step to an ordered JSON object, e.g. to replace it in a scenario file.
+func StepToOrderedJSON(step mj.Step) oj.OJsonObject {
+	return stepToOJ(step)
+}
+
+func stepsToOJ(steps []mj.Step) oj.OJsonObject {
+	var stepOJList []oj.OJsonObject
+	for _, generalStep := range steps {
+		stepOJList = append(stepOJList, stepToOJ(generalStep))
+	}
+	stepsOJ := oj.OJsonList(stepOJList)
+	return &stepsOJ
+}
+
+func stepToOJ(generalStep mj.Step) oj.OJsonObject {
+	stepOJ := oj.NewMap()
+	stepOJ.Put("step", stringToOJ(generalStep.StepTypeName()))
+	switch step := generalStep.(type) {
+	case *mj.ExternalStepsStep:
+		if len(step.Comment) > 0 {
+			stepOJ.Put("comment", stringToOJ(step.Comment))
+		}
+		stepOJ.Put("path", stringToOJ(step.Path))
+		if len(step.Parameters) > 0 {
+			parametersOJ := oj.NewMap()
+			for _, parameter := range step.Parameters {
+				parametersOJ.Put(parameter.Name, stringToOJ(parameter.Value))
+			}
+			stepOJ.Put("parameters", parametersOJ)
+		}
+	case *mj.SetStateStep:
+		if len(step.Comment) > 0 {
+			stepOJ.Put("comment", stringToOJ(step.Comment))
+		}
+		if len(step.Accounts) > 0 {
+			stepOJ.Put("accounts", AccountsToOJ(step.Accounts))
+		}
+		if len(step.NewAddressMocks) > 0 {
+			stepOJ.Put("newAddresses", newAddressMocksToOJ(step.NewAddressMocks))
+		}
+		if step.NewAddressDerivation != mj.NewAddressDerivationUnspecified {
+			stepOJ.Put("newAddressDerivation", stringToOJ(string(step.NewAddressDerivation)))
+		}
+		if step.PreviousBlockInfo != nil {
+			stepOJ.Put("previousBlockInfo", blockInfoToOJ(step.PreviousBlockInfo))
+		}
+		if step.CurrentBlockInfo != nil {
+			stepOJ.Put("currentBlockInfo", blockInfoToOJ(step.CurrentBlockInfo))
+		}
+		if len(step.BlockHashes) > 0 {
+			stepOJ.Put("blockHashes", blockHashesToOJ(step.BlockHashes))
+		}
+	case *mj.CheckStateStep:
+		if len(step.Comment) > 0 {
+			stepOJ.Put("comment", stringToOJ(step.Comment))
+		}
+		stepOJ.Put("accounts", checkAccountsToOJ(step.CheckAccounts))
+		if !step.StateRootHash.IsUnspecified() {
+			stepOJ.Put("stateRootHash", checkBytesToOJ(step.StateRootHash))
+		}
+	case *mj.DumpStateStep:
+		if len(step.Comment) > 0 {
+			stepOJ.Put("comment", stringToOJ(step.Comment))
+		}
+	case *mj.BlockStep:
+		if len(step.Comment) > 0 {
+			stepOJ.Put("comment", stringToOJ(step.Comment))
+		}
+		if step.CurrentBlockInfo != nil {
+			stepOJ.Put("currentBlockInfo", blockInfoToOJ(step.CurrentBlockInfo))
+		}
+		if len(step.BlockGasLimit.Original) > 0 {
+			stepOJ.Put("blockGasLimit", uint64ToOJ(step.BlockGasLimit))
+		}
+		stepOJ.Put("steps", stepsToOJ(step.Steps))
+	case *mj.TxStep:
+		if len(step.TxIdent) > 0 {
+			stepOJ.Put("txId", stringToOJ(step.TxIdent))
+		}
+		if len(step.Comment) > 0 {
+			stepOJ.Put("comment", stringToOJ(step.Comment))
+		}
+		stepOJ.Put("tx", transactionToScenarioOJ(step.Tx))
+		if step.Tx.Type.IsSmartContractTx() && step.ExpectedResult != nil {
+			stepOJ.Put("expect", resultToOJ(step.ExpectedResult))
+		}
+	}
+
+	return stepOJ
+}
+
 func transactionToScenarioOJ(tx *mj.Transaction) oj.OJsonObject {
 	transactionOJ := oj.NewMap()
 	if tx.Type.HasSender() {
-		transactionOJ.Put("from", bytesFromStringToOJ(tx.From))
+		transactionOJ.Put("from", addressToOJ(tx.From))
 	}
 	if tx.Type.HasReceiver() {
-		transactionOJ.Put("to", bytesFromStringToOJ(tx.To))
+		transactionOJ.Put("to", addressToOJ(tx.To))
 	}
 	if tx.Type.HasValue() {
 		transactionOJ.Put("value", bigIntToOJ(tx.Value))
@@ -113,7 +176,9 @@ func transactionToScenarioOJ(tx *mj.Transaction) oj.OJsonObject {
 		transactionOJ.Put("contractCode", bytesFromStringToOJ(tx.Code))
 	}
 
-	if tx.Type.HasFunction() || tx.Type == mj.ScDeploy {
+	if tx.TypedArguments != nil {
+		transactionOJ.Put("typedArguments", tx.TypedArguments.Original)
+	} else if tx.Type.HasFunction() || tx.Type == mj.ScDeploy {
 		var argList []oj.OJsonObject
 		for _, arg := range tx.Arguments {
 			argList = append(argList, bytesFromTreeToOJ(arg))
@@ -134,9 +199,9 @@ func newAddressMocksToOJ(newAddressMocks []*mj.NewAddressMock) oj.OJsonObject {
 	var namList []oj.OJsonObject
 	for _, namEntry := range newAddressMocks {
 		namOJ := oj.NewMap()
-		namOJ.Put("creatorAddress", bytesFromStringToOJ(namEntry.CreatorAddress))
+		namOJ.Put("creatorAddress", addressToOJ(namEntry.CreatorAddress))
 		namOJ.Put("creatorNonce", uint64ToOJ(namEntry.CreatorNonce))
-		namOJ.Put("newAddress", bytesFromStringToOJ(namEntry.NewAddress))
+		namOJ.Put("newAddress", addressToOJ(namEntry.NewAddress))
 		namList = append(namList, namOJ)
 	}
 	namOJList := oj.OJsonList(namList)
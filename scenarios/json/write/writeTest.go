@@ -49,7 +49,7 @@ func transactionToTestOJ(tx *mj.Transaction) oj.OJsonObject {
 	transactionOJ.Put("function", stringToOJ(tx.Function))
 	transactionOJ.Put("gasLimit", uint64ToOJ(tx.GasLimit))
 	transactionOJ.Put("value", bigIntToOJ(tx.Value))
-	transactionOJ.Put("to", bytesFromStringToOJ(tx.To))
+	transactionOJ.Put("to", addressToOJ(tx.To))
 
 	var argList []oj.OJsonObject
 	for _, arg := range tx.Arguments {
@@ -62,7 +62,7 @@ func transactionToTestOJ(tx *mj.Transaction) oj.OJsonObject {
 		transactionOJ.Put("contractCode", bytesFromStringToOJ(tx.Code))
 	}
 	transactionOJ.Put("gasPrice", uint64ToOJ(tx.GasPrice))
-	transactionOJ.Put("from", bytesFromStringToOJ(tx.From))
+	transactionOJ.Put("from", addressToOJ(tx.From))
 
 	return transactionOJ
 }
@@ -0,0 +1,101 @@
+package orderedjson
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Format is a syntax the ordered JSON model can be read from and written in.
+type Format int
+
+const (
+	// FormatJSON is strict JSON, the default.
+	FormatJSON Format = iota
+
+	// FormatJSON5 is JSON with comments, trailing commas, unquoted keys, single quoted strings and unquoted numbers.
+	FormatJSON5
+
+	// FormatYAML is YAML, with the key order of its maps preserved.
+	FormatYAML
+)
+
+// formatExtensions lists the file extensions of each format, the first one being used when writing
+var formatExtensions = []struct {
+	extension string
+	format    Format
+}{
+	{".json", FormatJSON},
+	{".json5", FormatJSON5},
+	{".yaml", FormatYAML},
+	{".yml", FormatYAML},
+}
+
+// FormatFromPath selects the format by the file extension; unknown extensions are JSON.
+func FormatFromPath(path string) Format {
+	extension := filepath.Ext(path)
+	for _, formatExtension := range formatExtensions {
+		if formatExtension.extension == extension {
+			return formatExtension.format
+		}
+	}
+	return FormatJSON
+}
+
+// Extension yields the file extension of the format, e.g. ".yaml".
+func (format Format) Extension() string {
+	for _, formatExtension := range formatExtensions {
+		if formatExtension.format == format {
+			return formatExtension.extension
+		}
+	}
+	return ".json"
+}
+
+// HasSuffixInAnyFormat checks the path against a suffix such as ".scen.json",
+// accepting it with the extension of any format, e.g. "test.scen.yaml" or "test.scen.json5".
+func HasSuffixInAnyFormat(path string, suffix string) bool {
+	suffixBase := strings.TrimSuffix(suffix, filepath.Ext(suffix))
+	for _, formatExtension := range formatExtensions {
+		if strings.HasSuffix(path, suffixBase+formatExtension.extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOrderedFormat parses any of the formats, preserving order in maps.
+func ParseOrderedFormat(input []byte, format Format) (OJsonObject, error) {
+	switch format {
+	case FormatJSON5:
+		return ParseOrderedJSON5(input)
+	case FormatYAML:
+		return ParseOrderedYAML(input)
+	default:
+		return ParseOrderedJSON(input)
+	}
+}
+
+// HasComments tells whether the input contains comments, which the ordered JSON model does not keep.
+// Strict JSON has no comments.
+func HasComments(input []byte, format Format) (bool, error) {
+	switch format {
+	case FormatJSON5:
+		return json5HasComments(input)
+	case FormatYAML:
+		return yamlHasComments(input)
+	default:
+		return false, nil
+	}
+}
+
+// FormattedString returns a formatted string representation of an ordered JSON, in any of the formats.
+func FormattedString(j OJsonObject, format Format) string {
+	switch format {
+	case FormatJSON5:
+		return JSON5String(j)
+	case FormatYAML:
+		return YAMLString(j)
+	default:
+		return JSONString(j)
+	}
+}
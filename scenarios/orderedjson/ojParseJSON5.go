@@ -0,0 +1,183 @@
+package orderedjson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ParseOrderedJSON5 parses JSON5 preserving order in maps.
+// The JSON5 extensions are rewritten to strict JSON first:
+// comments are dropped, trailing commas removed, unquoted keys and values quoted,
+// single quoted strings converted to double quoted ones.
+func ParseOrderedJSON5(input []byte) (OJsonObject, error) {
+	jsonInput, err := json5ToJSON(input)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOrderedJSON(jsonInput)
+}
+
+func isJSON5TokenChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '$' || c == '+' || c == '-' || c == '.'
+}
+
+func json5ToJSON(input []byte) ([]byte, error) {
+	var out bytes.Buffer
+	i := 0
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == '"':
+			end, err := stringEnd(input, i)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(input[i:end])
+			i = end
+		case c == '\'':
+			end, err := stringEnd(input, i)
+			if err != nil {
+				return nil, err
+			}
+			writeSingleQuotedAsDoubleQuoted(&out, input[i+1:end-1])
+			i = end
+		case c == '/':
+			end, err := commentEnd(input, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case c == ',':
+			next, err := skipInsignificant(input, i+1)
+			if err != nil {
+				return nil, err
+			}
+			if next < len(input) && (input[next] == ']' || input[next] == '}') {
+				// trailing comma
+				i++
+				continue
+			}
+			out.WriteByte(c)
+			i++
+		case isJSON5TokenChar(c):
+			end := i
+			for end < len(input) && isJSON5TokenChar(input[end]) {
+				end++
+			}
+			token := string(input[i:end])
+			next, err := skipInsignificant(input, end)
+			if err != nil {
+				return nil, err
+			}
+			isKey := next < len(input) && input[next] == ':'
+			if !isKey && (token == "true" || token == "false" || token == "null") {
+				out.WriteString(token)
+			} else {
+				// keys and numbers; other bare words are read as strings as well, the same as in YAML
+				out.WriteString(`"` + token + `"`)
+			}
+			i = end
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// json5HasComments looks for comments outside of strings; bare words cannot contain slashes
+func json5HasComments(input []byte) (bool, error) {
+	for i := 0; i < len(input); i++ {
+		switch input[i] {
+		case '"', '\'':
+			end, err := stringEnd(input, i)
+			if err != nil {
+				return false, err
+			}
+			i = end - 1
+		case '/':
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// stringEnd yields the position after the closing quote of the string starting at start
+func stringEnd(input []byte, start int) (int, error) {
+	quote := input[start]
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1, nil
+		}
+	}
+	return 0, errors.New("unterminated string")
+}
+
+// commentEnd yields the position after the comment starting at start; line comments keep their newline
+func commentEnd(input []byte, start int) (int, error) {
+	if start+1 >= len(input) {
+		return 0, errors.New("misplaced character /")
+	}
+	switch input[start+1] {
+	case '/':
+		end := bytes.IndexByte(input[start:], '\n')
+		if end < 0 {
+			return len(input), nil
+		}
+		return start + end, nil
+	case '*':
+		end := bytes.Index(input[start+2:], []byte("*/"))
+		if end < 0 {
+			return 0, errors.New("unterminated comment")
+		}
+		return start + 2 + end + 2, nil
+	default:
+		return 0, fmt.Errorf("misplaced character /%c", input[start+1])
+	}
+}
+
+// skipInsignificant yields the position of the next character that is neither whitespace nor in a comment
+func skipInsignificant(input []byte, start int) (int, error) {
+	i := start
+	for i < len(input) {
+		switch {
+		case isWhitespace(input[i]):
+			i++
+		case input[i] == '/':
+			end, err := commentEnd(input, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+// writeSingleQuotedAsDoubleQuoted keeps the escapes, like the JSON parser does, except for the quotes themselves
+func writeSingleQuotedAsDoubleQuoted(out *bytes.Buffer, contents []byte) {
+	out.WriteByte('"')
+	for i := 0; i < len(contents); i++ {
+		c := contents[i]
+		switch {
+		case c == '\\' && i+1 < len(contents) && contents[i+1] == '\'':
+			out.WriteByte('\'')
+			i++
+		case c == '\\' && i+1 < len(contents):
+			out.Write(contents[i : i+2])
+			i++
+		case c == '"':
+			out.WriteString(`\"`)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	out.WriteByte('"')
+}
@@ -0,0 +1,58 @@
+package orderedjson
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var json5IdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// JSON5String returns a JSON5 representation of an ordered JSON:
+// keys are unquoted where possible, and all items are followed by commas, so lines can be moved freely.
+func JSON5String(j OJsonObject) string {
+	var sb strings.Builder
+	writeJSON5(j, &sb, 0)
+	return sb.String()
+}
+
+func writeJSON5(j OJsonObject, sb *strings.Builder, indent int) {
+	switch typed := j.(type) {
+	case *OJsonMap:
+		if typed.Size() == 0 {
+			sb.WriteString("{}")
+			return
+		}
+		sb.WriteString("{\n")
+		for _, child := range typed.OrderedKV {
+			addIndent(sb, indent+1)
+			if json5IdentifierRegex.MatchString(child.Key) {
+				sb.WriteString(child.Key)
+			} else {
+				sb.WriteString(fmt.Sprintf("\"%s\"", child.Key))
+			}
+			sb.WriteString(": ")
+			writeJSON5(child.Value, sb, indent+1)
+			sb.WriteString(",\n")
+		}
+		addIndent(sb, indent)
+		sb.WriteString("}")
+	case *OJsonList:
+		collection := typed.AsList()
+		if len(collection) == 0 {
+			sb.WriteString("[]")
+			return
+		}
+		sb.WriteString("[\n")
+		for _, child := range collection {
+			addIndent(sb, indent+1)
+			writeJSON5(child, sb, indent+1)
+			sb.WriteString(",\n")
+		}
+		addIndent(sb, indent)
+		sb.WriteString("]")
+	case nil:
+	default:
+		typed.writeJSON(sb, indent)
+	}
+}
@@ -0,0 +1,177 @@
+package orderedjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML integers are read as their text, so they can be written unquoted
+var yamlPlainIntegerRegex = regexp.MustCompile(`^[-+]?(0x[0-9a-fA-F]+|[0-9]+)$`)
+
+// ParseOrderedYAML parses YAML preserving order in maps.
+// Numbers are read as strings, so "1000" and 1000 are the same, and nulls as empty strings.
+func ParseOrderedYAML(input []byte) (OJsonObject, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(input, &document)
+	if err != nil {
+		return nil, err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return nil, errors.New("YAML input should contain a single document")
+	}
+	return yamlNodeToOJ(document.Content[0])
+}
+
+func yamlHasComments(input []byte) (bool, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(input, &document)
+	if err != nil {
+		return false, err
+	}
+	return yamlNodeHasComments(&document), nil
+}
+
+func yamlNodeHasComments(node *yaml.Node) bool {
+	if len(node.HeadComment) > 0 || len(node.LineComment) > 0 || len(node.FootComment) > 0 {
+		return true
+	}
+	for _, child := range node.Content {
+		if yamlNodeHasComments(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func yamlNodeToOJ(node *yaml.Node) (OJsonObject, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlNodeToOJ(node.Alias)
+	case yaml.MappingNode:
+		result := NewMap()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			if keyNode.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: map keys should be scalars", keyNode.Line)
+			}
+			value, err := yamlNodeToOJ(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			result.Put(escapeJSONString(keyNode.Value), value)
+		}
+		return result, nil
+	case yaml.SequenceNode:
+		list := make(OJsonList, 0, len(node.Content))
+		for _, itemNode := range node.Content {
+			item, err := yamlNodeToOJ(itemNode)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return &list, nil
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!bool":
+			var value bool
+			err := node.Decode(&value)
+			if err != nil {
+				return nil, err
+			}
+			result := OJsonBool(value)
+			return &result, nil
+		case "!!null":
+			return &OJsonString{Value: ""}, nil
+		default:
+			return &OJsonString{Value: escapeJSONString(node.Value)}, nil
+		}
+	default:
+		return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+	}
+}
+
+// YAMLString returns a YAML representation of an ordered JSON.
+func YAMLString(j OJsonObject) string {
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	err := encoder.Encode(ojToYAMLNode(j))
+	if err != nil {
+		// the nodes are built here, so encoding them cannot fail
+		panic(err)
+	}
+	return sb.String()
+}
+
+func ojToYAMLNode(j OJsonObject) *yaml.Node {
+	switch typed := j.(type) {
+	case *OJsonMap:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		if typed.Size() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, kvp := range typed.OrderedKV {
+			node.Content = append(node.Content,
+				yamlStringNode(kvp.Key),
+				ojToYAMLNode(kvp.Value))
+		}
+		return node
+	case *OJsonList:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		if len(typed.AsList()) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, item := range typed.AsList() {
+			node.Content = append(node.Content, ojToYAMLNode(item))
+		}
+		return node
+	case *OJsonBool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%v", bool(*typed))}
+	case *OJsonString:
+		return yamlStringNode(typed.Value)
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	}
+}
+
+func yamlStringNode(jsonValue string) *yaml.Node {
+	value := unescapeJSONString(jsonValue)
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if yamlPlainIntegerRegex.MatchString(value) {
+		node.Tag = ""
+	}
+	if strings.Contains(value, "'") {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	return node
+}
+
+// escapeJSONString converts a value to the form the JSON parser keeps strings in, with their escapes
+func escapeJSONString(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(value)
+	if err != nil {
+		return value
+	}
+	escaped := strings.TrimSuffix(buffer.String(), "\n")
+	return escaped[1 : len(escaped)-1]
+}
+
+// unescapeJSONString is the inverse of escapeJSONString; invalid escapes are kept as they are
+func unescapeJSONString(jsonValue string) string {
+	var value string
+	err := json.Unmarshal([]byte(`"`+jsonValue+`"`), &value)
+	if err != nil {
+		return jsonValue
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+package testcommon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-common-go"
+	worldmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/world"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+)
+
+// RunContractCreateInWorld deploys a contract on the host, against the MockWorld, the way scenarios
+// execute transactions: the caller pays for the gas upfront, and the changes are committed to the world
+// if the deployment succeeds and rolled back otherwise. The output is then open for verification.
+func RunContractCreateInWorld(tb testing.TB, host vmhost.VMHost, world *worldmock.MockWorld, input *vmcommon.ContractCreateInput) *VMOutputVerifier {
+	vmOutput, err := runInWorld(world, &input.VMInput, func() (*vmcommon.VMOutput, error) {
+		return host.RunSmartContractCreate(input)
+	})
+	return NewVMOutputVerifier(tb, vmOutput, err)
+}
+
+// RunContractCallInWorld calls a contract on the host, against the MockWorld, the way scenarios
+// execute transactions: the caller pays for the gas upfront, the ESDT transfer, if any, is performed
+// before the call, and the changes are committed to the world if the call succeeds and rolled back otherwise.
+// The output is then open for verification.
+func RunContractCallInWorld(tb testing.TB, host vmhost.VMHost, world *worldmock.MockWorld, input *vmcommon.ContractCallInput) *VMOutputVerifier {
+	vmOutput, err := runInWorld(world, &input.VMInput, func() (*vmcommon.VMOutput, error) {
+		if len(input.ESDTTransfers) > 0 {
+			esdtTransfer := input.ESDTTransfers[0]
+			gasRemaining, err := world.BuiltinFuncs.PerformDirectESDTTransfer(
+				input.CallerAddr,
+				input.RecipientAddr,
+				esdtTransfer.ESDTTokenName,
+				esdtTransfer.ESDTTokenNonce,
+				esdtTransfer.ESDTValue,
+				input.CallType,
+				input.GasProvided,
+				input.GasPrice)
+			if err != nil {
+				return nil, err
+			}
+			input.GasProvided = gasRemaining
+		}
+		return host.RunSmartContractCall(input)
+	})
+	return NewVMOutputVerifier(tb, vmOutput, err)
+}
+
+func runInWorld(world *worldmock.MockWorld, vmInput *vmcommon.VMInput, run func() (*vmcommon.VMOutput, error)) (*vmcommon.VMOutput, error) {
+	world.CreateStateBackup()
+
+	vmOutput, err := func() (*vmcommon.VMOutput, error) {
+		err := world.UpdateWorldStateBefore(vmInput.CallerAddr, vmInput.GasProvided, vmInput.GasPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		vmOutput, err := run()
+		if err != nil || vmOutput.ReturnCode != vmcommon.Ok {
+			return vmOutput, err
+		}
+
+		// the call value leaving the caller is not part of the output accounts
+		err = world.UpdateBalanceWithDelta(vmInput.CallerAddr, big.NewInt(0).Neg(vmInput.CallValue))
+		if err != nil {
+			return nil, err
+		}
+		return vmOutput, world.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
+	}()
+
+	if err != nil || vmOutput.ReturnCode != vmcommon.Ok {
+		errRollback := world.RollbackChanges()
+		if err == nil {
+			err = errRollback
+		}
+		return vmOutput, err
+	}
+	return vmOutput, world.CommitChanges()
+}
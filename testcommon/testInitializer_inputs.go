@@ -449,6 +449,18 @@ func (contractInput *ContractCallInputBuilder) WithGasProvided(gas uint64) *Cont
 	return contractInput
 }
 
+// WithCallValue provides the CallValue for ContractCallInputBuilder
+func (contractInput *ContractCallInputBuilder) WithCallValue(callValue int64) *ContractCallInputBuilder {
+	contractInput.ContractCallInput.VMInput.CallValue = big.NewInt(callValue)
+	return contractInput
+}
+
+// WithGasPrice provides the GasPrice for ContractCallInputBuilder
+func (contractInput *ContractCallInputBuilder) WithGasPrice(gasPrice uint64) *ContractCallInputBuilder {
+	contractInput.ContractCallInput.VMInput.GasPrice = gasPrice
+	return contractInput
+}
+
 // WithFunction provides the function to be called for ContractCallInputBuilder
 func (contractInput *ContractCallInputBuilder) WithFunction(function string) *ContractCallInputBuilder {
 	contractInput.ContractCallInput.Function = function
@@ -494,6 +506,13 @@ func (contractInput *ContractCallInputBuilder) WithESDTTokenName(esdtTokenName [
 	return contractInput
 }
 
+// WithESDTTokenNonce provides the ESDTTokenNonce for ContractCallInputBuilder
+func (contractInput *ContractCallInputBuilder) WithESDTTokenNonce(esdtTokenNonce uint64) *ContractCallInputBuilder {
+	contractInput.initESDTTransferIfNeeded()
+	contractInput.ContractCallInput.ESDTTransfers[0].ESDTTokenNonce = esdtTokenNonce
+	return contractInput
+}
+
 // Build completes the build of a ContractCallInput
 func (contractInput *ContractCallInputBuilder) Build() *vmcommon.ContractCallInput {
 	return &contractInput.ContractCallInput
@@ -535,6 +554,12 @@ func (contractInput *ContractCreateInputBuilder) WithCallValue(callValue int64)
 	return contractInput
 }
 
+// WithGasPrice provides the GasPrice for a ContractCreateInputBuilder
+func (contractInput *ContractCreateInputBuilder) WithGasPrice(gasPrice uint64) *ContractCreateInputBuilder {
+	contractInput.ContractCreateInput.GasPrice = gasPrice
+	return contractInput
+}
+
 // WithArguments provides the Arguments for a ContractCreateInputBuilder
 func (contractInput *ContractCreateInputBuilder) WithArguments(arguments ...[]byte) *ContractCreateInputBuilder {
 	contractInput.ContractCreateInput.Arguments = arguments
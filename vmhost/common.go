@@ -1,6 +1,8 @@
 package vmhost
 
 import (
+	"time"
+
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-v1_3-go/config"
 )
@@ -25,8 +27,17 @@ const (
 
 	// BreakpointOutOfGas means that Wasmer must stop immediately due to gas being exhausted
 	BreakpointOutOfGas
+
+	// BreakpointExecutionTimeout means that Wasmer must stop immediately because the wall-clock
+	// deadline of the execution has been reached
+	BreakpointExecutionTimeout
 )
 
+// ExecutionTimedOut is the return code of an execution stopped by its wall-clock deadline, so that callers
+// can tell a timeout from a failure of the contract itself. It extends the return codes defined by vmcommon.ReturnCode,
+// following the last of them; vmcommon.ReturnCode.String() reports it as an unknown code.
+const ExecutionTimedOut = vmcommon.SimulateFailed + 1
+
 // AsyncCallExecutionMode encodes the execution modes of an AsyncCall
 type AsyncCallExecutionMode uint
 
@@ -100,6 +111,23 @@ const (
 	UpgradeFunctionName = "upgradeContract"
 )
 
+// ESDTFunctionsImports lists the EEI functions which a contract may only
+// import after the ESDT functions have been enabled
+var ESDTFunctionsImports = []string{
+	"transferESDTExecute",
+	"transferESDTNFTExecute",
+	"transferValueExecute",
+	"getESDTBalance",
+	"getESDTTokenData",
+	"getESDTTokenType",
+	"getESDTTokenNonce",
+	"getCurrentESDTNFTNonce",
+	"getESDTNFTNameLength",
+	"getESDTNFTAttributeLength",
+	"getESDTNFTURILength",
+	"bigIntGetESDTExternalBalance",
+}
+
 // CodeDeployInput contains code deploy state, whether it comes from a ContractCreateInput or a ContractCallInput
 type CodeDeployInput struct {
 	ContractCode         []byte
@@ -118,6 +146,8 @@ type VMHostParameters struct {
 	WasmerSIGSEGVPassthrough bool
 	UseWarmInstance          bool
 	EnableEpochsHandler      EnableEpochsHandler
+	// ExecutionTimeout is the optional wall-clock deadline of each execution; 0 disables it
+	ExecutionTimeout time.Duration
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
@@ -445,6 +445,11 @@ func (context *meteringContext) BlockGasLimit() uint64 {
 	return context.blockGasLimit
 }
 
+// SetBlockGasLimit sets the gas limit for the current block
+func (context *meteringContext) SetBlockGasLimit(gasLimit uint64) {
+	context.blockGasLimit = gasLimit
+}
+
 // DeductInitialGasForExecution deducts gas for compilation and locks gas if the execution is an asynchronous call
 func (context *meteringContext) DeductInitialGasForExecution(contract []byte) error {
 	costPerByte := context.gasSchedule.BaseOperationCost.CompilePerByte
@@ -488,6 +488,9 @@ func (context *outputContext) resolveReturnCodeFromError(err error) vmcommon.Ret
 	if errors.Is(err, vmhost.ErrNotEnoughGas) {
 		return vmcommon.OutOfGas
 	}
+	if errors.Is(err, vmhost.ErrExecutionTimeout) {
+		return vmhost.ExecutionTimedOut
+	}
 	if errors.Is(err, vmhost.ErrContractNotFound) {
 		return vmcommon.ContractNotFound
 	}
@@ -386,6 +386,24 @@ func TestOutputContext_VMOutputError(t *testing.T) {
 	require.Equal(t, expected, vmOutput)
 }
 
+func TestOutputContext_VMOutputErrorTimeout(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{
+		MeteringContext: &contextmock.MeteringContextMock{},
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			VMInput: &vmcommon.VMInput{},
+		},
+	}
+
+	outputContext, _ := NewOutputContext(host)
+
+	vmOutput := outputContext.CreateVMOutputInCaseOfError(vmhost.ErrExecutionTimeout)
+	require.Equal(t, vmhost.ExecutionTimedOut, vmOutput.ReturnCode)
+	require.NotEqual(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionTimeout.Error(), vmOutput.ReturnMessage)
+}
+
 func TestOutputContext_Transfer(t *testing.T) {
 	t.Parallel()
 
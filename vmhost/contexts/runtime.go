@@ -6,6 +6,7 @@ import (
 	"fmt"
 	builtinMath "math"
 	"math/big"
+	"sync"
 	"unsafe"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -51,6 +52,9 @@ type runtimeContext struct {
 
 	instanceBuilder vmhost.InstanceBuilder
 
+	mutInstance        sync.Mutex
+	executionInterrupt vmhost.BreakpointValue
+
 	errors vmhost.WrappableError
 }
 
@@ -111,11 +115,11 @@ func (context *runtimeContext) setWarmInstanceWhenNeeded(gasLimit uint64) bool {
 	if scAddress != nil && useWarm {
 		logRuntime.Trace("reusing warm instance")
 
-		context.instance = context.warmInstance
-		context.SetPointsUsed(0)
-		context.instance.SetGasLimit(gasLimit)
+		context.warmInstance.SetPointsUsed(0)
+		context.warmInstance.SetGasLimit(gasLimit)
+		context.warmInstance.SetBreakpointValue(uint64(vmhost.BreakpointNone))
 
-		context.SetRuntimeBreakpointValue(vmhost.BreakpointNone)
+		context.setInstance(context.warmInstance)
 		return true
 	}
 
@@ -125,7 +129,7 @@ func (context *runtimeContext) setWarmInstanceWhenNeeded(gasLimit uint64) bool {
 // StartWasmerInstance creates a new wasmer instance if the maxWasmerInstances has not been reached.
 func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error {
 	if context.RunningInstancesCount() >= context.maxWasmerInstances {
-		context.instance = nil
+		context.setInstance(nil)
 		logRuntime.Error("create instance", "error", vmhost.ErrMaxInstancesReached)
 		return vmhost.ErrMaxInstancesReached
 	}
@@ -177,7 +181,7 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 		return false
 	}
 
-	context.instance = newInstance
+	context.setInstance(newInstance)
 
 	hostReference := uintptr(unsafe.Pointer(&context.host))
 	context.instance.SetContextData(hostReference)
@@ -200,12 +204,12 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	}
 	newInstance, err := context.instanceBuilder.NewInstanceWithOptions(contract, options)
 	if err != nil {
-		context.instance = nil
+		context.setInstance(nil)
 		logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
 		return err
 	}
 
-	context.instance = newInstance
+	context.setInstance(newInstance)
 
 	if newCode || len(codeHash) == 0 {
 		codeHash, err = context.host.Crypto().Sha256(contract)
@@ -284,9 +288,9 @@ func (context *runtimeContext) ResetWarmInstance() {
 		return
 	}
 
-	context.instance.Clean()
+	// detached first, so that the watchdog cannot interrupt an instance already freed
+	context.detachInstance().Clean()
 
-	context.instance = nil
 	context.warmInstanceAddress = nil
 	context.warmInstance = nil
 	logRuntime.Trace("warm instance cleaned")
@@ -383,6 +387,58 @@ func (context *runtimeContext) ClearStateStack() {
 	context.stateStack = make([]*runtimeContext, 0)
 }
 
+// setInstance replaces the current wasmer instance; a pending execution
+// interrupt is applied to the new instance as well.
+func (context *runtimeContext) setInstance(instance wasmer.InstanceHandler) {
+	context.mutInstance.Lock()
+	defer context.mutInstance.Unlock()
+
+	context.instance = instance
+	context.applyExecutionInterrupt()
+}
+
+// detachInstance unsets the current wasmer instance and returns it, under the same
+// lock as SetExecutionInterrupt; only the detached instance can be safely cleaned.
+func (context *runtimeContext) detachInstance() wasmer.InstanceHandler {
+	context.mutInstance.Lock()
+	defer context.mutInstance.Unlock()
+
+	instance := context.instance
+	context.instance = nil
+	return instance
+}
+
+// applyExecutionInterrupt stops the current wasmer instance at its next
+// breakpoint or metering check; must be called with mutInstance held.
+func (context *runtimeContext) applyExecutionInterrupt() {
+	if context.executionInterrupt == vmhost.BreakpointNone || context.instance == nil {
+		return
+	}
+
+	context.instance.SetBreakpointValue(uint64(context.executionInterrupt))
+	context.instance.SetGasLimit(0)
+}
+
+// SetExecutionInterrupt requests that the current execution stops with the given
+// breakpoint value, including any instance started afterwards. It is safe to call
+// from another goroutine. Passing BreakpointNone withdraws the request.
+func (context *runtimeContext) SetExecutionInterrupt(value vmhost.BreakpointValue) {
+	context.mutInstance.Lock()
+	defer context.mutInstance.Unlock()
+
+	context.executionInterrupt = value
+	context.applyExecutionInterrupt()
+	logRuntime.Trace("execution interrupt set", "breakpoint", value)
+}
+
+// GetExecutionInterrupt returns the breakpoint value of the pending execution interrupt, if any.
+func (context *runtimeContext) GetExecutionInterrupt() vmhost.BreakpointValue {
+	context.mutInstance.Lock()
+	defer context.mutInstance.Unlock()
+
+	return context.executionInterrupt
+}
+
 // pushInstance appends the current wasmer instance to the instance stack.
 func (context *runtimeContext) pushInstance() {
 	context.instanceStack = append(context.instanceStack, context.instance)
@@ -410,7 +466,7 @@ func (context *runtimeContext) popInstance() {
 	}
 
 	context.CleanWasmerInstance()
-	context.instance = prevInstance
+	context.setInstance(prevInstance)
 }
 
 // RunningInstancesCount returns the length of the instance stack.
@@ -615,41 +671,10 @@ func (context *runtimeContext) checkBackwardCompatibility() error {
 		return nil
 	}
 
-	if context.instance.IsFunctionImported("transferESDTExecute") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("transferESDTNFTExecute") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("transferValueExecute") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTBalance") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTTokenData") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTTokenType") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTTokenNonce") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getCurrentESDTNFTNonce") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTNFTNameLength") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTNFTAttributeLength") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("getESDTNFTURILength") {
-		return vmhost.ErrContractInvalid
-	}
-	if context.instance.IsFunctionImported("bigIntGetESDTExternalBalance") {
-		return vmhost.ErrContractInvalid
+	for _, functionName := range vmhost.ESDTFunctionsImports {
+		if context.instance.IsFunctionImported(functionName) {
+			return vmhost.ErrContractInvalid
+		}
 	}
 
 	return nil
@@ -717,8 +742,7 @@ func (context *runtimeContext) CleanWasmerInstance() {
 		return
 	}
 
-	context.instance.Clean()
-	context.instance = nil
+	context.detachInstance().Clean()
 
 	logRuntime.Trace("instance cleaned")
 }
@@ -685,3 +685,56 @@ func TestRuntimeContext_PopInstanceIfStackIsEmptyShouldNotPanic(t *testing.T) {
 
 	require.Equal(t, 0, len(runtimeContext.stateStack))
 }
+
+// interruptDuringCleanInstance simulates the watchdog interrupting the execution while the instance is being cleaned
+type interruptDuringCleanInstance struct {
+	*contextmock.InstanceMock
+	runtimeContext *runtimeContext
+	cleaned        bool
+	usedAfterClean bool
+}
+
+func (instance *interruptDuringCleanInstance) Clean() {
+	instance.cleaned = true
+	instance.runtimeContext.SetExecutionInterrupt(vmhost.BreakpointExecutionTimeout)
+}
+
+func (instance *interruptDuringCleanInstance) SetBreakpointValue(value uint64) {
+	instance.usedAfterClean = instance.usedAfterClean || instance.cleaned
+	instance.InstanceMock.SetBreakpointValue(value)
+}
+
+func (instance *interruptDuringCleanInstance) SetGasLimit(gasLimit uint64) {
+	instance.usedAfterClean = instance.usedAfterClean || instance.cleaned
+	instance.InstanceMock.SetGasLimit(gasLimit)
+}
+
+func TestRuntimeContext_CleanInstanceDetachesBeforeCleaning(t *testing.T) {
+	host := InitializeVMAndWasmer()
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType, false, builtInFunctions.NewBuiltInFunctionContainer())
+
+	instance := &interruptDuringCleanInstance{
+		InstanceMock:   contextmock.NewInstanceMock(nil),
+		runtimeContext: runtimeContext,
+	}
+	runtimeContext.setInstance(instance)
+	runtimeContext.CleanWasmerInstance()
+	require.True(t, instance.cleaned)
+	require.False(t, instance.usedAfterClean)
+	require.Nil(t, runtimeContext.instance)
+
+	runtimeContext.SetExecutionInterrupt(vmhost.BreakpointNone)
+	instance = &interruptDuringCleanInstance{
+		InstanceMock:   contextmock.NewInstanceMock(nil),
+		runtimeContext: runtimeContext,
+	}
+	runtimeContext.setInstance(instance)
+	runtimeContext.warmInstance = instance
+	runtimeContext.ResetWarmInstance()
+	require.True(t, instance.cleaned)
+	require.False(t, instance.usedAfterClean)
+	require.Nil(t, runtimeContext.instance)
+	require.Nil(t, runtimeContext.warmInstance)
+}
@@ -152,3 +152,9 @@ var ErrTransferValueOnESDTCall = errors.New("transfer value on esdt call")
 
 // ErrNilEnableEpochsHandler signals that enable epochs handler is nil
 var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrNilVMHost signals that a nil VMHost has been provided
+var ErrNilVMHost = errors.New("nil VMHost")
+
+// ErrExecutionTimeout signals that the execution was stopped because its wall-clock deadline has been reached
+var ErrExecutionTimeout = errors.New("execution deadline exceeded")
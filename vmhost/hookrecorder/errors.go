@@ -0,0 +1,23 @@
+package hookrecorder
+
+import (
+	"errors"
+)
+
+// ErrNilBlockchainHook signals that a nil blockchain hook has been provided
+var ErrNilBlockchainHook = errors.New("nil blockchain hook")
+
+// ErrNilWriter signals that a nil writer has been provided
+var ErrNilWriter = errors.New("nil writer")
+
+// ErrNilReader signals that a nil reader has been provided
+var ErrNilReader = errors.New("nil reader")
+
+// ErrReplayDiverged signals that the VM asked the replay hook something else than what was recorded
+var ErrReplayDiverged = errors.New("replay diverged from recording")
+
+// ErrReplayExhausted signals that the VM made more hook calls than were recorded
+var ErrReplayExhausted = errors.New("replay exhausted")
+
+// ErrReplayIncomplete signals that not all the recorded hook calls were replayed
+var ErrReplayIncomplete = errors.New("replay incomplete")
@@ -0,0 +1,292 @@
+package hookrecorder
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// HookCall is a single recorded call to the blockchain hook, as one line of a recording file
+type HookCall struct {
+	Index     int             `json:"index"`
+	Method    string          `json:"method"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Results   json.RawMessage `json:"results,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+func (call *HookCall) getError() error {
+	if len(call.Error) == 0 {
+		return nil
+	}
+	return errors.New(call.Error)
+}
+
+func errorToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+type newAddressArguments struct {
+	CreatorAddress []byte
+	CreatorNonce   uint64
+	VMType         []byte
+}
+
+type storageArguments struct {
+	Address []byte
+	Key     []byte
+}
+
+type storageResults struct {
+	Value     []byte
+	TrieDepth uint32
+}
+
+type nonceArguments struct {
+	Nonce uint64
+}
+
+type addressArguments struct {
+	Address []byte
+}
+
+type addressPairArguments struct {
+	Sender   []byte
+	Receiver []byte
+}
+
+type esdtTokenArguments struct {
+	Address []byte
+	TokenID []byte
+	Nonce   uint64
+}
+
+type tokenArguments struct {
+	TokenID []byte
+}
+
+type codeHashArguments struct {
+	CodeHash []byte
+}
+
+type compiledCodeArguments struct {
+	CodeHash []byte
+	Code     []byte
+}
+
+type compiledCodeResults struct {
+	Found bool
+	Code  []byte
+}
+
+type snapshotArguments struct {
+	Snapshot int
+}
+
+// stateEntry replaces the string keys of GetAllState, which do not survive JSON encoding when binary
+type stateEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+func newStateEntries(state map[string][]byte) []stateEntry {
+	entries := make([]stateEntry, 0, len(state))
+	for key, value := range state {
+		entries = append(entries, stateEntry{Key: []byte(key), Value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return string(entries[i].Key) < string(entries[j].Key)
+	})
+	return entries
+}
+
+func stateEntriesToMap(entries []stateEntry) map[string][]byte {
+	state := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		state[string(entry.Key)] = entry.Value
+	}
+	return state
+}
+
+func newAccount(account vmcommon.UserAccountHandler) *Account {
+	if account == nil || account.IsInterfaceNil() {
+		return nil
+	}
+
+	return &Account{
+		Nonce:           account.GetNonce(),
+		Balance:         account.GetBalance(),
+		CodeHash:        account.GetCodeHash(),
+		RootHash:        account.GetRootHash(),
+		Address:         account.AddressBytes(),
+		DeveloperReward: account.GetDeveloperReward(),
+		OwnerAddress:    account.GetOwnerAddress(),
+		UserName:        account.GetUserName(),
+		CodeMetadata:    account.GetCodeMetadata(),
+	}
+}
+
+// serializableVMOutput replaces the maps of vmcommon.VMOutput, whose binary keys do not survive JSON encoding
+type serializableVMOutput struct {
+	ReturnData      [][]byte
+	ReturnCode      vmcommon.ReturnCode
+	ReturnMessage   string
+	GasRemaining    uint64
+	GasRefund       *big.Int
+	OutputAccounts  []*serializableOutputAccount
+	DeletedAccounts [][]byte
+	TouchedAccounts [][]byte
+	Logs            []*vmcommon.LogEntry
+}
+
+type serializableOutputAccount struct {
+	Address             []byte
+	Nonce               uint64
+	Balance             *big.Int
+	BalanceDelta        *big.Int
+	StorageUpdates      []*vmcommon.StorageUpdate
+	Code                []byte
+	CodeMetadata        []byte
+	CodeDeployerAddress []byte
+	OutputTransfers     []serializableOutputTransfer
+	GasUsed             uint64
+}
+
+type serializableOutputTransfer struct {
+	Index         uint32
+	Value         *big.Int
+	GasLimit      uint64
+	GasLocked     uint64
+	AsyncData     []byte
+	Data          []byte
+	CallType      vm.CallType
+	SenderAddress []byte
+}
+
+func newSerializableVMOutput(vmOutput *vmcommon.VMOutput) *serializableVMOutput {
+	if vmOutput == nil {
+		return nil
+	}
+
+	output := &serializableVMOutput{
+		ReturnData:      vmOutput.ReturnData,
+		ReturnCode:      vmOutput.ReturnCode,
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       vmOutput.GasRefund,
+		OutputAccounts:  make([]*serializableOutputAccount, 0, len(vmOutput.OutputAccounts)),
+		DeletedAccounts: vmOutput.DeletedAccounts,
+		TouchedAccounts: vmOutput.TouchedAccounts,
+		Logs:            vmOutput.Logs,
+	}
+
+	for _, account := range vmOutput.OutputAccounts {
+		output.OutputAccounts = append(output.OutputAccounts, newSerializableOutputAccount(account))
+	}
+	sort.Slice(output.OutputAccounts, func(i, j int) bool {
+		return string(output.OutputAccounts[i].Address) < string(output.OutputAccounts[j].Address)
+	})
+
+	return output
+}
+
+func (output *serializableVMOutput) toVMOutput() *vmcommon.VMOutput {
+	if output == nil {
+		return nil
+	}
+
+	accounts := make(map[string]*vmcommon.OutputAccount, len(output.OutputAccounts))
+	for _, account := range output.OutputAccounts {
+		accounts[string(account.Address)] = account.toOutputAccount()
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnData:      output.ReturnData,
+		ReturnCode:      output.ReturnCode,
+		ReturnMessage:   output.ReturnMessage,
+		GasRemaining:    output.GasRemaining,
+		GasRefund:       output.GasRefund,
+		OutputAccounts:  accounts,
+		DeletedAccounts: output.DeletedAccounts,
+		TouchedAccounts: output.TouchedAccounts,
+		Logs:            output.Logs,
+	}
+}
+
+func newSerializableOutputAccount(account *vmcommon.OutputAccount) *serializableOutputAccount {
+	serializable := &serializableOutputAccount{
+		Address:             account.Address,
+		Nonce:               account.Nonce,
+		Balance:             account.Balance,
+		BalanceDelta:        account.BalanceDelta,
+		StorageUpdates:      make([]*vmcommon.StorageUpdate, 0, len(account.StorageUpdates)),
+		Code:                account.Code,
+		CodeMetadata:        account.CodeMetadata,
+		CodeDeployerAddress: account.CodeDeployerAddress,
+		OutputTransfers:     make([]serializableOutputTransfer, 0, len(account.OutputTransfers)),
+		GasUsed:             account.GasUsed,
+	}
+
+	for _, update := range account.StorageUpdates {
+		serializable.StorageUpdates = append(serializable.StorageUpdates, update)
+	}
+	sort.Slice(serializable.StorageUpdates, func(i, j int) bool {
+		return string(serializable.StorageUpdates[i].Offset) < string(serializable.StorageUpdates[j].Offset)
+	})
+
+	for _, transfer := range account.OutputTransfers {
+		serializable.OutputTransfers = append(serializable.OutputTransfers, serializableOutputTransfer{
+			Index:         transfer.Index,
+			Value:         transfer.Value,
+			GasLimit:      transfer.GasLimit,
+			GasLocked:     transfer.GasLocked,
+			AsyncData:     transfer.AsyncData,
+			Data:          transfer.Data,
+			CallType:      transfer.CallType,
+			SenderAddress: transfer.SenderAddress,
+		})
+	}
+
+	return serializable
+}
+
+func (account *serializableOutputAccount) toOutputAccount() *vmcommon.OutputAccount {
+	outputAccount := &vmcommon.OutputAccount{
+		Address:             account.Address,
+		Nonce:               account.Nonce,
+		Balance:             account.Balance,
+		BalanceDelta:        account.BalanceDelta,
+		StorageUpdates:      make(map[string]*vmcommon.StorageUpdate, len(account.StorageUpdates)),
+		Code:                account.Code,
+		CodeMetadata:        account.CodeMetadata,
+		CodeDeployerAddress: account.CodeDeployerAddress,
+		OutputTransfers:     make([]vmcommon.OutputTransfer, 0, len(account.OutputTransfers)),
+		GasUsed:             account.GasUsed,
+	}
+
+	for _, update := range account.StorageUpdates {
+		outputAccount.StorageUpdates[string(update.Offset)] = update
+	}
+
+	for _, transfer := range account.OutputTransfers {
+		outputAccount.OutputTransfers = append(outputAccount.OutputTransfers, vmcommon.OutputTransfer{
+			Index:         transfer.Index,
+			Value:         transfer.Value,
+			GasLimit:      transfer.GasLimit,
+			GasLocked:     transfer.GasLocked,
+			AsyncData:     transfer.AsyncData,
+			Data:          transfer.Data,
+			CallType:      transfer.CallType,
+			SenderAddress: transfer.SenderAddress,
+		})
+	}
+
+	return outputAccount
+}
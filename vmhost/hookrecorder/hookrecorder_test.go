@@ -0,0 +1,186 @@
+package hookrecorder
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+var errStorage = errors.New("storage error")
+
+// hookStub implements only the hook methods used by the tests
+type hookStub struct {
+	vmcommon.BlockchainHook
+}
+
+func (stub *hookStub) GetStorageData(_ []byte, index []byte) ([]byte, uint32, error) {
+	if bytes.Equal(index, []byte("missing")) {
+		return nil, 0, errStorage
+	}
+	return append([]byte("value-"), index...), 3, nil
+}
+
+func (stub *hookStub) CurrentNonce() uint64 {
+	return 42
+}
+
+func (stub *hookStub) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	return &Account{Address: address, Nonce: 7, Balance: big.NewInt(1000)}, nil
+}
+
+func (stub *hookStub) GetCode(_ vmcommon.UserAccountHandler) []byte {
+	return []byte("code")
+}
+
+func (stub *hookStub) GetAllState(_ []byte) (map[string][]byte, error) {
+	return map[string][]byte{"\x00\xff": []byte("binary"), "key": []byte("value")}, nil
+}
+
+func (stub *hookStub) GetESDTToken(_ []byte, _ []byte, _ uint64) (*esdt.ESDigitalToken, error) {
+	return &esdt.ESDigitalToken{Value: big.NewInt(5)}, nil
+}
+
+func (stub *hookStub) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	return &vmcommon.VMOutput{
+		ReturnCode: vmcommon.Ok,
+		ReturnData: [][]byte{[]byte(input.Function)},
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"\x01\x02": {
+				Address:        []byte{1, 2},
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{"\xfe": {Offset: []byte{0xfe}, Data: []byte("x")}},
+			},
+		},
+	}, nil
+}
+
+func (stub *hookStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func runScript(t *testing.T, hook vmcommon.BlockchainHook) {
+	value, depth, err := hook.GetStorageData([]byte("sc"), []byte("k"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("value-k"), value)
+	require.Equal(t, uint32(3), depth)
+
+	_, _, err = hook.GetStorageData([]byte("sc"), []byte("missing"))
+	require.Equal(t, errStorage.Error(), err.Error())
+
+	require.Equal(t, uint64(42), hook.CurrentNonce())
+
+	account, err := hook.GetUserAccount([]byte("alice"))
+	require.Nil(t, err)
+	require.Equal(t, uint64(7), account.GetNonce())
+	require.Equal(t, big.NewInt(1000), account.GetBalance())
+	require.Equal(t, []byte("code"), hook.GetCode(account))
+
+	state, err := hook.GetAllState([]byte("sc"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("binary"), state["\x00\xff"])
+	require.Len(t, state, 2)
+
+	token, err := hook.GetESDTToken([]byte("alice"), []byte("TOK-123456"), 0)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(5), token.Value)
+
+	vmOutput, err := hook.ProcessBuiltInFunction(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{CallValue: big.NewInt(0), Arguments: [][]byte{{0}}},
+		Function: "ESDTTransfer",
+	})
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("ESDTTransfer")}, vmOutput.ReturnData)
+	require.Equal(t, []byte("x"), vmOutput.OutputAccounts["\x01\x02"].StorageUpdates["\xfe"].Data)
+}
+
+func TestHookRecorder_RecordAndReplay(t *testing.T) {
+	recording := &bytes.Buffer{}
+	recorder, err := NewRecordingBlockchainHook(&hookStub{}, recording)
+	require.Nil(t, err)
+
+	runScript(t, recorder)
+	require.Nil(t, recorder.Err())
+	require.Equal(t, 8, recorder.NumCalls())
+
+	replay, err := NewReplayBlockchainHook(bytes.NewReader(recording.Bytes()))
+	require.Nil(t, err)
+	require.Equal(t, 8, replay.Remaining())
+
+	runScript(t, replay)
+	require.Nil(t, replay.CheckComplete())
+}
+
+func TestHookRecorder_ReplayDiverges(t *testing.T) {
+	recording := &bytes.Buffer{}
+	recorder, _ := NewRecordingBlockchainHook(&hookStub{}, recording)
+	_, _, _ = recorder.GetStorageData([]byte("sc"), []byte("k"))
+	_ = recorder.CurrentNonce()
+
+	replay, _ := NewReplayBlockchainHook(bytes.NewReader(recording.Bytes()))
+	value, trieDepth, err := replay.GetStorageData([]byte("sc"), []byte("other"))
+	require.True(t, errors.Is(err, ErrReplayDiverged))
+	require.Nil(t, value)
+	require.Equal(t, uint32(0), trieDepth)
+	require.True(t, errors.Is(replay.Err(), ErrReplayDiverged))
+	require.True(t, errors.Is(replay.CheckComplete(), ErrReplayDiverged))
+
+	// the first divergence is kept, and the following calls are not replayed
+	firstErr := replay.Err()
+	require.Equal(t, uint64(0), replay.CurrentNonce())
+	require.Equal(t, firstErr, replay.Err())
+	require.Equal(t, 2, replay.Remaining())
+
+	replay, _ = NewReplayBlockchainHook(bytes.NewReader(recording.Bytes()))
+	require.Equal(t, uint64(0), replay.CurrentNonce())
+	require.True(t, errors.Is(replay.Err(), ErrReplayDiverged))
+}
+
+func TestHookRecorder_ReplayExhaustedAndIncomplete(t *testing.T) {
+	recording := &bytes.Buffer{}
+	recorder, _ := NewRecordingBlockchainHook(&hookStub{}, recording)
+	_ = recorder.CurrentNonce()
+
+	replay, _ := NewReplayBlockchainHook(bytes.NewReader(recording.Bytes()))
+	require.True(t, errors.Is(replay.CheckComplete(), ErrReplayIncomplete))
+	require.Equal(t, uint64(42), replay.CurrentNonce())
+	require.Nil(t, replay.CheckComplete())
+
+	require.Equal(t, uint64(0), replay.CurrentNonce())
+	require.True(t, errors.Is(replay.Err(), ErrReplayExhausted))
+	require.True(t, errors.Is(replay.CheckComplete(), ErrReplayExhausted))
+}
+
+func TestHookRecorder_ContractCallInput(t *testing.T) {
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("alice"),
+			CallValue:   big.NewInt(10),
+			GasProvided: 1000,
+			Arguments:   [][]byte{{1}, {2, 3}},
+		},
+		RecipientAddr: []byte("sc"),
+		Function:      "increment",
+	}
+
+	buffer := &bytes.Buffer{}
+	require.Nil(t, WriteContractCallInput(buffer, input))
+
+	readInput, err := ReadContractCallInput(buffer)
+	require.Nil(t, err)
+	require.Equal(t, input, readInput)
+}
+
+func TestHookRecorder_NilArguments(t *testing.T) {
+	_, err := NewRecordingBlockchainHook(nil, &bytes.Buffer{})
+	require.Equal(t, ErrNilBlockchainHook, err)
+
+	_, err = NewRecordingBlockchainHook(&hookStub{}, nil)
+	require.Equal(t, ErrNilWriter, err)
+
+	_, err = NewReplayBlockchainHook(nil)
+	require.Equal(t, ErrNilReader, err)
+}
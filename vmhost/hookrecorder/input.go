@@ -0,0 +1,34 @@
+package hookrecorder
+
+import (
+	"encoding/json"
+	"io"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// WriteContractCallInput serializes the input of a recorded execution, to be re-run against a ReplayBlockchainHook
+func WriteContractCallInput(writer io.Writer, input *vmcommon.ContractCallInput) error {
+	if writer == nil {
+		return ErrNilWriter
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(input)
+}
+
+// ReadContractCallInput deserializes an input written by WriteContractCallInput
+func ReadContractCallInput(reader io.Reader) (*vmcommon.ContractCallInput, error) {
+	if reader == nil {
+		return nil, ErrNilReader
+	}
+
+	input := &vmcommon.ContractCallInput{}
+	err := json.NewDecoder(reader).Decode(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return input, nil
+}
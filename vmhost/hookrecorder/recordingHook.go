@@ -0,0 +1,304 @@
+package hookrecorder
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var log = logger.GetOrCreate("vm/hookrecorder")
+
+// RecordingBlockchainHook decorates a vmcommon.BlockchainHook, writing every call it
+// forwards, with its arguments and results, as one JSON line to the given writer
+type RecordingBlockchainHook struct {
+	hook    vmcommon.BlockchainHook
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	index   int
+	err     error
+}
+
+// NewRecordingBlockchainHook creates a new RecordingBlockchainHook
+func NewRecordingBlockchainHook(hook vmcommon.BlockchainHook, writer io.Writer) (*RecordingBlockchainHook, error) {
+	if check.IfNil(hook) {
+		return nil, ErrNilBlockchainHook
+	}
+	if writer == nil {
+		return nil, ErrNilWriter
+	}
+
+	return &RecordingBlockchainHook{
+		hook:    hook,
+		encoder: json.NewEncoder(writer),
+	}, nil
+}
+
+// Err returns the first error encountered while writing the recording, if any
+func (recorder *RecordingBlockchainHook) Err() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return recorder.err
+}
+
+// NumCalls returns the number of calls recorded so far
+func (recorder *RecordingBlockchainHook) NumCalls() int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return recorder.index
+}
+
+func (recorder *RecordingBlockchainHook) record(method string, arguments interface{}, results interface{}, err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	call := &HookCall{
+		Index:  recorder.index,
+		Method: method,
+		Error:  errorToString(err),
+	}
+	recorder.index++
+
+	var marshalErr error
+	if arguments != nil {
+		call.Arguments, marshalErr = json.Marshal(arguments)
+	}
+	if marshalErr == nil && results != nil {
+		call.Results, marshalErr = json.Marshal(results)
+	}
+	if marshalErr == nil {
+		marshalErr = recorder.encoder.Encode(call)
+	}
+	if marshalErr != nil && recorder.err == nil {
+		recorder.err = marshalErr
+		log.Error("hook call not recorded", "method", method, "index", call.Index, "error", marshalErr)
+	}
+}
+
+// NewAddress forwards and records the call
+func (recorder *RecordingBlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	address, err := recorder.hook.NewAddress(creatorAddress, creatorNonce, vmType)
+	recorder.record("NewAddress", &newAddressArguments{creatorAddress, creatorNonce, vmType}, address, err)
+	return address, err
+}
+
+// GetStorageData forwards and records the call
+func (recorder *RecordingBlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	value, trieDepth, err := recorder.hook.GetStorageData(accountAddress, index)
+	recorder.record("GetStorageData", &storageArguments{accountAddress, index}, &storageResults{value, trieDepth}, err)
+	return value, trieDepth, err
+}
+
+// GetBlockhash forwards and records the call
+func (recorder *RecordingBlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	hash, err := recorder.hook.GetBlockhash(nonce)
+	recorder.record("GetBlockhash", &nonceArguments{nonce}, hash, err)
+	return hash, err
+}
+
+// LastNonce forwards and records the call
+func (recorder *RecordingBlockchainHook) LastNonce() uint64 {
+	result := recorder.hook.LastNonce()
+	recorder.record("LastNonce", nil, result, nil)
+	return result
+}
+
+// LastRound forwards and records the call
+func (recorder *RecordingBlockchainHook) LastRound() uint64 {
+	result := recorder.hook.LastRound()
+	recorder.record("LastRound", nil, result, nil)
+	return result
+}
+
+// LastTimeStamp forwards and records the call
+func (recorder *RecordingBlockchainHook) LastTimeStamp() uint64 {
+	result := recorder.hook.LastTimeStamp()
+	recorder.record("LastTimeStamp", nil, result, nil)
+	return result
+}
+
+// LastRandomSeed forwards and records the call
+func (recorder *RecordingBlockchainHook) LastRandomSeed() []byte {
+	result := recorder.hook.LastRandomSeed()
+	recorder.record("LastRandomSeed", nil, result, nil)
+	return result
+}
+
+// LastEpoch forwards and records the call
+func (recorder *RecordingBlockchainHook) LastEpoch() uint32 {
+	result := recorder.hook.LastEpoch()
+	recorder.record("LastEpoch", nil, result, nil)
+	return result
+}
+
+// GetStateRootHash forwards and records the call
+func (recorder *RecordingBlockchainHook) GetStateRootHash() []byte {
+	result := recorder.hook.GetStateRootHash()
+	recorder.record("GetStateRootHash", nil, result, nil)
+	return result
+}
+
+// CurrentNonce forwards and records the call
+func (recorder *RecordingBlockchainHook) CurrentNonce() uint64 {
+	result := recorder.hook.CurrentNonce()
+	recorder.record("CurrentNonce", nil, result, nil)
+	return result
+}
+
+// CurrentRound forwards and records the call
+func (recorder *RecordingBlockchainHook) CurrentRound() uint64 {
+	result := recorder.hook.CurrentRound()
+	recorder.record("CurrentRound", nil, result, nil)
+	return result
+}
+
+// CurrentTimeStamp forwards and records the call
+func (recorder *RecordingBlockchainHook) CurrentTimeStamp() uint64 {
+	result := recorder.hook.CurrentTimeStamp()
+	recorder.record("CurrentTimeStamp", nil, result, nil)
+	return result
+}
+
+// CurrentRandomSeed forwards and records the call
+func (recorder *RecordingBlockchainHook) CurrentRandomSeed() []byte {
+	result := recorder.hook.CurrentRandomSeed()
+	recorder.record("CurrentRandomSeed", nil, result, nil)
+	return result
+}
+
+// CurrentEpoch forwards and records the call
+func (recorder *RecordingBlockchainHook) CurrentEpoch() uint32 {
+	result := recorder.hook.CurrentEpoch()
+	recorder.record("CurrentEpoch", nil, result, nil)
+	return result
+}
+
+// ProcessBuiltInFunction forwards and records the call
+func (recorder *RecordingBlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	arguments, _ := json.Marshal(input)
+	vmOutput, err := recorder.hook.ProcessBuiltInFunction(input)
+	recorder.record("ProcessBuiltInFunction", json.RawMessage(arguments), newSerializableVMOutput(vmOutput), err)
+	return vmOutput, err
+}
+
+// GetBuiltinFunctionNames forwards and records the call
+func (recorder *RecordingBlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	result := recorder.hook.GetBuiltinFunctionNames()
+	recorder.record("GetBuiltinFunctionNames", nil, result, nil)
+	return result
+}
+
+// GetAllState forwards and records the call
+func (recorder *RecordingBlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	state, err := recorder.hook.GetAllState(address)
+	recorder.record("GetAllState", &addressArguments{address}, newStateEntries(state), err)
+	return state, err
+}
+
+// GetUserAccount forwards and records the call
+func (recorder *RecordingBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := recorder.hook.GetUserAccount(address)
+	recorder.record("GetUserAccount", &addressArguments{address}, newAccount(account), err)
+	return account, err
+}
+
+// GetCode forwards and records the call
+func (recorder *RecordingBlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	code := recorder.hook.GetCode(account)
+	recorder.record("GetCode", newAccount(account), code, nil)
+	return code
+}
+
+// GetShardOfAddress forwards and records the call
+func (recorder *RecordingBlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	result := recorder.hook.GetShardOfAddress(address)
+	recorder.record("GetShardOfAddress", &addressArguments{address}, result, nil)
+	return result
+}
+
+// IsSmartContract forwards and records the call
+func (recorder *RecordingBlockchainHook) IsSmartContract(address []byte) bool {
+	result := recorder.hook.IsSmartContract(address)
+	recorder.record("IsSmartContract", &addressArguments{address}, result, nil)
+	return result
+}
+
+// IsPayable forwards and records the call
+func (recorder *RecordingBlockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	result, err := recorder.hook.IsPayable(sndAddress, recvAddress)
+	recorder.record("IsPayable", &addressPairArguments{sndAddress, recvAddress}, result, err)
+	return result, err
+}
+
+// SaveCompiledCode forwards and records the call
+func (recorder *RecordingBlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	recorder.hook.SaveCompiledCode(codeHash, code)
+	recorder.record("SaveCompiledCode", &compiledCodeArguments{codeHash, code}, nil, nil)
+}
+
+// GetCompiledCode forwards and records the call
+func (recorder *RecordingBlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	found, code := recorder.hook.GetCompiledCode(codeHash)
+	recorder.record("GetCompiledCode", &codeHashArguments{codeHash}, &compiledCodeResults{found, code}, nil)
+	return found, code
+}
+
+// ClearCompiledCodes forwards and records the call
+func (recorder *RecordingBlockchainHook) ClearCompiledCodes() {
+	recorder.hook.ClearCompiledCodes()
+	recorder.record("ClearCompiledCodes", nil, nil, nil)
+}
+
+// GetESDTToken forwards and records the call
+func (recorder *RecordingBlockchainHook) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	token, err := recorder.hook.GetESDTToken(address, tokenID, nonce)
+	recorder.record("GetESDTToken", &esdtTokenArguments{address, tokenID, nonce}, token, err)
+	return token, err
+}
+
+// IsPaused forwards and records the call
+func (recorder *RecordingBlockchainHook) IsPaused(tokenID []byte) bool {
+	result := recorder.hook.IsPaused(tokenID)
+	recorder.record("IsPaused", &tokenArguments{tokenID}, result, nil)
+	return result
+}
+
+// IsLimitedTransfer forwards and records the call
+func (recorder *RecordingBlockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	result := recorder.hook.IsLimitedTransfer(tokenID)
+	recorder.record("IsLimitedTransfer", &tokenArguments{tokenID}, result, nil)
+	return result
+}
+
+// GetSnapshot forwards and records the call
+func (recorder *RecordingBlockchainHook) GetSnapshot() int {
+	result := recorder.hook.GetSnapshot()
+	recorder.record("GetSnapshot", nil, result, nil)
+	return result
+}
+
+// RevertToSnapshot forwards and records the call
+func (recorder *RecordingBlockchainHook) RevertToSnapshot(snapshot int) error {
+	err := recorder.hook.RevertToSnapshot(snapshot)
+	recorder.record("RevertToSnapshot", &snapshotArguments{snapshot}, nil, err)
+	return err
+}
+
+// ExecuteSmartContractCallOnOtherVM forwards and records the call
+func (recorder *RecordingBlockchainHook) ExecuteSmartContractCallOnOtherVM(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	arguments, _ := json.Marshal(input)
+	vmOutput, err := recorder.hook.ExecuteSmartContractCallOnOtherVM(input)
+	recorder.record("ExecuteSmartContractCallOnOtherVM", json.RawMessage(arguments), newSerializableVMOutput(vmOutput), err)
+	return vmOutput, err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (recorder *RecordingBlockchainHook) IsInterfaceNil() bool {
+	return recorder == nil
+}
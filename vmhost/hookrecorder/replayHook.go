@@ -0,0 +1,349 @@
+package hookrecorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ReplayBlockchainHook is a vmcommon.BlockchainHook which answers with the results of a
+// recording made by RecordingBlockchainHook. The calls must arrive in the recorded order
+// and with the recorded arguments. The hook is called from Wasmer callbacks, where it must
+// not panic, so the first divergence is kept and reported by Err and CheckComplete, while
+// that call and all the following ones yield zero values and the error, where they can.
+type ReplayBlockchainHook struct {
+	mutex    sync.Mutex
+	calls    []*HookCall
+	position int
+	err      error
+}
+
+// NewReplayBlockchainHook creates a new ReplayBlockchainHook, reading a recording from the given reader
+func NewReplayBlockchainHook(reader io.Reader) (*ReplayBlockchainHook, error) {
+	if reader == nil {
+		return nil, ErrNilReader
+	}
+
+	calls := make([]*HookCall, 0)
+	decoder := json.NewDecoder(reader)
+	for {
+		call := &HookCall{}
+		err := decoder.Decode(call)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading hook call #%d: %w", len(calls), err)
+		}
+
+		calls = append(calls, call)
+	}
+
+	return &ReplayBlockchainHook{
+		calls: calls,
+	}, nil
+}
+
+// Err returns the error which stopped the replay, if any
+func (replay *ReplayBlockchainHook) Err() error {
+	replay.mutex.Lock()
+	defer replay.mutex.Unlock()
+
+	return replay.err
+}
+
+// Remaining returns the number of recorded calls not replayed yet
+func (replay *ReplayBlockchainHook) Remaining() int {
+	replay.mutex.Lock()
+	defer replay.mutex.Unlock()
+
+	return len(replay.calls) - replay.position
+}
+
+// CheckComplete verifies that the replay did not diverge and that all the recorded calls were replayed
+func (replay *ReplayBlockchainHook) CheckComplete() error {
+	replay.mutex.Lock()
+	defer replay.mutex.Unlock()
+
+	if replay.err != nil {
+		return replay.err
+	}
+	if replay.position < len(replay.calls) {
+		next := replay.calls[replay.position]
+		return fmt.Errorf("%w: %d calls left, next is #%d %s(%s)",
+			ErrReplayIncomplete, len(replay.calls)-replay.position, next.Index, next.Method, next.Arguments)
+	}
+
+	return nil
+}
+
+func (replay *ReplayBlockchainHook) fail(err error) error {
+	replay.err = err
+	return err
+}
+
+// next consumes the next recorded call, checking it against the actual one, and decodes its results;
+// once the replay failed, it leaves the results as they are and returns the error which stopped it
+func (replay *ReplayBlockchainHook) next(method string, arguments interface{}, results interface{}) error {
+	replay.mutex.Lock()
+	defer replay.mutex.Unlock()
+
+	if replay.err != nil {
+		return replay.err
+	}
+
+	var encodedArguments []byte
+	if arguments != nil {
+		var err error
+		encodedArguments, err = json.Marshal(arguments)
+		if err != nil {
+			return replay.fail(err)
+		}
+	}
+
+	if replay.position >= len(replay.calls) {
+		return replay.fail(fmt.Errorf("%w: unexpected call %s(%s) after %d calls",
+			ErrReplayExhausted, method, encodedArguments, len(replay.calls)))
+	}
+
+	call := replay.calls[replay.position]
+	if call.Method != method || !bytes.Equal(call.Arguments, encodedArguments) {
+		return replay.fail(fmt.Errorf("%w: call #%d: expected %s(%s), got %s(%s)",
+			ErrReplayDiverged, call.Index, call.Method, call.Arguments, method, encodedArguments))
+	}
+	replay.position++
+
+	if results != nil && len(call.Results) > 0 {
+		err := json.Unmarshal(call.Results, results)
+		if err != nil {
+			return replay.fail(fmt.Errorf("decoding results of call #%d %s: %w", call.Index, method, err))
+		}
+	}
+
+	return call.getError()
+}
+
+// NewAddress replays the recorded call
+func (replay *ReplayBlockchainHook) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	var address []byte
+	err := replay.next("NewAddress", &newAddressArguments{creatorAddress, creatorNonce, vmType}, &address)
+	return address, err
+}
+
+// GetStorageData replays the recorded call
+func (replay *ReplayBlockchainHook) GetStorageData(accountAddress []byte, index []byte) ([]byte, uint32, error) {
+	results := &storageResults{}
+	err := replay.next("GetStorageData", &storageArguments{accountAddress, index}, results)
+	return results.Value, results.TrieDepth, err
+}
+
+// GetBlockhash replays the recorded call
+func (replay *ReplayBlockchainHook) GetBlockhash(nonce uint64) ([]byte, error) {
+	var hash []byte
+	err := replay.next("GetBlockhash", &nonceArguments{nonce}, &hash)
+	return hash, err
+}
+
+// LastNonce replays the recorded call
+func (replay *ReplayBlockchainHook) LastNonce() uint64 {
+	var result uint64
+	_ = replay.next("LastNonce", nil, &result)
+	return result
+}
+
+// LastRound replays the recorded call
+func (replay *ReplayBlockchainHook) LastRound() uint64 {
+	var result uint64
+	_ = replay.next("LastRound", nil, &result)
+	return result
+}
+
+// LastTimeStamp replays the recorded call
+func (replay *ReplayBlockchainHook) LastTimeStamp() uint64 {
+	var result uint64
+	_ = replay.next("LastTimeStamp", nil, &result)
+	return result
+}
+
+// LastRandomSeed replays the recorded call
+func (replay *ReplayBlockchainHook) LastRandomSeed() []byte {
+	var result []byte
+	_ = replay.next("LastRandomSeed", nil, &result)
+	return result
+}
+
+// LastEpoch replays the recorded call
+func (replay *ReplayBlockchainHook) LastEpoch() uint32 {
+	var result uint32
+	_ = replay.next("LastEpoch", nil, &result)
+	return result
+}
+
+// GetStateRootHash replays the recorded call
+func (replay *ReplayBlockchainHook) GetStateRootHash() []byte {
+	var result []byte
+	_ = replay.next("GetStateRootHash", nil, &result)
+	return result
+}
+
+// CurrentNonce replays the recorded call
+func (replay *ReplayBlockchainHook) CurrentNonce() uint64 {
+	var result uint64
+	_ = replay.next("CurrentNonce", nil, &result)
+	return result
+}
+
+// CurrentRound replays the recorded call
+func (replay *ReplayBlockchainHook) CurrentRound() uint64 {
+	var result uint64
+	_ = replay.next("CurrentRound", nil, &result)
+	return result
+}
+
+// CurrentTimeStamp replays the recorded call
+func (replay *ReplayBlockchainHook) CurrentTimeStamp() uint64 {
+	var result uint64
+	_ = replay.next("CurrentTimeStamp", nil, &result)
+	return result
+}
+
+// CurrentRandomSeed replays the recorded call
+func (replay *ReplayBlockchainHook) CurrentRandomSeed() []byte {
+	var result []byte
+	_ = replay.next("CurrentRandomSeed", nil, &result)
+	return result
+}
+
+// CurrentEpoch replays the recorded call
+func (replay *ReplayBlockchainHook) CurrentEpoch() uint32 {
+	var result uint32
+	_ = replay.next("CurrentEpoch", nil, &result)
+	return result
+}
+
+// ProcessBuiltInFunction replays the recorded call
+func (replay *ReplayBlockchainHook) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	var output *serializableVMOutput
+	err := replay.next("ProcessBuiltInFunction", input, &output)
+	return output.toVMOutput(), err
+}
+
+// GetBuiltinFunctionNames replays the recorded call
+func (replay *ReplayBlockchainHook) GetBuiltinFunctionNames() vmcommon.FunctionNames {
+	result := make(vmcommon.FunctionNames)
+	_ = replay.next("GetBuiltinFunctionNames", nil, &result)
+	return result
+}
+
+// GetAllState replays the recorded call
+func (replay *ReplayBlockchainHook) GetAllState(address []byte) (map[string][]byte, error) {
+	var entries []stateEntry
+	err := replay.next("GetAllState", &addressArguments{address}, &entries)
+	return stateEntriesToMap(entries), err
+}
+
+// GetUserAccount replays the recorded call
+func (replay *ReplayBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	var account *Account
+	err := replay.next("GetUserAccount", &addressArguments{address}, &account)
+	if account == nil {
+		return nil, err
+	}
+	return account, err
+}
+
+// GetCode replays the recorded call
+func (replay *ReplayBlockchainHook) GetCode(account vmcommon.UserAccountHandler) []byte {
+	var code []byte
+	_ = replay.next("GetCode", newAccount(account), &code)
+	return code
+}
+
+// GetShardOfAddress replays the recorded call
+func (replay *ReplayBlockchainHook) GetShardOfAddress(address []byte) uint32 {
+	var result uint32
+	_ = replay.next("GetShardOfAddress", &addressArguments{address}, &result)
+	return result
+}
+
+// IsSmartContract replays the recorded call
+func (replay *ReplayBlockchainHook) IsSmartContract(address []byte) bool {
+	var result bool
+	_ = replay.next("IsSmartContract", &addressArguments{address}, &result)
+	return result
+}
+
+// IsPayable replays the recorded call
+func (replay *ReplayBlockchainHook) IsPayable(sndAddress []byte, recvAddress []byte) (bool, error) {
+	var result bool
+	err := replay.next("IsPayable", &addressPairArguments{sndAddress, recvAddress}, &result)
+	return result, err
+}
+
+// SaveCompiledCode replays the recorded call
+func (replay *ReplayBlockchainHook) SaveCompiledCode(codeHash []byte, code []byte) {
+	_ = replay.next("SaveCompiledCode", &compiledCodeArguments{codeHash, code}, nil)
+}
+
+// GetCompiledCode replays the recorded call
+func (replay *ReplayBlockchainHook) GetCompiledCode(codeHash []byte) (bool, []byte) {
+	results := &compiledCodeResults{}
+	_ = replay.next("GetCompiledCode", &codeHashArguments{codeHash}, results)
+	return results.Found, results.Code
+}
+
+// ClearCompiledCodes replays the recorded call
+func (replay *ReplayBlockchainHook) ClearCompiledCodes() {
+	_ = replay.next("ClearCompiledCodes", nil, nil)
+}
+
+// GetESDTToken replays the recorded call
+func (replay *ReplayBlockchainHook) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	var token *esdt.ESDigitalToken
+	err := replay.next("GetESDTToken", &esdtTokenArguments{address, tokenID, nonce}, &token)
+	return token, err
+}
+
+// IsPaused replays the recorded call
+func (replay *ReplayBlockchainHook) IsPaused(tokenID []byte) bool {
+	var result bool
+	_ = replay.next("IsPaused", &tokenArguments{tokenID}, &result)
+	return result
+}
+
+// IsLimitedTransfer replays the recorded call
+func (replay *ReplayBlockchainHook) IsLimitedTransfer(tokenID []byte) bool {
+	var result bool
+	_ = replay.next("IsLimitedTransfer", &tokenArguments{tokenID}, &result)
+	return result
+}
+
+// GetSnapshot replays the recorded call
+func (replay *ReplayBlockchainHook) GetSnapshot() int {
+	var result int
+	_ = replay.next("GetSnapshot", nil, &result)
+	return result
+}
+
+// RevertToSnapshot replays the recorded call
+func (replay *ReplayBlockchainHook) RevertToSnapshot(snapshot int) error {
+	return replay.next("RevertToSnapshot", &snapshotArguments{snapshot}, nil)
+}
+
+// ExecuteSmartContractCallOnOtherVM replays the recorded call
+func (replay *ReplayBlockchainHook) ExecuteSmartContractCallOnOtherVM(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	var output *serializableVMOutput
+	err := replay.next("ExecuteSmartContractCallOnOtherVM", input, &output)
+	return output.toVMOutput(), err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (replay *ReplayBlockchainHook) IsInterfaceNil() bool {
+	return replay == nil
+}
@@ -11,6 +11,9 @@ func (host *vmHost) handleBreakpointIfAny(executionErr error) error {
 
 	runtime := host.Runtime()
 	breakpointValue := runtime.GetRuntimeBreakpointValue()
+	if interrupt := runtime.GetExecutionInterrupt(); interrupt != vmhost.BreakpointNone {
+		breakpointValue = interrupt
+	}
 	if breakpointValue != vmhost.BreakpointNone {
 		err := host.handleBreakpoint(breakpointValue)
 		runtime.AddError(err)
@@ -34,6 +37,9 @@ func (host *vmHost) handleBreakpoint(breakpointValue vmhost.BreakpointValue) err
 	if breakpointValue == vmhost.BreakpointOutOfGas {
 		return vmhost.ErrNotEnoughGas
 	}
+	if breakpointValue == vmhost.BreakpointExecutionTimeout {
+		return vmhost.ErrExecutionTimeout
+	}
 
 	return vmhost.ErrUnhandledRuntimeBreakpoint
 }
@@ -0,0 +1,70 @@
+package hostCore
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/contexts"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/wasmanalyzer"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/wasmer"
+)
+
+// NewContractAnalyzerConfig creates a static analysis configuration which
+// validates imports against the given EEI and reserved function names; gated
+// imports are rejected according to the enableEpochsHandler, if provided
+func NewContractAnalyzerConfig(
+	imports *wasmer.Imports,
+	builtInFuncContainer vmcommon.BuiltInFunctionContainer,
+	enableEpochsHandler vmhost.EnableEpochsHandler,
+) *wasmanalyzer.Config {
+	config := wasmanalyzer.NewDefaultConfig()
+	config.Imports = importSignatures(imports)
+	config.GatedImports = flagGatedImports()
+
+	reserved := contexts.NewReservedFunctions(imports.Names(), builtInFuncContainer)
+	config.ReservedNames = make(map[string]struct{})
+	for _, name := range reserved.GetReserved() {
+		config.ReservedNames[name] = struct{}{}
+	}
+
+	if !check.IfNil(enableEpochsHandler) {
+		config.IsFlagEnabled = func(flag string) bool {
+			return enableEpochsHandler.IsFlagEnabled(core.EnableEpochFlag(flag))
+		}
+	}
+
+	return config
+}
+
+// flagGatedImports maps the EEI functions which may only be imported after
+// the activation of an epoch flag to the name of that flag
+func flagGatedImports() map[string]string {
+	gatedImports := make(map[string]string)
+	for _, functionName := range vmhost.ESDTFunctionsImports {
+		gatedImports[functionName] = string(BuiltInFunctionsFlag)
+	}
+	return gatedImports
+}
+
+func importSignatures(imports *wasmer.Imports) map[string]*wasmanalyzer.FunctionType {
+	signatures := make(map[string]*wasmanalyzer.FunctionType)
+	for name, signature := range imports.Signatures() {
+		signatures[name] = &wasmanalyzer.FunctionType{
+			Params:  analyzerValueTypes(signature.InputTypes),
+			Results: analyzerValueTypes(signature.OutputTypes),
+		}
+	}
+	return signatures
+}
+
+func analyzerValueTypes(valueTypes []wasmer.ValueType) []wasmanalyzer.ValueType {
+	result := make([]wasmanalyzer.ValueType, len(valueTypes))
+	for i, valueType := range valueTypes {
+		result[i] = wasmanalyzer.ValueTypeI32
+		if valueType == wasmer.TypeI64 {
+			result[i] = wasmanalyzer.ValueTypeI64
+		}
+	}
+	return result
+}
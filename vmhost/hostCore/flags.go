@@ -20,3 +20,8 @@ var allFlags = []core.EnableEpochFlag{
 	RepairCallbackFlag,
 	AheadOfTimeGasUsageFlag,
 }
+
+// AllFlags returns all the flags used by mx-chain-vm-v1_3-go in the current version
+func AllFlags() []core.EnableEpochFlag {
+	return append([]core.EnableEpochFlag(nil), allFlags...)
+}
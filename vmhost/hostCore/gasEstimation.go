@@ -0,0 +1,156 @@
+package hostCore
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/math"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+)
+
+// GasEstimation holds the gas requirements of an execution, as observed
+// while running it without applying its output; GasLocked is the gas locked
+// by its async calls, for their callbacks
+type GasEstimation struct {
+	GasProvided     uint64
+	GasUsed         uint64
+	GasForwarded    uint64
+	GasLocked       uint64
+	BuiltInFuncCost uint64
+	MinimalGasLimit uint64
+	VMOutput        *vmcommon.VMOutput
+}
+
+// IsSuccessful returns true if the estimated execution ended with vmcommon.Ok
+func (estimation *GasEstimation) IsSuccessful() bool {
+	return estimation.VMOutput != nil && estimation.VMOutput.ReturnCode == vmcommon.Ok
+}
+
+// GasEstimator runs executions on a VMHost only to observe their gas
+// consumption; the resulting VMOutputs are never applied to the blockchain hook
+type GasEstimator struct {
+	host vmhost.VMHost
+}
+
+// NewGasEstimator creates a new GasEstimator
+func NewGasEstimator(host vmhost.VMHost) (*GasEstimator, error) {
+	if check.IfNil(host) {
+		return nil, vmhost.ErrNilVMHost
+	}
+
+	return &GasEstimator{host: host}, nil
+}
+
+// EstimateCall executes the call with its provided gas and reports the gas it
+// used; if searchMinimal is set and the call succeeds, it also searches for
+// the smallest gas limit with which the call still succeeds
+func (estimator *GasEstimator) EstimateCall(input *vmcommon.ContractCallInput, searchMinimal bool) (*GasEstimation, error) {
+	run := func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		inputCopy := *input
+		inputCopy.GasProvided = gasLimit
+		return estimator.host.RunSmartContractCall(&inputCopy)
+	}
+
+	vmOutput, err := run(input.GasProvided)
+	if err != nil {
+		return nil, err
+	}
+
+	estimation := newGasEstimation(input.GasProvided, vmOutput)
+	estimation.BuiltInFuncCost = estimator.builtInFunctionCost(input.Function)
+
+	err = estimator.searchMinimalGasLimit(estimation, searchMinimal, run)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimation, nil
+}
+
+// EstimateCreate executes the deployment with its provided gas and reports the
+// gas it used; if searchMinimal is set and the deployment succeeds, it also
+// searches for the smallest gas limit with which it still succeeds
+func (estimator *GasEstimator) EstimateCreate(input *vmcommon.ContractCreateInput, searchMinimal bool) (*GasEstimation, error) {
+	run := func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		inputCopy := *input
+		inputCopy.GasProvided = gasLimit
+		return estimator.host.RunSmartContractCreate(&inputCopy)
+	}
+
+	vmOutput, err := run(input.GasProvided)
+	if err != nil {
+		return nil, err
+	}
+
+	estimation := newGasEstimation(input.GasProvided, vmOutput)
+	err = estimator.searchMinimalGasLimit(estimation, searchMinimal, run)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimation, nil
+}
+
+func newGasEstimation(gasProvided uint64, vmOutput *vmcommon.VMOutput) *GasEstimation {
+	estimation := &GasEstimation{
+		GasProvided: gasProvided,
+		VMOutput:    vmOutput,
+	}
+	if vmOutput == nil {
+		return estimation
+	}
+
+	estimation.GasUsed = math.SubUint64(gasProvided, vmOutput.GasRemaining)
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			estimation.GasForwarded = math.AddUint64(estimation.GasForwarded, outputTransfer.GasLimit)
+			estimation.GasLocked = math.AddUint64(estimation.GasLocked, outputTransfer.GasLocked)
+		}
+	}
+
+	return estimation
+}
+
+func (estimator *GasEstimator) builtInFunctionCost(functionName string) uint64 {
+	if !estimator.host.IsBuiltinFunctionName(functionName) {
+		return 0
+	}
+
+	builtInCosts := estimator.host.GetGasScheduleMap()["BuiltInCost"]
+	return builtInCosts[functionName]
+}
+
+// searchMinimalGasLimit binary-searches the smallest gas limit which lets the
+// execution succeed, between the gas used by the initial run and its gas limit
+func (estimator *GasEstimator) searchMinimalGasLimit(
+	estimation *GasEstimation,
+	searchMinimal bool,
+	run func(gasLimit uint64) (*vmcommon.VMOutput, error),
+) error {
+	if !estimation.IsSuccessful() {
+		return nil
+	}
+
+	estimation.MinimalGasLimit = estimation.GasProvided
+	if !searchMinimal {
+		return nil
+	}
+
+	low := estimation.GasUsed
+	high := estimation.GasProvided
+	for low < high {
+		middle := low + (high-low)/2
+		vmOutput, err := run(middle)
+		if err != nil {
+			return err
+		}
+
+		if vmOutput.ReturnCode == vmcommon.Ok {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+
+	estimation.MinimalGasLimit = high
+	return nil
+}
@@ -0,0 +1,100 @@
+package hostCore
+
+import (
+	"errors"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+// succeedingFrom creates a run function which succeeds with gas limits of at least minimalGasLimit, recording the gas limits tried
+func succeedingFrom(minimalGasLimit uint64, gasLimitsTried *[]uint64) func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+	return func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		*gasLimitsTried = append(*gasLimitsTried, gasLimit)
+		if gasLimit < minimalGasLimit {
+			return &vmcommon.VMOutput{ReturnCode: vmcommon.OutOfGas}, nil
+		}
+		return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasLimit - minimalGasLimit}, nil
+	}
+}
+
+func TestGasEstimator_SearchMinimalGasLimit(t *testing.T) {
+	estimator := &GasEstimator{}
+	var gasLimitsTried []uint64
+	estimation := newGasEstimation(1000, &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 500})
+
+	err := estimator.searchMinimalGasLimit(estimation, true, succeedingFrom(700, &gasLimitsTried))
+	require.Nil(t, err)
+	require.Equal(t, uint64(700), estimation.MinimalGasLimit)
+	for _, gasLimit := range gasLimitsTried {
+		require.True(t, gasLimit >= 500 && gasLimit < 1000)
+	}
+}
+
+func TestGasEstimator_SearchMinimalGasLimit_FailedRun(t *testing.T) {
+	estimator := &GasEstimator{}
+	var gasLimitsTried []uint64
+	estimation := newGasEstimation(1000, &vmcommon.VMOutput{ReturnCode: vmcommon.UserError})
+
+	err := estimator.searchMinimalGasLimit(estimation, true, succeedingFrom(700, &gasLimitsTried))
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), estimation.MinimalGasLimit)
+	require.Empty(t, gasLimitsTried)
+}
+
+func TestGasEstimator_SearchMinimalGasLimit_NotRequested(t *testing.T) {
+	estimator := &GasEstimator{}
+	var gasLimitsTried []uint64
+	estimation := newGasEstimation(1000, &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 500})
+
+	err := estimator.searchMinimalGasLimit(estimation, false, succeedingFrom(700, &gasLimitsTried))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1000), estimation.MinimalGasLimit)
+	require.Empty(t, gasLimitsTried)
+}
+
+func TestGasEstimator_SearchMinimalGasLimit_AllGasUsed(t *testing.T) {
+	estimator := &GasEstimator{}
+	var gasLimitsTried []uint64
+	estimation := newGasEstimation(1000, &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 0})
+	require.Equal(t, estimation.GasProvided, estimation.GasUsed)
+
+	// nothing to search, the gas used is the minimal gas limit
+	err := estimator.searchMinimalGasLimit(estimation, true, succeedingFrom(1000, &gasLimitsTried))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1000), estimation.MinimalGasLimit)
+	require.Empty(t, gasLimitsTried)
+}
+
+func TestGasEstimator_SearchMinimalGasLimit_RunError(t *testing.T) {
+	estimator := &GasEstimator{}
+	estimation := newGasEstimation(1000, &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 500})
+	expectedErr := errors.New("expected error")
+
+	err := estimator.searchMinimalGasLimit(estimation, true, func(gasLimit uint64) (*vmcommon.VMOutput, error) {
+		return nil, expectedErr
+	})
+	require.Equal(t, expectedErr, err)
+}
+
+func TestNewGasEstimation_GasLockedByAsyncTransfers(t *testing.T) {
+	estimation := newGasEstimation(1000, &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: 100})
+	require.Equal(t, uint64(900), estimation.GasUsed)
+	require.Equal(t, uint64(0), estimation.GasLocked)
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: 100,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"first": {OutputTransfers: []vmcommon.OutputTransfer{{GasLimit: 300, GasLocked: 50}}},
+			"second": {OutputTransfers: []vmcommon.OutputTransfer{
+				{GasLimit: 200, GasLocked: 70},
+				{GasLimit: 100},
+			}},
+		},
+	}
+	estimation = newGasEstimation(1000, vmOutput)
+	require.Equal(t, uint64(600), estimation.GasForwarded)
+	require.Equal(t, uint64(120), estimation.GasLocked)
+}
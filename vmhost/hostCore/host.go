@@ -3,6 +3,7 @@ package hostCore
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -47,6 +48,7 @@ type vmHost struct {
 	scAPIMethods         *wasmer.Imports
 	builtInFuncContainer vmcommon.BuiltInFunctionContainer
 	enableEpochsHandler  vmhost.EnableEpochsHandler
+	executionTimeout     time.Duration
 }
 
 // NewVMHost creates a new VM vmHost
@@ -74,6 +76,7 @@ func NewVMHost(
 		scAPIMethods:         nil,
 		builtInFuncContainer: hostParameters.BuiltInFuncContainer,
 		enableEpochsHandler:  hostParameters.EnableEpochsHandler,
+		executionTimeout:     hostParameters.ExecutionTimeout,
 	}
 
 	imports, err := vmhooks.BaseOpsAPIImports()
@@ -317,7 +320,11 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 		log.Error("RunSmartContractCreate", "error", err)
 	}
 
+	stopWatchdog := host.startExecutionWatchdog()
 	TryCatch(try, catch, "vmhost.RunSmartContractCreate")
+	if stopWatchdog() {
+		vmOutput = host.handleExecutionTimeout(vmOutput)
+	}
 	if vmOutput != nil {
 		log.Trace("RunSmartContractCreate end", "returnCode", vmOutput.ReturnCode, "returnMessage", vmOutput.ReturnMessage)
 	}
@@ -350,12 +357,16 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		log.Error("RunSmartContractCall", "error", err)
 	}
 
+	stopWatchdog := host.startExecutionWatchdog()
 	isUpgrade := input.Function == vmhost.UpgradeFunctionName
 	if isUpgrade {
 		TryCatch(tryUpgrade, catch, "vmhost.RunSmartContractUpgrade")
 	} else {
 		TryCatch(tryCall, catch, "vmhost.RunSmartContractCall")
 	}
+	if stopWatchdog() {
+		vmOutput = host.handleExecutionTimeout(vmOutput)
+	}
 
 	return
 }
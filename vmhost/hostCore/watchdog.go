@@ -0,0 +1,60 @@
+package hostCore
+
+import (
+	"time"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+)
+
+// startExecutionWatchdog arms the wall-clock deadline of the execution about to
+// start, if one is configured. When the deadline is reached, the watchdog goroutine
+// interrupts the running Wasmer instance with BreakpointExecutionTimeout. The
+// returned function disarms the watchdog and reports whether the deadline was reached.
+func (host *vmHost) startExecutionWatchdog() func() bool {
+	if host.executionTimeout == 0 {
+		return func() bool {
+			return false
+		}
+	}
+
+	runtime := host.Runtime()
+	done := make(chan struct{})
+	timedOut := make(chan bool, 1)
+
+	go func() {
+		timer := time.NewTimer(host.executionTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-done:
+			timedOut <- false
+		case <-timer.C:
+			log.Debug("execution deadline reached", "timeout", host.executionTimeout)
+			runtime.SetExecutionInterrupt(vmhost.BreakpointExecutionTimeout)
+			timedOut <- true
+		}
+	}()
+
+	return func() bool {
+		close(done)
+		result := <-timedOut
+		runtime.SetExecutionInterrupt(vmhost.BreakpointNone)
+		return result
+	}
+}
+
+// handleExecutionTimeout reports a failed execution that was interrupted by the
+// watchdog as timed out, with ErrExecutionTimeout, regardless of how the interruption surfaced. Executions
+// that finished successfully before the interruption took effect are left as they are.
+func (host *vmHost) handleExecutionTimeout(vmOutput *vmcommon.VMOutput) *vmcommon.VMOutput {
+	host.runtimeContext.ResetWarmInstance()
+
+	if vmOutput == nil || vmOutput.ReturnCode == vmcommon.Ok {
+		return vmOutput
+	}
+
+	vmOutput.ReturnCode = vmhost.ExecutionTimedOut
+	vmOutput.ReturnMessage = vmhost.ErrExecutionTimeout.Error()
+	return vmOutput
+}
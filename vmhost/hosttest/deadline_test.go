@@ -0,0 +1,111 @@
+package hostCoretest
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/config"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/mock"
+	contextmock "github.com/multiversx/mx-chain-vm-v1_3-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-v1_3-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-v1_3-go/vmhost/hostCore"
+	"github.com/stretchr/testify/require"
+)
+
+// endlessLoopCode is a contract exporting "loop", which never returns, and "noop"
+var endlessLoopCode = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type section: () -> ()
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	// function section: two functions of type 0
+	0x03, 0x03, 0x02, 0x00, 0x00,
+	// memory section: one page
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// export section: memory, loop, noop
+	0x07, 0x18, 0x03,
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+	0x04, 'l', 'o', 'o', 'p', 0x00, 0x00,
+	0x04, 'n', 'o', 'o', 'p', 0x00, 0x01,
+	// code section: loop { br 0 }, and an empty body
+	0x0a, 0x0c, 0x02,
+	0x07, 0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b,
+	0x02, 0x00, 0x0b,
+}
+
+func defaultTestVMWithExecutionTimeout(tb testing.TB, code []byte, timeout time.Duration) vmhost.VMHost {
+	stubBlockchainHook := &contextmock.BlockchainHookStub{}
+	stubBlockchainHook.GetUserAccountCalled = func(scAddress []byte) (vmcommon.UserAccountHandler, error) {
+		if bytes.Equal(scAddress, test.ParentAddress) {
+			return &contextmock.StubAccount{
+				Balance: big.NewInt(0),
+			}, nil
+		}
+		return nil, test.ErrAccountNotFound
+	}
+	stubBlockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	host, err := hostCore.NewVMHost(stubBlockchainHook, &vmhost.VMHostParameters{
+		VMType:               test.DefaultVMType,
+		BlockGasLimit:        uint64(1000),
+		GasSchedule:          config.MakeGasMapForTests(),
+		BuiltInFuncContainer: builtInFunctions.NewBuiltInFunctionContainer(),
+		ProtectedKeyPrefix:   []byte("E" + "L" + "R" + "O" + "N" + "D"),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return true
+			},
+		},
+		ExecutionTimeout: timeout,
+	})
+	require.Nil(tb, err)
+	require.NotNil(tb, host)
+
+	return host
+}
+
+func TestExecution_Deadline_StopsEndlessLoop(t *testing.T) {
+	host := defaultTestVMWithExecutionTimeout(t, endlessLoopCode, 50*time.Millisecond)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(math.MaxInt64).
+		WithFunction("loop").
+		Build()
+
+	start := time.Now()
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+	require.Equal(t, vmhost.ExecutionTimedOut, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionTimeout.Error(), vmOutput.ReturnMessage)
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	// the interrupt must not leak into the next execution
+	input.Function = "noop"
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestExecution_Deadline_NotReached(t *testing.T) {
+	host := defaultTestVMWithExecutionTimeout(t, endlessLoopCode, time.Minute)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(100000).
+		WithFunction("noop").
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
@@ -120,6 +120,8 @@ type RuntimeContext interface {
 	MustVerifyNextContractCode()
 	SetRuntimeBreakpointValue(value BreakpointValue)
 	GetRuntimeBreakpointValue() BreakpointValue
+	SetExecutionInterrupt(value BreakpointValue)
+	GetExecutionInterrupt() BreakpointValue
 	IsContractOnTheStack(address []byte) bool
 	GetAsyncCallInfo() *AsyncCallInfo
 	SetAsyncCallInfo(asyncCallInfo *AsyncCallInfo)
@@ -219,6 +221,7 @@ type MeteringContext interface {
 	GetSCPrepareInitialCost() uint64
 	BoundGasLimit(value int64) uint64
 	BlockGasLimit() uint64
+	SetBlockGasLimit(gasLimit uint64)
 	DeductInitialGasForExecution(contract []byte) error
 	DeductInitialGasForDirectDeployment(input CodeDeployInput) error
 	DeductInitialGasForIndirectDeployment(input CodeDeployInput) error
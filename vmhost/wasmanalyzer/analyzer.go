@@ -0,0 +1,353 @@
+package wasmanalyzer
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+)
+
+const maxLengthOfFunctionName = 256
+
+// Limits of the default configuration
+const (
+	DefaultMaxFunctions        = 8192
+	DefaultMaxLocals           = 4096
+	DefaultMaxTableSize        = 8192
+	DefaultMaxDataSegmentsSize = 1 << 20
+	DefaultMaxMemoryPages      = 64
+)
+
+// Config holds the rules enforced by the analyzer
+type Config struct {
+	// Limits; zero means unlimited
+	MaxFunctions        uint32
+	MaxLocals           uint64
+	MaxTableSize        uint32
+	MaxDataSegmentsSize uint64
+	MaxMemoryPages      uint32
+
+	// AllowFloatOpcodes disables the rejection of floating point instructions
+	AllowFloatOpcodes bool
+
+	// Imports holds the signatures of the functions a contract may import;
+	// a nil map disables the verification of imports
+	Imports map[string]*FunctionType
+
+	// GatedImports maps imported functions to the name of the epoch flag which enables them
+	GatedImports map[string]string
+
+	// IsFlagEnabled tells whether an epoch flag is active; if nil, gated
+	// imports are reported, but not rejected
+	IsFlagEnabled func(flag string) bool
+
+	// ReservedNames holds the names a contract may not export
+	ReservedNames map[string]struct{}
+}
+
+// NewDefaultConfig creates a Config with the default limits and no import restrictions
+func NewDefaultConfig() *Config {
+	return &Config{
+		MaxFunctions:        DefaultMaxFunctions,
+		MaxLocals:           DefaultMaxLocals,
+		MaxTableSize:        DefaultMaxTableSize,
+		MaxDataSegmentsSize: DefaultMaxDataSegmentsSize,
+		MaxMemoryPages:      DefaultMaxMemoryPages,
+	}
+}
+
+// Report holds the outcome of the analysis of a module
+type Report struct {
+	Module           *Module
+	NumFunctions     int
+	MaxLocals        uint64
+	DataSegmentsSize uint64
+	MemoryPages      uint32
+	TableSize        uint32
+	FloatOpcodes     map[string]int
+	GatedImports     map[string]string
+	Violations       []*Violation
+}
+
+// IsValid returns true if no violations were found
+func (report *Report) IsValid() bool {
+	return len(report.Violations) == 0
+}
+
+// Err returns the first violation found, or nil if the module is valid
+func (report *Report) Err() error {
+	if report.IsValid() {
+		return nil
+	}
+	return report.Violations[0]
+}
+
+func (report *Report) addViolation(err error, functionIndex int, format string, args ...interface{}) {
+	report.Violations = append(report.Violations, &Violation{
+		Err:           err,
+		FunctionIndex: functionIndex,
+		Detail:        fmt.Sprintf(format, args...),
+	})
+}
+
+// Analyzer statically verifies WebAssembly contracts before deployment
+type Analyzer struct {
+	config *Config
+}
+
+// NewAnalyzer creates a new Analyzer
+func NewAnalyzer(config *Config) (*Analyzer, error) {
+	if config == nil {
+		return nil, ErrNilConfig
+	}
+
+	return &Analyzer{config: config}, nil
+}
+
+// Validate analyzes the code and returns the first violation found, if any
+func (analyzer *Analyzer) Validate(code []byte) error {
+	report, err := analyzer.Analyze(code)
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// Analyze decodes the code and reports all the rules it breaks; an error is
+// returned only if the code cannot be decoded
+func (analyzer *Analyzer) Analyze(code []byte) (*Report, error) {
+	module, err := DecodeModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Module:       module,
+		NumFunctions: module.NumImportedFn + len(module.Functions),
+		FloatOpcodes: make(map[string]int),
+		GatedImports: make(map[string]string),
+	}
+
+	analyzer.checkFunctionCount(report)
+	analyzer.checkMemory(report)
+	analyzer.checkTables(report)
+	analyzer.checkDataSegments(report)
+	analyzer.checkImports(report)
+	analyzer.checkExports(report)
+	err = analyzer.checkFunctionBodies(report)
+	if err != nil {
+		return nil, err
+	}
+	err = analyzer.checkInitExpressions(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (analyzer *Analyzer) checkFunctionCount(report *Report) {
+	maxFunctions := analyzer.config.MaxFunctions
+	if maxFunctions > 0 && report.NumFunctions > int(maxFunctions) {
+		report.addViolation(ErrTooManyFunctions, NoFunction, "%d functions, at most %d allowed", report.NumFunctions, maxFunctions)
+	}
+}
+
+func (analyzer *Analyzer) checkMemory(report *Report) {
+	module := report.Module
+	for _, memory := range module.Memories {
+		if memory.Min > report.MemoryPages {
+			report.MemoryPages = memory.Min
+		}
+	}
+
+	maxPages := analyzer.config.MaxMemoryPages
+	if maxPages > 0 && report.MemoryPages > maxPages {
+		report.addViolation(ErrTooManyMemoryPages, NoFunction, "%d initial pages, at most %d allowed", report.MemoryPages, maxPages)
+	}
+
+	for _, exp := range module.Exports {
+		if exp.Kind == ExternalMemory {
+			return
+		}
+	}
+	report.addViolation(ErrMemoryDeclarationMissing, NoFunction, "no exported memory")
+}
+
+func (analyzer *Analyzer) checkTables(report *Report) {
+	maxTableSize := analyzer.config.MaxTableSize
+	for i, table := range report.Module.Tables {
+		size := table.Min
+		if table.HasMax && table.Max > size {
+			size = table.Max
+		}
+		if size > report.TableSize {
+			report.TableSize = size
+		}
+
+		if maxTableSize > 0 && size > maxTableSize {
+			report.addViolation(ErrTableTooLarge, NoFunction, "table %d has %d elements, at most %d allowed", i, size, maxTableSize)
+		}
+	}
+}
+
+func (analyzer *Analyzer) checkDataSegments(report *Report) {
+	for _, size := range report.Module.DataSizes {
+		report.DataSegmentsSize += uint64(size)
+	}
+
+	maxSize := analyzer.config.MaxDataSegmentsSize
+	if maxSize > 0 && report.DataSegmentsSize > maxSize {
+		report.addViolation(ErrDataSegmentsTooLarge, NoFunction, "%d bytes of data, at most %d allowed", report.DataSegmentsSize, maxSize)
+	}
+}
+
+func (analyzer *Analyzer) checkImports(report *Report) {
+	module := report.Module
+	functionIndex := 0
+	for _, imp := range module.Imports {
+		if imp.Kind != ExternalFunction || imp.Module != "env" {
+			report.addViolation(ErrUnsupportedImport, NoFunction, "%s %s.%s", imp.Kind, imp.Module, imp.Name)
+			if imp.Kind == ExternalFunction {
+				functionIndex++
+			}
+			continue
+		}
+
+		analyzer.checkImportedFunction(report, imp, functionIndex)
+		functionIndex++
+	}
+}
+
+func (analyzer *Analyzer) checkImportedFunction(report *Report, imp *Import, functionIndex int) {
+	flag, isGated := analyzer.config.GatedImports[imp.Name]
+	if isGated {
+		report.GatedImports[imp.Name] = flag
+		isFlagEnabled := analyzer.config.IsFlagEnabled
+		if isFlagEnabled != nil && !isFlagEnabled(flag) {
+			report.addViolation(ErrImportGatedByFlag, functionIndex, "%s requires %s", imp.Name, flag)
+		}
+	}
+
+	if analyzer.config.Imports == nil {
+		return
+	}
+
+	expected, ok := analyzer.config.Imports[imp.Name]
+	if !ok {
+		report.addViolation(ErrUnknownImport, functionIndex, "%s", imp.Name)
+		return
+	}
+
+	declared, ok := report.Module.FunctionType(functionIndex)
+	if !ok {
+		report.addViolation(ErrImportSignatureMismatch, functionIndex, "%s has an invalid type index", imp.Name)
+		return
+	}
+	if !declared.Equals(expected) {
+		report.addViolation(ErrImportSignatureMismatch, functionIndex, "%s declared as %s, expected %s", imp.Name, declared, expected)
+	}
+}
+
+func (analyzer *Analyzer) checkExports(report *Report) {
+	module := report.Module
+	for _, exp := range module.Exports {
+		if exp.Kind != ExternalFunction {
+			continue
+		}
+
+		functionIndex := int(exp.Index)
+		if !analyzer.isValidFunctionName(exp.Name) {
+			report.addViolation(ErrInvalidFunctionName, functionIndex, "%q", exp.Name)
+		}
+
+		signature, ok := module.FunctionType(functionIndex)
+		if !ok || len(signature.Params) > 0 || len(signature.Results) > 0 {
+			report.addViolation(ErrFunctionNonvoidSignature, functionIndex, "%s", exp.Name)
+		}
+	}
+}
+
+func (analyzer *Analyzer) isValidFunctionName(functionName string) bool {
+	if len(functionName) == 0 || len(functionName) >= maxLengthOfFunctionName {
+		return false
+	}
+	for i := 0; i < len(functionName); i++ {
+		if functionName[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	_, isReserved := analyzer.config.ReservedNames[functionName]
+	return !isReserved
+}
+
+func (analyzer *Analyzer) checkFunctionBodies(report *Report) error {
+	module := report.Module
+	maxLocals := analyzer.config.MaxLocals
+	for i, function := range module.Functions {
+		functionIndex := module.NumImportedFn + i
+		if function.NumLocals > report.MaxLocals {
+			report.MaxLocals = function.NumLocals
+		}
+		if maxLocals > 0 && function.NumLocals > maxLocals {
+			report.addViolation(ErrTooManyLocals, functionIndex, "%d locals, at most %d allowed", function.NumLocals, maxLocals)
+		}
+
+		floatOpcodes := make(map[string]int)
+		err := walkInstructions(newByteReader(function.Body), func(opcode uint32) {
+			name, isFloat := floatOpcodeNames[opcode]
+			if isFloat {
+				floatOpcodes[name]++
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("function %d: %w", functionIndex, err)
+		}
+
+		analyzer.reportFloatOpcodes(report, functionIndex, floatOpcodes)
+	}
+
+	return nil
+}
+
+func (analyzer *Analyzer) checkInitExpressions(report *Report) error {
+	module := report.Module
+	floatOpcodes := make(map[string]int)
+	initExpressions := make([][]byte, 0)
+	initExpressions = append(initExpressions, module.GlobalInits...)
+	initExpressions = append(initExpressions, module.ElementInits...)
+	initExpressions = append(initExpressions, module.DataInits...)
+	for _, expression := range initExpressions {
+		err := walkInstructions(newByteReader(expression), func(opcode uint32) {
+			name, isFloat := floatOpcodeNames[opcode]
+			if isFloat {
+				floatOpcodes[name]++
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	analyzer.reportFloatOpcodes(report, NoFunction, floatOpcodes)
+	return nil
+}
+
+func (analyzer *Analyzer) reportFloatOpcodes(report *Report, functionIndex int, floatOpcodes map[string]int) {
+	for name, count := range floatOpcodes {
+		report.FloatOpcodes[name] += count
+	}
+	if len(floatOpcodes) == 0 || analyzer.config.AllowFloatOpcodes {
+		return
+	}
+
+	report.addViolation(ErrFloatOpcode, functionIndex, "uses %s", sortedKeys(floatOpcodes))
+}
+
+func sortedKeys(set map[string]int) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,282 @@
+package wasmanalyzer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func uleb(value uint32) []byte {
+	result := make([]byte, 0)
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value != 0 {
+			b |= 0x80
+		}
+		result = append(result, b)
+		if value == 0 {
+			return result
+		}
+	}
+}
+
+func name(s string) []byte {
+	return append(uleb(uint32(len(s))), []byte(s)...)
+}
+
+func section(id byte, items ...[]byte) []byte {
+	content := uleb(uint32(len(items)))
+	for _, item := range items {
+		content = append(content, item...)
+	}
+	result := []byte{id}
+	result = append(result, uleb(uint32(len(content)))...)
+	return append(result, content...)
+}
+
+func concat(parts ...[]byte) []byte {
+	result := make([]byte, 0)
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+	return result
+}
+
+func body(numI64Locals uint32, instructions ...byte) []byte {
+	content := []byte{}
+	if numI64Locals > 0 {
+		content = concat(uleb(1), uleb(numI64Locals), []byte{byte(ValueTypeI64)})
+	} else {
+		content = uleb(0)
+	}
+	content = append(content, instructions...)
+	content = append(content, opcodeEnd)
+	return append(uleb(uint32(len(content))), content...)
+}
+
+// buildModule creates a contract importing "getArgument" (i32 -> i32) and
+// exporting its memory and the given bodies as functions named f0, f1, ...
+func buildModule(importType []byte, bodies ...[]byte) []byte {
+	voidType := []byte{0x60, 0x00, 0x00}
+	functions := make([][]byte, len(bodies))
+	exports := [][]byte{concat(name("memory"), []byte{byte(ExternalMemory)}, uleb(0))}
+	for i := range bodies {
+		functions[i] = uleb(0)
+		exports = append(exports, concat(name("f"+string(rune('0'+i))), []byte{byte(ExternalFunction)}, uleb(uint32(i+1))))
+	}
+
+	return concat(
+		wasmPreamble,
+		section(sectionType, voidType, importType),
+		section(sectionImport, concat(name("env"), name("getArgument"), []byte{byte(ExternalFunction)}, uleb(1))),
+		section(sectionFunction, functions...),
+		section(sectionMemory, []byte{0x00, 0x02}),
+		section(sectionExport, exports...),
+		section(sectionCode, bodies...),
+	)
+}
+
+var getArgumentType = []byte{0x60, 0x01, byte(ValueTypeI32), 0x01, byte(ValueTypeI32)}
+
+func newTestConfig() *Config {
+	config := NewDefaultConfig()
+	config.Imports = map[string]*FunctionType{
+		"getArgument":    {Params: []ValueType{ValueTypeI32}, Results: []ValueType{ValueTypeI32}},
+		"getESDTBalance": {Params: []ValueType{ValueTypeI32}, Results: []ValueType{ValueTypeI32}},
+	}
+	config.ReservedNames = map[string]struct{}{"getArgument": {}}
+	return config
+}
+
+func requireSingleViolation(t *testing.T, report *Report, expected error) {
+	require.Len(t, report.Violations, 1)
+	require.True(t, errors.Is(report.Err(), expected), report.Err().Error())
+	require.True(t, errors.Is(report.Err(), ErrContractRejected))
+}
+
+func TestAnalyzer_NilConfig(t *testing.T) {
+	analyzer, err := NewAnalyzer(nil)
+	require.Nil(t, analyzer)
+	require.Equal(t, ErrNilConfig, err)
+}
+
+func TestAnalyzer_ValidModule(t *testing.T) {
+	analyzer, _ := NewAnalyzer(newTestConfig())
+	code := buildModule(getArgumentType,
+		body(0, opcodeI32Const, 0x00, opcodeCall, 0x00, 0x1a),
+		body(2, opcodeBlock, 0x40, opcodeLoop, 0x40, opcodeBr, 0x01, opcodeEnd, opcodeEnd),
+	)
+
+	report, err := analyzer.Analyze(code)
+	require.Nil(t, err)
+	require.True(t, report.IsValid(), report.Err())
+	require.Nil(t, analyzer.Validate(code))
+	require.Equal(t, 3, report.NumFunctions)
+	require.Equal(t, uint64(2), report.MaxLocals)
+	require.Equal(t, uint32(2), report.MemoryPages)
+	require.Len(t, report.Module.Imports, 1)
+	require.Len(t, report.Module.Exports, 3)
+}
+
+func TestAnalyzer_MalformedModule(t *testing.T) {
+	analyzer, _ := NewAnalyzer(newTestConfig())
+
+	_, err := analyzer.Analyze([]byte("not wasm"))
+	require.True(t, errors.Is(err, ErrInvalidMagic))
+
+	code := buildModule(getArgumentType, body(0))
+	_, err = analyzer.Analyze(code[:len(code)-1])
+	require.True(t, errors.Is(err, ErrMalformedModule))
+
+	code = buildModule(getArgumentType, body(0, 0xfd, 0x00))
+	_, err = analyzer.Analyze(code)
+	require.True(t, errors.Is(err, ErrUnsupportedOpcode))
+}
+
+func TestAnalyzer_OversizedCount(t *testing.T) {
+	analyzer, _ := NewAnalyzer(newTestConfig())
+	maxCount := uleb(0xffffffff)
+
+	// 15 bytes declaring 4 billion functions, the count must be rejected before allocating anything
+	code := concat(wasmPreamble, []byte{sectionFunction, byte(len(maxCount))}, maxCount)
+	require.Len(t, code, 15)
+	_, err := analyzer.Analyze(code)
+	require.True(t, errors.Is(err, ErrUnexpectedEnd))
+
+	functionType := concat(uleb(1), []byte{0x60}, maxCount, uleb(0))
+	code = concat(wasmPreamble, []byte{sectionType}, uleb(uint32(len(functionType))), functionType)
+	_, err = analyzer.Analyze(code)
+	require.True(t, errors.Is(err, ErrUnexpectedEnd))
+}
+
+func TestAnalyzer_FloatOpcodes(t *testing.T) {
+	f32Add := []byte{opcodeF32Const, 0, 0, 0, 0, opcodeF32Const, 0, 0, 0, 0, 0x92, 0x1a}
+	truncSat := []byte{opcodeF64Const, 0, 0, 0, 0, 0, 0, 0, 0, opcodePrefixMisc, 0x02, 0x1a}
+	code := buildModule(getArgumentType, body(0, f32Add...), body(0, truncSat...))
+
+	analyzer, _ := NewAnalyzer(newTestConfig())
+	report, err := analyzer.Analyze(code)
+	require.Nil(t, err)
+	require.Len(t, report.Violations, 2)
+	require.True(t, errors.Is(report.Violations[0], ErrFloatOpcode))
+	require.Equal(t, 1, report.Violations[0].FunctionIndex)
+	require.Equal(t, 2, report.Violations[1].FunctionIndex)
+	require.Equal(t, 2, report.FloatOpcodes["f32.const"])
+	require.Equal(t, 1, report.FloatOpcodes["i32.trunc_sat_f64_s"])
+
+	config := newTestConfig()
+	config.AllowFloatOpcodes = true
+	analyzer, _ = NewAnalyzer(config)
+	require.Nil(t, analyzer.Validate(code))
+}
+
+func TestAnalyzer_Limits(t *testing.T) {
+	code := buildModule(getArgumentType, body(5), body(0))
+
+	config := newTestConfig()
+	config.MaxFunctions = 2
+	analyzer, _ := NewAnalyzer(config)
+	report, _ := analyzer.Analyze(code)
+	requireSingleViolation(t, report, ErrTooManyFunctions)
+
+	config = newTestConfig()
+	config.MaxLocals = 4
+	analyzer, _ = NewAnalyzer(config)
+	report, _ = analyzer.Analyze(code)
+	requireSingleViolation(t, report, ErrTooManyLocals)
+	require.Equal(t, 1, report.Violations[0].FunctionIndex)
+
+	config = newTestConfig()
+	config.MaxMemoryPages = 1
+	analyzer, _ = NewAnalyzer(config)
+	report, _ = analyzer.Analyze(code)
+	requireSingleViolation(t, report, ErrTooManyMemoryPages)
+}
+
+func TestAnalyzer_TablesAndData(t *testing.T) {
+	code := buildModule(getArgumentType, body(0))
+	code = append(code, section(sectionData, concat(uleb(0), []byte{opcodeI32Const, 0x00, opcodeEnd}, uleb(3), []byte("abc")))...)
+	tableSection := section(sectionTable, []byte{byte(ValueTypeFuncRef), 0x01, 0x02, 0x0a})
+	code = concat(code[:len(wasmPreamble)], tableSection, code[len(wasmPreamble):])
+
+	config := newTestConfig()
+	config.MaxTableSize = 5
+	config.MaxDataSegmentsSize = 2
+	analyzer, _ := NewAnalyzer(config)
+	report, err := analyzer.Analyze(code)
+	require.Nil(t, err)
+	require.Equal(t, uint32(10), report.TableSize)
+	require.Equal(t, uint64(3), report.DataSegmentsSize)
+	require.Len(t, report.Violations, 2)
+	require.True(t, errors.Is(report.Violations[0], ErrTableTooLarge))
+	require.True(t, errors.Is(report.Violations[1], ErrDataSegmentsTooLarge))
+}
+
+func TestAnalyzer_Imports(t *testing.T) {
+	analyzer, _ := NewAnalyzer(newTestConfig())
+
+	wrongType := []byte{0x60, 0x01, byte(ValueTypeI64), 0x01, byte(ValueTypeI32)}
+	report, err := analyzer.Analyze(buildModule(wrongType, body(0)))
+	require.Nil(t, err)
+	requireSingleViolation(t, report, ErrImportSignatureMismatch)
+	require.Equal(t, 0, report.Violations[0].FunctionIndex)
+
+	config := newTestConfig()
+	delete(config.Imports, "getArgument")
+	analyzer, _ = NewAnalyzer(config)
+	report, _ = analyzer.Analyze(buildModule(getArgumentType, body(0)))
+	requireSingleViolation(t, report, ErrUnknownImport)
+}
+
+func TestAnalyzer_GatedImports(t *testing.T) {
+	code := buildModule(getArgumentType, body(0))
+
+	config := newTestConfig()
+	config.GatedImports = map[string]string{"getArgument": "SomeFlag"}
+	analyzer, _ := NewAnalyzer(config)
+	report, _ := analyzer.Analyze(code)
+	require.True(t, report.IsValid())
+	require.Equal(t, map[string]string{"getArgument": "SomeFlag"}, report.GatedImports)
+
+	config.IsFlagEnabled = func(flag string) bool { return false }
+	report, _ = analyzer.Analyze(code)
+	requireSingleViolation(t, report, ErrImportGatedByFlag)
+
+	config.IsFlagEnabled = func(flag string) bool { return flag == "SomeFlag" }
+	require.Nil(t, analyzer.Validate(code))
+}
+
+func TestAnalyzer_Exports(t *testing.T) {
+	config := newTestConfig()
+	config.ReservedNames["f0"] = struct{}{}
+	analyzer, _ := NewAnalyzer(config)
+	report, _ := analyzer.Analyze(buildModule(getArgumentType, body(0)))
+	requireSingleViolation(t, report, ErrInvalidFunctionName)
+
+	code := buildModule(getArgumentType, body(0))
+	code = concat(code[:len(wasmPreamble)],
+		section(sectionType, []byte{0x60, 0x00, 0x01, byte(ValueTypeI32)}, getArgumentType),
+		code[len(wasmPreamble)+len(section(sectionType, []byte{0x60, 0x00, 0x00}, getArgumentType)):],
+	)
+	analyzer, _ = NewAnalyzer(newTestConfig())
+	report, err := analyzer.Analyze(code)
+	require.Nil(t, err)
+	requireSingleViolation(t, report, ErrFunctionNonvoidSignature)
+}
+
+func TestAnalyzer_MemoryNotExported(t *testing.T) {
+	code := concat(
+		wasmPreamble,
+		section(sectionType, []byte{0x60, 0x00, 0x00}),
+		section(sectionFunction, uleb(0)),
+		section(sectionExport, concat(name("f0"), []byte{byte(ExternalFunction)}, uleb(0))),
+		section(sectionCode, body(0)),
+	)
+
+	analyzer, _ := NewAnalyzer(newTestConfig())
+	report, err := analyzer.Analyze(code)
+	require.Nil(t, err)
+	requireSingleViolation(t, report, ErrMemoryDeclarationMissing)
+}
@@ -0,0 +1,95 @@
+package wasmanalyzer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMalformedModule signals that the bytecode is not a well-formed WebAssembly module
+var ErrMalformedModule = errors.New("malformed wasm module")
+
+// ErrUnexpectedEnd signals that the bytecode ended before a complete element could be read
+var ErrUnexpectedEnd = fmt.Errorf("%w (unexpected end)", ErrMalformedModule)
+
+// ErrInvalidMagic signals that the bytecode does not start with the WebAssembly preamble
+var ErrInvalidMagic = fmt.Errorf("%w (invalid magic number or version)", ErrMalformedModule)
+
+// ErrLEB128Overflow signals that a LEB128-encoded integer does not fit its declared size
+var ErrLEB128Overflow = fmt.Errorf("%w (LEB128 overflow)", ErrMalformedModule)
+
+// ErrUnsupportedOpcode signals that the bytecode contains an instruction unknown to the analyzer
+var ErrUnsupportedOpcode = fmt.Errorf("%w (unsupported opcode)", ErrMalformedModule)
+
+// ErrNilConfig signals that a nil configuration has been provided
+var ErrNilConfig = errors.New("nil analyzer config")
+
+// ErrContractRejected is the parent of all the violations reported by the analyzer
+var ErrContractRejected = errors.New("contract rejected by static analysis")
+
+// ErrFloatOpcode signals that a function uses floating point instructions
+var ErrFloatOpcode = fmt.Errorf("%w (floating point opcode)", ErrContractRejected)
+
+// ErrTooManyFunctions signals that the module declares more functions than allowed
+var ErrTooManyFunctions = fmt.Errorf("%w (too many functions)", ErrContractRejected)
+
+// ErrTooManyLocals signals that a function declares more locals than allowed
+var ErrTooManyLocals = fmt.Errorf("%w (too many locals)", ErrContractRejected)
+
+// ErrTableTooLarge signals that a table is larger than allowed
+var ErrTableTooLarge = fmt.Errorf("%w (table too large)", ErrContractRejected)
+
+// ErrDataSegmentsTooLarge signals that the data segments exceed the allowed size
+var ErrDataSegmentsTooLarge = fmt.Errorf("%w (data segments too large)", ErrContractRejected)
+
+// ErrTooManyMemoryPages signals that the memory declares more pages than allowed
+var ErrTooManyMemoryPages = fmt.Errorf("%w (too many memory pages)", ErrContractRejected)
+
+// ErrMemoryDeclarationMissing signals that the module does not export its memory
+var ErrMemoryDeclarationMissing = fmt.Errorf("%w (memory declaration missing)", ErrContractRejected)
+
+// ErrUnknownImport signals that the module imports a function which is not part of the EEI
+var ErrUnknownImport = fmt.Errorf("%w (unknown import)", ErrContractRejected)
+
+// ErrUnsupportedImport signals that the module imports something other than a function from "env"
+var ErrUnsupportedImport = fmt.Errorf("%w (unsupported import)", ErrContractRejected)
+
+// ErrImportSignatureMismatch signals that an imported function is declared with the wrong signature
+var ErrImportSignatureMismatch = fmt.Errorf("%w (import signature mismatch)", ErrContractRejected)
+
+// ErrImportGatedByFlag signals that an imported function is not yet available, because its flag is disabled
+var ErrImportGatedByFlag = fmt.Errorf("%w (import gated by disabled flag)", ErrContractRejected)
+
+// ErrInvalidFunctionName signals that an exported function has an invalid or reserved name
+var ErrInvalidFunctionName = fmt.Errorf("%w (invalid function name)", ErrContractRejected)
+
+// ErrFunctionNonvoidSignature signals that an exported function has parameters or results
+var ErrFunctionNonvoidSignature = fmt.Errorf("%w (nonvoid signature)", ErrContractRejected)
+
+// Violation is a single rule broken by a module, as detected by the analyzer
+type Violation struct {
+	// Err is one of the sentinel errors of this package, usable with errors.Is
+	Err error
+
+	// FunctionIndex is the index of the offending function in the function
+	// index space (imports first), or NoFunction if not related to a function
+	FunctionIndex int
+
+	// Detail describes the offending element
+	Detail string
+}
+
+// NoFunction marks a violation which is not related to a particular function
+const NoFunction = -1
+
+// Error returns the description of the violation
+func (violation *Violation) Error() string {
+	if violation.FunctionIndex == NoFunction {
+		return fmt.Sprintf("%s: %s", violation.Err.Error(), violation.Detail)
+	}
+	return fmt.Sprintf("%s: function %d: %s", violation.Err.Error(), violation.FunctionIndex, violation.Detail)
+}
+
+// Unwrap returns the sentinel error of the violation
+func (violation *Violation) Unwrap() error {
+	return violation.Err
+}
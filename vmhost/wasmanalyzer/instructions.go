@@ -0,0 +1,199 @@
+package wasmanalyzer
+
+import "fmt"
+
+const (
+	opcodeBlock        = 0x02
+	opcodeLoop         = 0x03
+	opcodeIf           = 0x04
+	opcodeEnd          = 0x0b
+	opcodeBr           = 0x0c
+	opcodeBrIf         = 0x0d
+	opcodeBrTable      = 0x0e
+	opcodeCall         = 0x10
+	opcodeCallIndirect = 0x11
+	opcodeSelectTyped  = 0x1c
+	opcodeLocalGet     = 0x20
+	opcodeTableSet     = 0x26
+	opcodeLoadFirst    = 0x28
+	opcodeStoreLast    = 0x3e
+	opcodeMemorySize   = 0x3f
+	opcodeMemoryGrow   = 0x40
+	opcodeI32Const     = 0x41
+	opcodeI64Const     = 0x42
+	opcodeF32Const     = 0x43
+	opcodeF64Const     = 0x44
+	opcodeRefNull      = 0xd0
+	opcodeRefFunc      = 0xd2
+	opcodePrefixMisc   = 0xfc
+	opcodePrefixSIMD   = 0xfd
+)
+
+// miscOpcode encodes an instruction of the 0xFC family as a single opcode value
+func miscOpcode(subOpcode uint32) uint32 {
+	return opcodePrefixMisc<<8 | subOpcode
+}
+
+// floatOpcodeNames names all the instructions which operate on floating point values
+var floatOpcodeNames = map[uint32]string{
+	0x2a: "f32.load", 0x2b: "f64.load", 0x38: "f32.store", 0x39: "f64.store",
+	0x43: "f32.const", 0x44: "f64.const",
+	0x5b: "f32.eq", 0x5c: "f32.ne", 0x5d: "f32.lt", 0x5e: "f32.gt", 0x5f: "f32.le", 0x60: "f32.ge",
+	0x61: "f64.eq", 0x62: "f64.ne", 0x63: "f64.lt", 0x64: "f64.gt", 0x65: "f64.le", 0x66: "f64.ge",
+	0x8b: "f32.abs", 0x8c: "f32.neg", 0x8d: "f32.ceil", 0x8e: "f32.floor", 0x8f: "f32.trunc",
+	0x90: "f32.nearest", 0x91: "f32.sqrt", 0x92: "f32.add", 0x93: "f32.sub", 0x94: "f32.mul",
+	0x95: "f32.div", 0x96: "f32.min", 0x97: "f32.max", 0x98: "f32.copysign",
+	0x99: "f64.abs", 0x9a: "f64.neg", 0x9b: "f64.ceil", 0x9c: "f64.floor", 0x9d: "f64.trunc",
+	0x9e: "f64.nearest", 0x9f: "f64.sqrt", 0xa0: "f64.add", 0xa1: "f64.sub", 0xa2: "f64.mul",
+	0xa3: "f64.div", 0xa4: "f64.min", 0xa5: "f64.max", 0xa6: "f64.copysign",
+	0xa8: "i32.trunc_f32_s", 0xa9: "i32.trunc_f32_u", 0xaa: "i32.trunc_f64_s", 0xab: "i32.trunc_f64_u",
+	0xae: "i64.trunc_f32_s", 0xaf: "i64.trunc_f32_u", 0xb0: "i64.trunc_f64_s", 0xb1: "i64.trunc_f64_u",
+	0xb2: "f32.convert_i32_s", 0xb3: "f32.convert_i32_u", 0xb4: "f32.convert_i64_s", 0xb5: "f32.convert_i64_u",
+	0xb6: "f32.demote_f64",
+	0xb7: "f64.convert_i32_s", 0xb8: "f64.convert_i32_u", 0xb9: "f64.convert_i64_s", 0xba: "f64.convert_i64_u",
+	0xbb: "f64.promote_f32",
+	0xbc: "i32.reinterpret_f32", 0xbd: "i64.reinterpret_f64", 0xbe: "f32.reinterpret_i32", 0xbf: "f64.reinterpret_i64",
+	miscOpcode(0): "i32.trunc_sat_f32_s", miscOpcode(1): "i32.trunc_sat_f32_u",
+	miscOpcode(2): "i32.trunc_sat_f64_s", miscOpcode(3): "i32.trunc_sat_f64_u",
+	miscOpcode(4): "i64.trunc_sat_f32_s", miscOpcode(5): "i64.trunc_sat_f32_u",
+	miscOpcode(6): "i64.trunc_sat_f64_s", miscOpcode(7): "i64.trunc_sat_f64_u",
+}
+
+// IsFloatOpcode returns true if the opcode operates on floating point values
+func IsFloatOpcode(opcode uint32) bool {
+	_, ok := floatOpcodeNames[opcode]
+	return ok
+}
+
+// walkInstructions decodes the instructions of an expression, calling visit
+// for each opcode, and stops after the "end" which closes the expression
+func walkInstructions(reader *byteReader, visit func(opcode uint32)) error {
+	depth := 0
+	for {
+		b, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		opcode := uint32(b)
+		if b == opcodePrefixMisc {
+			subOpcode, err := reader.readUint32()
+			if err != nil {
+				return err
+			}
+			opcode = miscOpcode(subOpcode)
+		}
+
+		visit(opcode)
+
+		switch {
+		case opcode == opcodeEnd:
+			depth--
+			if depth < 0 {
+				return nil
+			}
+		case opcode == opcodeBlock || opcode == opcodeLoop || opcode == opcodeIf:
+			depth++
+			err = skipBlockType(reader)
+		default:
+			err = skipImmediates(reader, opcode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func skipBlockType(reader *byteReader) error {
+	b, err := reader.peekByte()
+	if err != nil {
+		return err
+	}
+	if b == 0x40 || isValueType(b) {
+		reader.offset++
+		return nil
+	}
+
+	_, err = reader.readVarInt33()
+	return err
+}
+
+func skipImmediates(reader *byteReader, opcode uint32) error {
+	var err error
+	switch {
+	case opcode == opcodeBr || opcode == opcodeBrIf || opcode == opcodeCall || opcode == opcodeRefFunc:
+		_, err = reader.readUint32()
+	case opcode == opcodeBrTable:
+		err = skipUint32Vector(reader)
+		if err == nil {
+			_, err = reader.readUint32()
+		}
+	case opcode == opcodeCallIndirect:
+		err = skipUint32s(reader, 2)
+	case opcode == opcodeSelectTyped:
+		_, err = readValueTypes(reader)
+	case opcode >= opcodeLocalGet && opcode <= opcodeTableSet:
+		_, err = reader.readUint32()
+	case opcode >= opcodeLoadFirst && opcode <= opcodeStoreLast:
+		err = skipUint32s(reader, 2)
+	case opcode == opcodeMemorySize || opcode == opcodeMemoryGrow:
+		_, err = reader.readByte()
+	case opcode == opcodeI32Const:
+		_, err = reader.readVarInt32()
+	case opcode == opcodeI64Const:
+		_, err = reader.readVarInt64()
+	case opcode == opcodeF32Const:
+		err = reader.skip(4)
+	case opcode == opcodeF64Const:
+		err = reader.skip(8)
+	case opcode == opcodeRefNull:
+		_, err = reader.readByte()
+	case opcode == opcodePrefixSIMD:
+		err = fmt.Errorf("%w (SIMD instructions)", ErrUnsupportedOpcode)
+	case opcode>>8 == opcodePrefixMisc:
+		err = skipMiscImmediates(reader, opcode&0xff)
+	case isReservedOpcode(opcode):
+		err = fmt.Errorf("%w (0x%02x)", ErrUnsupportedOpcode, opcode)
+	}
+	return err
+}
+
+func isReservedOpcode(opcode uint32) bool {
+	return (opcode >= 0x06 && opcode <= 0x0a) ||
+		(opcode >= 0x12 && opcode <= 0x19) ||
+		(opcode >= 0x1d && opcode <= 0x1f) ||
+		opcode == 0x27 ||
+		(opcode >= 0xc5 && opcode <= 0xcf) ||
+		(opcode >= 0xd3 && opcode <= 0xff)
+}
+
+func skipMiscImmediates(reader *byteReader, subOpcode uint32) error {
+	switch {
+	case subOpcode <= 7:
+		return nil
+	case subOpcode == 8 || subOpcode == 10 || subOpcode == 12 || subOpcode == 14:
+		return skipUint32s(reader, 2)
+	case subOpcode <= 17:
+		return skipUint32s(reader, 1)
+	default:
+		return fmt.Errorf("%w (0xfc %d)", ErrUnsupportedOpcode, subOpcode)
+	}
+}
+
+func skipUint32s(reader *byteReader, count int) error {
+	for i := 0; i < count; i++ {
+		_, err := reader.readUint32()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipUint32Vector(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+	return skipUint32s(reader, int(count))
+}
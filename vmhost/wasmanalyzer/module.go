@@ -0,0 +1,672 @@
+package wasmanalyzer
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ValueType is a WebAssembly value type, as encoded in the binary format
+type ValueType byte
+
+const (
+	// ValueTypeI32 is the WebAssembly i32 type
+	ValueTypeI32 ValueType = 0x7f
+
+	// ValueTypeI64 is the WebAssembly i64 type
+	ValueTypeI64 ValueType = 0x7e
+
+	// ValueTypeF32 is the WebAssembly f32 type
+	ValueTypeF32 ValueType = 0x7d
+
+	// ValueTypeF64 is the WebAssembly f64 type
+	ValueTypeF64 ValueType = 0x7c
+
+	// ValueTypeV128 is the WebAssembly v128 type
+	ValueTypeV128 ValueType = 0x7b
+
+	// ValueTypeFuncRef is the WebAssembly funcref type
+	ValueTypeFuncRef ValueType = 0x70
+
+	// ValueTypeExternRef is the WebAssembly externref type
+	ValueTypeExternRef ValueType = 0x6f
+)
+
+// String returns the WebAssembly text format name of the value type
+func (valueType ValueType) String() string {
+	switch valueType {
+	case ValueTypeI32:
+		return "i32"
+	case ValueTypeI64:
+		return "i64"
+	case ValueTypeF32:
+		return "f32"
+	case ValueTypeF64:
+		return "f64"
+	case ValueTypeV128:
+		return "v128"
+	case ValueTypeFuncRef:
+		return "funcref"
+	case ValueTypeExternRef:
+		return "externref"
+	default:
+		return fmt.Sprintf("unknown(0x%02x)", byte(valueType))
+	}
+}
+
+func isValueType(b byte) bool {
+	switch ValueType(b) {
+	case ValueTypeI32, ValueTypeI64, ValueTypeF32, ValueTypeF64, ValueTypeV128, ValueTypeFuncRef, ValueTypeExternRef:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExternalKind is the kind of an imported or exported entity
+type ExternalKind byte
+
+const (
+	// ExternalFunction marks an imported or exported function
+	ExternalFunction ExternalKind = 0x00
+
+	// ExternalTable marks an imported or exported table
+	ExternalTable ExternalKind = 0x01
+
+	// ExternalMemory marks an imported or exported memory
+	ExternalMemory ExternalKind = 0x02
+
+	// ExternalGlobal marks an imported or exported global
+	ExternalGlobal ExternalKind = 0x03
+)
+
+// String returns the name of the external kind
+func (kind ExternalKind) String() string {
+	switch kind {
+	case ExternalFunction:
+		return "function"
+	case ExternalTable:
+		return "table"
+	case ExternalMemory:
+		return "memory"
+	case ExternalGlobal:
+		return "global"
+	default:
+		return fmt.Sprintf("unknown(0x%02x)", byte(kind))
+	}
+}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+// WASMPageSize is the size in bytes of a WebAssembly memory page
+const WASMPageSize = 65536
+
+var wasmPreamble = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// FunctionType is the signature of a function
+type FunctionType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+// String returns the signature in WebAssembly text format style
+func (functionType *FunctionType) String() string {
+	return fmt.Sprintf("%v -> %v", functionType.Params, functionType.Results)
+}
+
+// Equals returns true if both signatures have the same parameters and results
+func (functionType *FunctionType) Equals(other *FunctionType) bool {
+	return valueTypesEqual(functionType.Params, other.Params) &&
+		valueTypesEqual(functionType.Results, other.Results)
+}
+
+func valueTypesEqual(left []ValueType, right []ValueType) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Limits are the bounds of a table or memory
+type Limits struct {
+	Min    uint32
+	Max    uint32
+	HasMax bool
+}
+
+// Import is an entity imported by the module
+type Import struct {
+	Module    string
+	Name      string
+	Kind      ExternalKind
+	TypeIndex uint32
+}
+
+// Export is an entity exported by the module
+type Export struct {
+	Name  string
+	Kind  ExternalKind
+	Index uint32
+}
+
+// Function is a function defined by the module, together with its body
+type Function struct {
+	TypeIndex uint32
+	NumLocals uint64
+	Locals    []ValueType
+	Body      []byte
+}
+
+// Module is the decoded structure of a WebAssembly binary, as needed by the analyzer
+type Module struct {
+	Types         []*FunctionType
+	Imports       []*Import
+	Functions     []*Function
+	Tables        []Limits
+	Memories      []Limits
+	Exports       []*Export
+	GlobalInits   [][]byte
+	ElementInits  [][]byte
+	DataInits     [][]byte
+	DataSizes     []uint32
+	NumImportedFn int
+}
+
+// DecodeModule decodes the sections of a WebAssembly binary relevant to the analysis
+func DecodeModule(code []byte) (*Module, error) {
+	if len(code) < len(wasmPreamble) || !bytes.Equal(code[:len(wasmPreamble)], wasmPreamble) {
+		return nil, ErrInvalidMagic
+	}
+
+	module := &Module{}
+	reader := newByteReader(code[len(wasmPreamble):])
+	var functionTypeIndices []uint32
+	for !reader.isEmpty() {
+		sectionID, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		sectionSize, err := reader.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		sectionBytes, err := reader.readBytes(sectionSize)
+		if err != nil {
+			return nil, err
+		}
+
+		sectionReader := newByteReader(sectionBytes)
+		switch sectionID {
+		case sectionCustom, sectionStart, sectionDataCount:
+			continue
+		case sectionType:
+			err = module.decodeTypes(sectionReader)
+		case sectionImport:
+			err = module.decodeImports(sectionReader)
+		case sectionFunction:
+			functionTypeIndices, err = decodeFunctionDeclarations(sectionReader)
+		case sectionTable:
+			err = module.decodeTables(sectionReader)
+		case sectionMemory:
+			err = module.decodeMemories(sectionReader)
+		case sectionGlobal:
+			err = module.decodeGlobals(sectionReader)
+		case sectionExport:
+			err = module.decodeExports(sectionReader)
+		case sectionElement:
+			err = module.decodeElements(sectionReader)
+		case sectionCode:
+			err = module.decodeCode(sectionReader, functionTypeIndices)
+		case sectionData:
+			err = module.decodeData(sectionReader)
+		default:
+			err = fmt.Errorf("%w (unknown section %d)", ErrMalformedModule, sectionID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(module.Functions) != len(functionTypeIndices) {
+		return nil, fmt.Errorf("%w (function and code sections differ in length)", ErrMalformedModule)
+	}
+
+	return module, nil
+}
+
+// FunctionType returns the signature of a function from the function index space
+func (module *Module) FunctionType(functionIndex int) (*FunctionType, bool) {
+	var typeIndex uint32
+	if functionIndex < module.NumImportedFn {
+		importIndex := 0
+		for _, imp := range module.Imports {
+			if imp.Kind != ExternalFunction {
+				continue
+			}
+			if importIndex == functionIndex {
+				typeIndex = imp.TypeIndex
+				break
+			}
+			importIndex++
+		}
+	} else {
+		localIndex := functionIndex - module.NumImportedFn
+		if localIndex >= len(module.Functions) {
+			return nil, false
+		}
+		typeIndex = module.Functions[localIndex].TypeIndex
+	}
+
+	if int(typeIndex) >= len(module.Types) {
+		return nil, false
+	}
+	return module.Types[typeIndex], true
+}
+
+func (module *Module) decodeTypes(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		form, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if form != 0x60 {
+			return fmt.Errorf("%w (invalid function type form 0x%02x)", ErrMalformedModule, form)
+		}
+
+		params, err := readValueTypes(reader)
+		if err != nil {
+			return err
+		}
+		results, err := readValueTypes(reader)
+		if err != nil {
+			return err
+		}
+
+		module.Types = append(module.Types, &FunctionType{Params: params, Results: results})
+	}
+
+	return nil
+}
+
+func (module *Module) decodeImports(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		imp := &Import{}
+		imp.Module, err = reader.readName()
+		if err != nil {
+			return err
+		}
+		imp.Name, err = reader.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		imp.Kind = ExternalKind(kind)
+
+		switch imp.Kind {
+		case ExternalFunction:
+			imp.TypeIndex, err = reader.readUint32()
+			module.NumImportedFn++
+		case ExternalTable:
+			_, err = reader.readByte()
+			if err == nil {
+				_, err = readLimits(reader)
+			}
+		case ExternalMemory:
+			_, err = readLimits(reader)
+		case ExternalGlobal:
+			err = reader.skip(2)
+		default:
+			err = fmt.Errorf("%w (invalid import kind 0x%02x)", ErrMalformedModule, kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		module.Imports = append(module.Imports, imp)
+	}
+
+	return nil
+}
+
+func decodeFunctionDeclarations(reader *byteReader) ([]uint32, error) {
+	count, err := reader.readCount()
+	if err != nil {
+		return nil, err
+	}
+
+	typeIndices := make([]uint32, 0, count)
+	for i := uint32(0); i < count; i++ {
+		typeIndex, err := reader.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		typeIndices = append(typeIndices, typeIndex)
+	}
+
+	return typeIndices, nil
+}
+
+func (module *Module) decodeTables(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		_, err = reader.readByte()
+		if err != nil {
+			return err
+		}
+		limits, err := readLimits(reader)
+		if err != nil {
+			return err
+		}
+		module.Tables = append(module.Tables, limits)
+	}
+
+	return nil
+}
+
+func (module *Module) decodeMemories(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		limits, err := readLimits(reader)
+		if err != nil {
+			return err
+		}
+		module.Memories = append(module.Memories, limits)
+	}
+
+	return nil
+}
+
+func (module *Module) decodeGlobals(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		err = reader.skip(2)
+		if err != nil {
+			return err
+		}
+		initExpr, err := readConstantExpression(reader)
+		if err != nil {
+			return err
+		}
+		module.GlobalInits = append(module.GlobalInits, initExpr)
+	}
+
+	return nil
+}
+
+func (module *Module) decodeExports(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		exp := &Export{}
+		exp.Name, err = reader.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		exp.Kind = ExternalKind(kind)
+		exp.Index, err = reader.readUint32()
+		if err != nil {
+			return err
+		}
+
+		module.Exports = append(module.Exports, exp)
+	}
+
+	return nil
+}
+
+func (module *Module) decodeElements(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		flags, err := reader.readUint32()
+		if err != nil {
+			return err
+		}
+		if flags > 7 {
+			return fmt.Errorf("%w (invalid element segment flags %d)", ErrMalformedModule, flags)
+		}
+
+		isPassiveOrDeclarative := flags&0x01 != 0
+		hasTableIndex := flags&0x02 != 0
+		usesExpressions := flags&0x04 != 0
+
+		if !isPassiveOrDeclarative {
+			if hasTableIndex {
+				_, err = reader.readUint32()
+				if err != nil {
+					return err
+				}
+			}
+			offsetExpr, err := readConstantExpression(reader)
+			if err != nil {
+				return err
+			}
+			module.ElementInits = append(module.ElementInits, offsetExpr)
+		}
+
+		if isPassiveOrDeclarative || hasTableIndex {
+			_, err = reader.readByte()
+			if err != nil {
+				return err
+			}
+		}
+
+		numElements, err := reader.readUint32()
+		if err != nil {
+			return err
+		}
+		for j := uint32(0); j < numElements; j++ {
+			if usesExpressions {
+				elementExpr, err := readConstantExpression(reader)
+				if err != nil {
+					return err
+				}
+				module.ElementInits = append(module.ElementInits, elementExpr)
+				continue
+			}
+			_, err = reader.readUint32()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (module *Module) decodeCode(reader *byteReader, functionTypeIndices []uint32) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(functionTypeIndices) {
+		return fmt.Errorf("%w (function and code sections differ in length)", ErrMalformedModule)
+	}
+
+	for i := uint32(0); i < count; i++ {
+		size, err := reader.readUint32()
+		if err != nil {
+			return err
+		}
+		bodyBytes, err := reader.readBytes(size)
+		if err != nil {
+			return err
+		}
+
+		function := &Function{TypeIndex: functionTypeIndices[i]}
+		bodyReader := newByteReader(bodyBytes)
+		numLocalGroups, err := bodyReader.readUint32()
+		if err != nil {
+			return err
+		}
+		for j := uint32(0); j < numLocalGroups; j++ {
+			numLocals, err := bodyReader.readUint32()
+			if err != nil {
+				return err
+			}
+			localType, err := bodyReader.readByte()
+			if err != nil {
+				return err
+			}
+			function.NumLocals += uint64(numLocals)
+			function.Locals = append(function.Locals, ValueType(localType))
+		}
+		function.Body = bodyBytes[bodyReader.offset:]
+
+		module.Functions = append(module.Functions, function)
+	}
+
+	return nil
+}
+
+func (module *Module) decodeData(reader *byteReader) error {
+	count, err := reader.readUint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		flags, err := reader.readUint32()
+		if err != nil {
+			return err
+		}
+
+		switch flags {
+		case 0:
+		case 1:
+		case 2:
+			_, err = reader.readUint32()
+		default:
+			err = fmt.Errorf("%w (invalid data segment flags %d)", ErrMalformedModule, flags)
+		}
+		if err != nil {
+			return err
+		}
+
+		if flags != 1 {
+			offsetExpr, err := readConstantExpression(reader)
+			if err != nil {
+				return err
+			}
+			module.DataInits = append(module.DataInits, offsetExpr)
+		}
+
+		size, err := reader.readUint32()
+		if err != nil {
+			return err
+		}
+		err = reader.skip(size)
+		if err != nil {
+			return err
+		}
+		module.DataSizes = append(module.DataSizes, size)
+	}
+
+	return nil
+}
+
+func readValueTypes(reader *byteReader) ([]ValueType, error) {
+	count, err := reader.readCount()
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypes := make([]ValueType, 0, count)
+	for i := uint32(0); i < count; i++ {
+		b, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if !isValueType(b) {
+			return nil, fmt.Errorf("%w (invalid value type 0x%02x)", ErrMalformedModule, b)
+		}
+		valueTypes = append(valueTypes, ValueType(b))
+	}
+
+	return valueTypes, nil
+}
+
+func readLimits(reader *byteReader) (Limits, error) {
+	flags, err := reader.readByte()
+	if err != nil {
+		return Limits{}, err
+	}
+
+	limits := Limits{}
+	limits.Min, err = reader.readUint32()
+	if err != nil {
+		return Limits{}, err
+	}
+	if flags&0x01 != 0 {
+		limits.HasMax = true
+		limits.Max, err = reader.readUint32()
+		if err != nil {
+			return Limits{}, err
+		}
+	}
+
+	return limits, nil
+}
+
+// readConstantExpression returns the bytes of an initializer expression, up to and including its final "end"
+func readConstantExpression(reader *byteReader) ([]byte, error) {
+	start := reader.offset
+	err := walkInstructions(reader, func(opcode uint32) {})
+	if err != nil {
+		return nil, err
+	}
+	return reader.data[start:reader.offset], nil
+}
@@ -0,0 +1,142 @@
+package wasmanalyzer
+
+import "math"
+
+// byteReader reads the primitive encodings of the WebAssembly binary format
+type byteReader struct {
+	data   []byte
+	offset int
+}
+
+func newByteReader(data []byte) *byteReader {
+	return &byteReader{data: data}
+}
+
+func (reader *byteReader) isEmpty() bool {
+	return reader.offset >= len(reader.data)
+}
+
+func (reader *byteReader) readByte() (byte, error) {
+	if reader.isEmpty() {
+		return 0, ErrUnexpectedEnd
+	}
+	b := reader.data[reader.offset]
+	reader.offset++
+	return b, nil
+}
+
+func (reader *byteReader) peekByte() (byte, error) {
+	if reader.isEmpty() {
+		return 0, ErrUnexpectedEnd
+	}
+	return reader.data[reader.offset], nil
+}
+
+func (reader *byteReader) readBytes(length uint32) ([]byte, error) {
+	if uint64(reader.offset)+uint64(length) > uint64(len(reader.data)) {
+		return nil, ErrUnexpectedEnd
+	}
+	bytes := reader.data[reader.offset : reader.offset+int(length)]
+	reader.offset += int(length)
+	return bytes, nil
+}
+
+func (reader *byteReader) skip(length uint32) error {
+	_, err := reader.readBytes(length)
+	return err
+}
+
+func (reader *byteReader) readUint32() (uint32, error) {
+	value, err := reader.readUnsignedLEB128(32)
+	return uint32(value), err
+}
+
+// readCount reads the number of elements of a vector, which cannot exceed the remaining bytes,
+// since every element takes at least one byte; this bounds the memory allocated for the elements
+func (reader *byteReader) readCount() (uint32, error) {
+	count, err := reader.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(count) > uint64(len(reader.data)-reader.offset) {
+		return 0, ErrUnexpectedEnd
+	}
+	return count, nil
+}
+
+func (reader *byteReader) readVarInt32() (int32, error) {
+	value, err := reader.readSignedLEB128(32)
+	return int32(value), err
+}
+
+func (reader *byteReader) readVarInt33() (int64, error) {
+	return reader.readSignedLEB128(33)
+}
+
+func (reader *byteReader) readVarInt64() (int64, error) {
+	return reader.readSignedLEB128(64)
+}
+
+func (reader *byteReader) readUnsignedLEB128(bits uint) (uint64, error) {
+	var result uint64
+	var shift uint
+	for {
+		b, err := reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift >= bits {
+			return 0, ErrLEB128Overflow
+		}
+
+		result |= uint64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if bits < 64 && result > uint64(math.MaxUint64>>(64-bits)) {
+		return 0, ErrLEB128Overflow
+	}
+	return result, nil
+}
+
+func (reader *byteReader) readSignedLEB128(bits uint) (int64, error) {
+	var result int64
+	var shift uint
+	var b byte
+	var err error
+	for {
+		b, err = reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if shift >= bits {
+			return 0, ErrLEB128Overflow
+		}
+
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if shift < 64 && b&0x40 != 0 {
+		result |= -1 << shift
+	}
+	return result, nil
+}
+
+func (reader *byteReader) readName() (string, error) {
+	length, err := reader.readUint32()
+	if err != nil {
+		return "", err
+	}
+	name, err := reader.readBytes(length)
+	if err != nil {
+		return "", err
+	}
+	return string(name), nil
+}
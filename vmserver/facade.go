@@ -142,6 +142,33 @@ func (f *DebugFacade) QuerySmartContract(request QueryRequest) (*QueryResponse,
 	return response, err
 }
 
+// EstimateGas estimates the gas needed by a deployment or a contract call,
+// without altering the world
+func (f *DebugFacade) EstimateGas(request EstimateRequest) (*EstimateResponse, error) {
+	log.Debug("Debugf.EstimateGas()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response := world.estimateGas(request)
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
 // CreateAccount creates a test account
 func (f *DebugFacade) CreateAccount(request CreateAccountRequest) (*CreateAccountResponse, error) {
 	log.Debug("Debugf.CreateAccount()")
@@ -45,6 +45,23 @@ func TestFacade_RunContract_Counter(t *testing.T) {
 	require.Equal(t, []byte{2}, state["COUNTER"])
 }
 
+func TestFacade_EstimateGas_Counter(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+
+	estimation := context.estimateCall(contractAddressHex, alice.hex, "increment")
+	require.Greater(t, estimation.GasUsed, uint64(0))
+	require.GreaterOrEqual(t, estimation.MinimalGasLimit, estimation.GasUsed)
+	require.Less(t, estimation.MinimalGasLimit, uint64(gasLimit))
+
+	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(1), counterValue)
+}
+
 func TestFacade_RunContract_ERC20(t *testing.T) {
 	context := newTestContext(t)
 
@@ -0,0 +1,59 @@
+package vmserver
+
+// EstimateRequest is a CLI / REST request message; it estimates a deployment
+// when no contract is given, and a contract call otherwise
+type EstimateRequest struct {
+	DeployRequest
+	ContractAddressHex    string
+	ContractAddress       []byte
+	Function              string
+	SearchMinimalGasLimit bool
+}
+
+func (request *EstimateRequest) isDeploy() bool {
+	return len(request.ContractAddressHex) == 0
+}
+
+func (request *EstimateRequest) digest() error {
+	if request.isDeploy() {
+		return request.DeployRequest.digest()
+	}
+
+	err := request.ContractRequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	request.Arguments, err = decodeArguments(request.ArgumentsHex)
+	if err != nil {
+		return err
+	}
+
+	request.ContractAddress, err = fromHex(request.ContractAddressHex)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (request *EstimateRequest) toRunRequest() RunRequest {
+	return RunRequest{
+		ContractRequestBase: request.ContractRequestBase,
+		ContractAddressHex:  request.ContractAddressHex,
+		ContractAddress:     request.ContractAddress,
+		Function:            request.Function,
+		ArgumentsHex:        request.ArgumentsHex,
+		Arguments:           request.Arguments,
+	}
+}
+
+// EstimateResponse is a CLI / REST response message
+type EstimateResponse struct {
+	ContractResponseBase
+	GasUsed         uint64
+	GasForwarded    uint64
+	GasLocked       uint64
+	BuiltInFuncCost uint64
+	MinimalGasLimit uint64
+}
@@ -0,0 +1,47 @@
+package vmserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEstimateRequest(contractAddressHex string, function string) *EstimateRequest {
+	return &EstimateRequest{
+		DeployRequest: DeployRequest{
+			ContractRequestBase: ContractRequestBase{
+				ImpersonatedHex: "abba",
+				GasLimit:        gasLimit,
+			},
+			CodeHex: "0061736d",
+		},
+		ContractAddressHex: contractAddressHex,
+		Function:           function,
+	}
+}
+
+func Test_EstimateRequest_Deploy(t *testing.T) {
+	// the function is irrelevant without a contract
+	request := newTestEstimateRequest("", "increment")
+	require.True(t, request.isDeploy())
+
+	err := request.digest()
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x00, 0x61, 0x73, 0x6d}, request.Code)
+	require.Nil(t, request.ContractAddress)
+}
+
+func Test_EstimateRequest_Call(t *testing.T) {
+	// a call is estimated whenever there is a contract, even without a function
+	request := newTestEstimateRequest("c0de", "")
+	require.False(t, request.isDeploy())
+
+	err := request.digest()
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xc0, 0xde}, request.ContractAddress)
+	require.Nil(t, request.Code)
+
+	runRequest := request.toRunRequest()
+	require.Equal(t, "c0de", runRequest.ContractAddressHex)
+	require.Equal(t, "", runRequest.Function)
+}
@@ -150,6 +150,33 @@ func (context *testContext) queryContract(contract string, impersonated string,
 	return response
 }
 
+func (context *testContext) estimateCall(contract string, impersonated string, function string, arguments ...string) *EstimateResponse {
+	request := EstimateRequest{
+		DeployRequest: DeployRequest{
+			ContractRequestBase: ContractRequestBase{
+				RequestBase:     context.createRequestBase(),
+				ImpersonatedHex: impersonated,
+				GasLimit:        gasLimit,
+			},
+			ArgumentsHex: arguments,
+		},
+		ContractAddressHex:    contract,
+		Function:              function,
+		SearchMinimalGasLimit: true,
+	}
+
+	response, err := context.facade.EstimateGas(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	require.NotNil(t, response.Output)
+	require.Nil(t, response.Error)
+	require.Equal(t, vmcommon.Ok.String(), response.ReturnCodeString, response.Output.ReturnMessage)
+
+	return response
+}
+
 func (response *ContractResponseBase) getFirstResultAsInt64() int64 {
 	result, err := response.Output.GetFirstReturnData(vm.AsBigInt)
 	if err != nil {
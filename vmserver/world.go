@@ -21,7 +21,7 @@ type worldDataModel struct {
 type world struct {
 	id             string
 	blockchainHook *worldmock.MockWorld
-	vm             vmcommon.VMExecutionHandler
+	vm             vmhost.VMHost
 }
 
 func newWorldDataModel(worldID string) *worldDataModel {
@@ -128,6 +128,43 @@ func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	return response
 }
 
+func (w *world) estimateGas(request EstimateRequest) *EstimateResponse {
+	response := &EstimateResponse{}
+	estimator, err := hostCore.NewGasEstimator(w.vm)
+	if err != nil {
+		response.Error = err
+		return response
+	}
+
+	var vmInput *vmcommon.VMInput
+	var estimation *hostCore.GasEstimation
+	if request.isDeploy() {
+		input := w.prepareDeployInput(request.DeployRequest)
+		log.Trace("w.estimateGas()", "input", prettyJson(input))
+		vmInput = &input.VMInput
+		estimation, err = estimator.EstimateCreate(input, request.SearchMinimalGasLimit)
+	} else {
+		input := w.prepareCallInput(request.toRunRequest())
+		log.Trace("w.estimateGas()", "input", prettyJson(input))
+		vmInput = &input.VMInput
+		estimation, err = estimator.EstimateCall(input, request.SearchMinimalGasLimit)
+	}
+	if err != nil {
+		response.ContractResponseBase = createContractResponseBase(vmInput, nil)
+		response.Error = err
+		return response
+	}
+
+	response.ContractResponseBase = createContractResponseBase(vmInput, estimation.VMOutput)
+	response.GasUsed = estimation.GasUsed
+	response.GasForwarded = estimation.GasForwarded
+	response.GasLocked = estimation.GasLocked
+	response.BuiltInFuncCost = estimation.BuiltInFuncCost
+	response.MinimalGasLimit = estimation.MinimalGasLimit
+
+	return response
+}
+
 func (w *world) createAccount(request CreateAccountRequest) *CreateAccountResponse {
 	log.Trace("w.createAccount()", "request", prettyJson(request))
 
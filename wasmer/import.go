@@ -217,3 +217,36 @@ func (instanceContext *InstanceContext) Memory() MemoryHandler {
 func (instanceContext *InstanceContext) Data() unsafe.Pointer {
 	return cWasmerInstanceContextDataGet(instanceContext.context)
 }
+
+// ImportedFunctionSignature holds the WebAssembly value types of the inputs
+// and of the outputs of an imported function
+type ImportedFunctionSignature struct {
+	InputTypes  []ValueType
+	OutputTypes []ValueType
+}
+
+// Signatures returns the signatures of all the imported functions, indexed by name
+func (imports *Imports) Signatures() map[string]*ImportedFunctionSignature {
+	signatures := make(map[string]*ImportedFunctionSignature)
+	for _, namespacedImports := range imports.imports {
+		for name, importFunction := range namespacedImports {
+			signatures[name] = &ImportedFunctionSignature{
+				InputTypes:  valueTypesFromTags(importFunction.wasmInputs),
+				OutputTypes: valueTypesFromTags(importFunction.wasmOutputs),
+			}
+		}
+	}
+	return signatures
+}
+
+func valueTypesFromTags(tags []cWasmerValueTag) []ValueType {
+	valueTypes := make([]ValueType, len(tags))
+	for i, tag := range tags {
+		if tag == cWasmI64 {
+			valueTypes[i] = TypeI64
+			continue
+		}
+		valueTypes[i] = TypeI32
+	}
+	return valueTypes
+}